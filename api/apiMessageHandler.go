@@ -64,6 +64,8 @@ func (handler *gaugeAPIMessageHandler) MessageBytesReceived(bytesRead []byte, co
 			responseMessage = handler.extractConcept(apiMessage)
 		case gauge_messages.APIMessage_FormatSpecsRequest:
 			responseMessage = handler.formatSpecs(apiMessage)
+		// Inlining a concept and parameterizing steps are code actions of the language server only, as gauge-proto has
+		// no api message types for them yet. Requests of other types get an unsupported response.
 		default:
 			responseMessage = handler.createUnsupportedAPIMessageResponse(apiMessage)
 		}
@@ -0,0 +1,228 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package dap
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/gauge"
+)
+
+type stepMode int
+
+const (
+	runToBreakpoint stepMode = iota
+	stepIn
+	stepOver
+	stepOut
+)
+
+const (
+	threadID             = 1
+	dataTableRowScope    = 1
+	stopReasonEntry      = "entry"
+	stopReasonStep       = "step"
+	stopReasonBreakpoint = "breakpoint"
+)
+
+// debugger decides where the execution pauses and describes the paused execution.
+// beforeStep is called from the execution, all other methods from the requests of the client.
+type debugger struct {
+	mu          sync.Mutex
+	breakpoints map[string]map[int]bool
+	mode        stepMode
+	depth       int
+	entry       bool
+	detached    bool
+	// invocations are the step being executed and the concept invocations it is nested in, outermost first.
+	invocations []*execution.PausePoint
+	paused      bool
+	resume      chan struct{}
+	conceptFile func(stepValue string) string
+	stopped     func(reason string)
+}
+
+func newDebugger(conceptFile func(string) string, stopped func(string)) *debugger {
+	return &debugger{breakpoints: make(map[string]map[int]bool), conceptFile: conceptFile, stopped: stopped}
+}
+
+func (d *debugger) setBreakpoints(file string, lines []int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.breakpoints[filepath.Clean(file)] = make(map[int]bool)
+	for _, line := range lines {
+		d.breakpoints[filepath.Clean(file)][line] = true
+	}
+}
+
+func (d *debugger) stopOnEntry() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mode, d.entry = stepIn, true
+}
+
+func (d *debugger) beforeStep(p *execution.PausePoint) {
+	d.mu.Lock()
+	depth := len(p.Concepts)
+	if depth > len(d.invocations) {
+		depth = len(d.invocations)
+	}
+	d.invocations = append(d.invocations[:depth], p)
+	reason := d.stopReason(d.fileOf(len(d.invocations)-1), p.Step.LineNo, len(p.Concepts))
+	if reason == "" {
+		d.mu.Unlock()
+		return
+	}
+	d.paused, d.entry = true, false
+	d.resume = make(chan struct{})
+	resume := d.resume
+	d.mu.Unlock()
+	d.stopped(reason)
+	<-resume
+}
+
+func (d *debugger) stopReason(file string, line, depth int) string {
+	if d.detached {
+		return ""
+	}
+	if d.breakpoints[filepath.Clean(file)][line] {
+		return stopReasonBreakpoint
+	}
+	stop := d.mode == stepIn || (d.mode == stepOver && depth <= d.depth) || (d.mode == stepOut && depth < d.depth)
+	if !stop {
+		return ""
+	}
+	if d.entry {
+		return stopReasonEntry
+	}
+	return stopReasonStep
+}
+
+// proceed resumes a paused execution, pausing again as per the given mode.
+func (d *debugger) proceed(mode stepMode) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.paused {
+		return
+	}
+	d.mode, d.depth, d.paused = mode, len(d.invocations)-1, false
+	close(d.resume)
+}
+
+// detach lets the execution run to completion without pausing anymore.
+func (d *debugger) detach() {
+	d.mu.Lock()
+	d.detached = true
+	d.mu.Unlock()
+	d.proceed(runToBreakpoint)
+}
+
+// fileOf returns the file of the invocation at the given index, which is the concept file of the concept it is nested in.
+func (d *debugger) fileOf(i int) string {
+	if i == 0 {
+		return d.invocations[0].SpecFile
+	}
+	if file := d.conceptFile(d.invocations[i-1].Step.Value); file != "" {
+		return file
+	}
+	return d.invocations[i].SpecFile
+}
+
+// stackFrames lists the paused step, the concept invocations it is nested in and the scenario, innermost first.
+// The id of a frame is its index in the list, counting from 1.
+func (d *debugger) stackFrames() []stackFrame {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	frames := make([]stackFrame, 0)
+	if !d.paused {
+		return frames
+	}
+	for i := len(d.invocations) - 1; i >= 0; i-- {
+		step := d.invocations[i].Step
+		frames = append(frames, stackFrame{ID: len(frames) + 1, Name: step.LineText, Source: sourceOf(d.fileOf(i)), Line: step.LineNo, Column: 1})
+	}
+	if scenario := d.invocations[0].Scenario; scenario != nil && scenario.Heading != nil {
+		frames = append(frames, stackFrame{ID: len(frames) + 1, Name: scenario.Heading.Value, Source: sourceOf(d.invocations[0].SpecFile), Line: scenario.Heading.LineNo, Column: 1})
+	}
+	return frames
+}
+
+// scopes of a step frame are its parameters and the data table row, the scenario frame has only the data table row.
+// Parameters of a frame are referred by the frame id shifted past the data table row reference.
+func (d *debugger) scopes(frameID int) []scope {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	scopes := make([]scope, 0)
+	if !d.paused {
+		return scopes
+	}
+	if frameID >= 1 && frameID <= len(d.invocations) {
+		scopes = append(scopes, scope{Name: "Parameters", VariablesReference: dataTableRowScope + frameID})
+	}
+	if len(d.dataTableRow()) > 0 {
+		scopes = append(scopes, scope{Name: "Data table row", VariablesReference: dataTableRowScope})
+	}
+	return scopes
+}
+
+func (d *debugger) variables(ref int) []variable {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	variables := make([]variable, 0)
+	if !d.paused {
+		return variables
+	}
+	if ref == dataTableRowScope {
+		return append(variables, d.dataTableRow()...)
+	}
+	i := len(d.invocations) - (ref - dataTableRowScope)
+	if i < 0 || i >= len(d.invocations) {
+		return variables
+	}
+	for n, p := range d.invocations[i].Parameters {
+		variables = append(variables, parameterVariable(n, p))
+	}
+	return variables
+}
+
+func (d *debugger) dataTableRow() []variable {
+	var variables []variable
+	scenario := d.invocations[0].Scenario
+	if scenario == nil {
+		return variables
+	}
+	for _, table := range []gauge.Table{scenario.SpecDataTableRow, scenario.ScenarioDataTableRow} {
+		rows := table.Rows()
+		if len(rows) == 0 {
+			continue
+		}
+		for i, header := range table.Headers {
+			variables = append(variables, variable{Name: header, Value: rows[0][i]})
+		}
+	}
+	return variables
+}
+
+func parameterVariable(n int, p *gm.Parameter) variable {
+	name := fmt.Sprintf("param %d", n+1)
+	if p.GetParameterType() != gm.Parameter_Static && p.GetParameterType() != gm.Parameter_Table && p.GetName() != "" {
+		name = fmt.Sprintf("<%s>", p.GetName())
+	}
+	value := p.GetValue()
+	if p.GetTable() != nil {
+		value = fmt.Sprintf("table with %d rows", len(p.GetTable().GetRows()))
+	}
+	return variable{Name: name, Value: value}
+}
+
+func sourceOf(file string) source {
+	return source{Name: filepath.Base(file), Path: file}
+}
@@ -0,0 +1,152 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package dap
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/gauge"
+)
+
+var (
+	specFile    = filepath.Join("specs", "login.spec")
+	conceptFile = filepath.Join("concepts", "login.cpt")
+)
+
+// loginScenario runs a scenario of a concept with a step, followed by a step, for the data table row of john.
+func loginScenario() []*execution.PausePoint {
+	table := gauge.NewTable([]string{"user"}, [][]gauge.TableCell{{gauge.GetTableCell("john")}}, 1)
+	scenario := &gauge.Scenario{Heading: &gauge.Heading{Value: "Login", LineNo: 6}, SpecDataTableRow: *table}
+	concept := &gauge.Step{Value: "login as {}", LineText: "login as <user>", LineNo: 7, IsConcept: true}
+	user := &gm.Parameter{ParameterType: gm.Parameter_Dynamic, Name: "user", Value: "john"}
+	return []*execution.PausePoint{
+		{Step: concept, Parameters: []*gm.Parameter{user}, Scenario: scenario, SpecFile: specFile},
+		{Step: &gauge.Step{Value: "enter user {}", LineText: "enter user <name>", LineNo: 2}, Parameters: []*gm.Parameter{{ParameterType: gm.Parameter_Dynamic, Name: "name", Value: "john"}}, Concepts: []*gauge.Step{concept}, Scenario: scenario, SpecFile: specFile},
+		{Step: &gauge.Step{Value: "open dashboard", LineText: "open dashboard", LineNo: 8}, Scenario: scenario, SpecFile: specFile},
+	}
+}
+
+type stop struct {
+	reason string
+	line   int
+}
+
+// debug runs the pause points through the debugger, answering every pause with the next of the given modes.
+func debug(d *debugger, modes ...stepMode) []stop {
+	stops := make(chan stop)
+	d.stopped = func(reason string) {
+		stops <- stop{reason: reason, line: d.invocations[len(d.invocations)-1].Step.LineNo}
+	}
+	done := make(chan bool)
+	go func() {
+		for _, p := range loginScenario() {
+			d.beforeStep(p)
+		}
+		close(done)
+	}()
+	var got []stop
+	for {
+		select {
+		case s := <-stops:
+			got = append(got, s)
+			mode := runToBreakpoint
+			if len(modes) > 0 {
+				mode, modes = modes[0], modes[1:]
+			}
+			d.proceed(mode)
+		case <-done:
+			return got
+		}
+	}
+}
+
+func newTestDebugger() *debugger {
+	return newDebugger(func(value string) string {
+		if value == "login as {}" {
+			return conceptFile
+		}
+		return ""
+	}, nil)
+}
+
+func TestDebuggerStopsAtBreakpointsInSpecAndConceptFiles(t *testing.T) {
+	d := newTestDebugger()
+	d.setBreakpoints(conceptFile, []int{2})
+	d.setBreakpoints(specFile, []int{8})
+
+	got := debug(d)
+
+	want := []stop{{stopReasonBreakpoint, 2}, {stopReasonBreakpoint, 8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong stops.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestDebuggerStepsOverConcepts(t *testing.T) {
+	d := newTestDebugger()
+	d.stopOnEntry()
+
+	got := debug(d, stepOver, stepOver)
+
+	want := []stop{{stopReasonEntry, 7}, {stopReasonStep, 8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong stops.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestDebuggerStepsIntoConcepts(t *testing.T) {
+	d := newTestDebugger()
+	d.stopOnEntry()
+
+	got := debug(d, stepIn, stepOut)
+
+	want := []stop{{stopReasonEntry, 7}, {stopReasonStep, 2}, {stopReasonStep, 8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong stops.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestDebuggerDescribesPausedStep(t *testing.T) {
+	d := newTestDebugger()
+	d.setBreakpoints(conceptFile, []int{2})
+	d.stopped = func(string) {}
+	points := loginScenario()
+	d.beforeStep(points[0])
+	go d.beforeStep(points[1])
+	for paused := false; !paused; {
+		d.mu.Lock()
+		paused = d.paused
+		d.mu.Unlock()
+	}
+	defer d.detach()
+
+	wantFrames := []stackFrame{
+		{ID: 1, Name: "enter user <name>", Source: source{Name: "login.cpt", Path: conceptFile}, Line: 2, Column: 1},
+		{ID: 2, Name: "login as <user>", Source: source{Name: "login.spec", Path: specFile}, Line: 7, Column: 1},
+		{ID: 3, Name: "Login", Source: source{Name: "login.spec", Path: specFile}, Line: 6, Column: 1},
+	}
+	if got := d.stackFrames(); !reflect.DeepEqual(got, wantFrames) {
+		t.Errorf("Wrong stack frames.\ngot:  %v\nwant: %v", got, wantFrames)
+	}
+	wantScopes := []scope{{Name: "Parameters", VariablesReference: 3}, {Name: "Data table row", VariablesReference: dataTableRowScope}}
+	if got := d.scopes(2); !reflect.DeepEqual(got, wantScopes) {
+		t.Errorf("Wrong scopes.\ngot:  %v\nwant: %v", got, wantScopes)
+	}
+	if got := d.variables(3); !reflect.DeepEqual(got, []variable{{Name: "<user>", Value: "john"}}) {
+		t.Errorf("Wrong parameters of concept invocation, got: %v", got)
+	}
+	if got := d.variables(2); !reflect.DeepEqual(got, []variable{{Name: "<name>", Value: "john"}}) {
+		t.Errorf("Wrong parameters of step, got: %v", got)
+	}
+	if got := d.variables(dataTableRowScope); !reflect.DeepEqual(got, []variable{{Name: "user", Value: "john"}}) {
+		t.Errorf("Wrong data table row, got: %v", got)
+	}
+}
@@ -0,0 +1,175 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+type request struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type response struct {
+	Seq        int         `json:"seq"`
+	Type       string      `json:"type"`
+	RequestSeq int         `json:"request_seq"`
+	Command    string      `json:"command"`
+	Success    bool        `json:"success"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+type event struct {
+	Seq   int         `json:"seq"`
+	Type  string      `json:"type"`
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+type capabilities struct {
+	SupportsConfigurationDoneRequest bool `json:"supportsConfigurationDoneRequest"`
+}
+
+type launchArguments struct {
+	Specs       []string `json:"specs"`
+	NoDebug     bool     `json:"noDebug"`
+	StopOnEntry bool     `json:"stopOnEntry"`
+}
+
+type source struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path"`
+}
+
+type sourceBreakpoint struct {
+	Line int `json:"line"`
+}
+
+type setBreakpointsArguments struct {
+	Source      source             `json:"source"`
+	Breakpoints []sourceBreakpoint `json:"breakpoints"`
+}
+
+type breakpoint struct {
+	Verified bool   `json:"verified"`
+	Line     int    `json:"line"`
+	Message  string `json:"message,omitempty"`
+}
+
+type breakpointsBody struct {
+	Breakpoints []breakpoint `json:"breakpoints"`
+}
+
+type thread struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type threadsBody struct {
+	Threads []thread `json:"threads"`
+}
+
+type stackFrame struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Source source `json:"source"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+type stackTraceBody struct {
+	StackFrames []stackFrame `json:"stackFrames"`
+	TotalFrames int          `json:"totalFrames"`
+}
+
+type scopesArguments struct {
+	FrameID int `json:"frameId"`
+}
+
+type scope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+type scopesBody struct {
+	Scopes []scope `json:"scopes"`
+}
+
+type variablesArguments struct {
+	VariablesReference int `json:"variablesReference"`
+}
+
+type variable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+type variablesBody struct {
+	Variables []variable `json:"variables"`
+}
+
+type stoppedEventBody struct {
+	Reason            string `json:"reason"`
+	ThreadID          int    `json:"threadId"`
+	AllThreadsStopped bool   `json:"allThreadsStopped"`
+}
+
+type exitedEventBody struct {
+	ExitCode int `json:"exitCode"`
+}
+
+type continueBody struct {
+	AllThreadsContinued bool `json:"allThreadsContinued"`
+}
+
+func readRequest(r *bufio.Reader) (*request, error) {
+	content, err := readContent(r)
+	if err != nil {
+		return nil, err
+	}
+	req := &request{}
+	if err := json.Unmarshal(content, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// readContent reads a message framed by a Content-Length header, the same way as the language server protocol.
+func readContent(r *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %s", err.Error())
+	}
+	content := make([]byte, length)
+	_, err = io.ReadFull(r, content)
+	return content, err
+}
+
+func writeMessage(w io.Writer, m interface{}) error {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	return err
+}
@@ -0,0 +1,243 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+)
+
+// session serves the requests of a debugging client, running the specs with a debugger attached.
+type session struct {
+	rw       io.ReadWriter
+	mu       sync.Mutex
+	seq      int
+	specDirs []string
+	launch   launchArguments
+	debugger *debugger
+	execute  func(specDirs []string) int
+	running  sync.WaitGroup
+}
+
+// Start listens for a debugging client on the given port, or on a free port if none is given,
+// and serves it till it disconnects. The language runner is debugged separately with its own debugger.
+func Start(port string, specDirs []string) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%s", port))
+	if err != nil {
+		logger.Fatalf(true, "Failed to start debug adapter. %s", err.Error())
+	}
+	defer listener.Close()
+	logger.Infof(true, "Listening on port:%d", listener.Addr().(*net.TCPAddr).Port)
+	conn, err := listener.Accept()
+	if err != nil {
+		logger.Fatalf(true, "Failed to accept debugging client. %s", err.Error())
+	}
+	defer conn.Close()
+	s := newSession(conn, specDirs, execution.ExecuteSpecs, conceptFiles())
+	s.serve()
+	s.running.Wait()
+}
+
+func newSession(rw io.ReadWriter, specDirs []string, execute func([]string) int, conceptFile func(string) string) *session {
+	s := &session{rw: rw, specDirs: specDirs, execute: execute}
+	s.debugger = newDebugger(conceptFile, func(reason string) {
+		s.sendEvent("stopped", stoppedEventBody{Reason: reason, ThreadID: threadID, AllThreadsStopped: true})
+	})
+	return s
+}
+
+// conceptFiles maps the value of a concept to the file which defines it.
+func conceptFiles() func(string) string {
+	dictionary, _, err := parser.ParseConcepts()
+	if err != nil {
+		logger.Errorf(false, "Unable to parse concepts: %s", err.Error())
+		return func(string) string { return "" }
+	}
+	return func(stepValue string) string {
+		if concept := dictionary.Search(stepValue); concept != nil {
+			return concept.FileName
+		}
+		return ""
+	}
+}
+
+func (s *session) serve() {
+	reader := bufio.NewReader(s.rw)
+	for {
+		req, err := readRequest(reader)
+		if err != nil {
+			if err != io.EOF {
+				logger.Errorf(false, "Failed to read debug adapter request: %s", err.Error())
+			}
+			s.debugger.detach()
+			return
+		}
+		body, err := s.handle(req)
+		s.respond(req, body, err)
+		switch req.Command {
+		case "initialize":
+			s.sendEvent("initialized", nil)
+		case "disconnect":
+			return
+		}
+	}
+}
+
+func (s *session) handle(req *request) (interface{}, error) {
+	switch req.Command {
+	case "initialize":
+		return capabilities{SupportsConfigurationDoneRequest: true}, nil
+	case "launch":
+		if err := unmarshalArguments(req, &s.launch); err != nil {
+			return nil, err
+		}
+		if s.launch.StopOnEntry {
+			s.debugger.stopOnEntry()
+		}
+		return nil, nil
+	case "setBreakpoints":
+		var args setBreakpointsArguments
+		if err := unmarshalArguments(req, &args); err != nil {
+			return nil, err
+		}
+		return s.setBreakpoints(args), nil
+	case "configurationDone":
+		s.running.Add(1)
+		go s.run()
+		return nil, nil
+	case "threads":
+		return threadsBody{Threads: []thread{{ID: threadID, Name: "Execution"}}}, nil
+	case "stackTrace":
+		frames := s.debugger.stackFrames()
+		return stackTraceBody{StackFrames: frames, TotalFrames: len(frames)}, nil
+	case "scopes":
+		var args scopesArguments
+		if err := unmarshalArguments(req, &args); err != nil {
+			return nil, err
+		}
+		return scopesBody{Scopes: s.debugger.scopes(args.FrameID)}, nil
+	case "variables":
+		var args variablesArguments
+		if err := unmarshalArguments(req, &args); err != nil {
+			return nil, err
+		}
+		return variablesBody{Variables: s.debugger.variables(args.VariablesReference)}, nil
+	case "continue":
+		s.debugger.proceed(runToBreakpoint)
+		return continueBody{AllThreadsContinued: true}, nil
+	case "next":
+		s.debugger.proceed(stepOver)
+		return nil, nil
+	case "stepIn":
+		s.debugger.proceed(stepIn)
+		return nil, nil
+	case "stepOut":
+		s.debugger.proceed(stepOut)
+		return nil, nil
+	case "disconnect":
+		s.debugger.detach()
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported request: %s", req.Command)
+}
+
+// run executes the launched specs, or the spec directories of the project when none are given.
+func (s *session) run() {
+	defer s.running.Done()
+	specDirs := s.specDirs
+	if len(s.launch.Specs) > 0 {
+		specDirs = s.launch.Specs
+	}
+	if s.launch.NoDebug {
+		s.debugger.detach()
+	}
+	execution.AttachDebugger(s.debugger.beforeStep)
+	exitCode := s.execute(specDirs)
+	execution.DetachDebugger()
+	s.sendEvent("exited", exitedEventBody{ExitCode: exitCode})
+	s.sendEvent("terminated", nil)
+}
+
+// setBreakpoints verifies the breakpoints of a file, which are valid only on steps.
+func (s *session) setBreakpoints(args setBreakpointsArguments) breakpointsBody {
+	stepLines := stepLinesOf(args.Source.Path)
+	var lines []int
+	body := breakpointsBody{Breakpoints: make([]breakpoint, 0)}
+	for _, b := range args.Breakpoints {
+		if stepLines[b.Line] {
+			lines = append(lines, b.Line)
+			body.Breakpoints = append(body.Breakpoints, breakpoint{Verified: true, Line: b.Line})
+			continue
+		}
+		body.Breakpoints = append(body.Breakpoints, breakpoint{Line: b.Line, Message: "Breakpoints can be set only on steps"})
+	}
+	s.debugger.setBreakpoints(args.Source.Path, lines)
+	return body
+}
+
+func stepLinesOf(file string) map[int]bool {
+	lines := make(map[int]bool)
+	content, err := common.ReadFileContents(file)
+	if err != nil {
+		return lines
+	}
+	if util.IsConcept(file) {
+		concepts, _ := new(parser.ConceptParser).Parse(content, file)
+		for _, concept := range concepts {
+			for _, step := range concept.ConceptSteps {
+				lines[step.LineNo] = true
+			}
+		}
+		return lines
+	}
+	spec, _ := new(parser.SpecParser).ParseSpecText(content, file)
+	for _, item := range spec.AllItems() {
+		if item.Kind() == gauge.StepKind {
+			lines[item.(*gauge.Step).LineNo] = true
+		}
+	}
+	return lines
+}
+
+func (s *session) respond(req *request, body interface{}, err error) {
+	res := response{Type: "response", RequestSeq: req.Seq, Command: req.Command, Success: err == nil, Body: body}
+	if err != nil {
+		res.Message = err.Error()
+	}
+	s.send(func(seq int) interface{} { res.Seq = seq; return res })
+}
+
+func (s *session) sendEvent(name string, body interface{}) {
+	s.send(func(seq int) interface{} { return event{Seq: seq, Type: "event", Event: name, Body: body} })
+}
+
+func (s *session) send(m func(seq int) interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	if err := writeMessage(s.rw, m(s.seq)); err != nil {
+		logger.Errorf(false, "Failed to write debug adapter message: %s", err.Error())
+	}
+}
+
+func unmarshalArguments(req *request, v interface{}) error {
+	if len(req.Arguments) == 0 {
+		return nil
+	}
+	return json.Unmarshal(req.Arguments, v)
+}
@@ -0,0 +1,142 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/execution"
+)
+
+const debuggedSpec = "# Login spec\n\n|user|\n|----|\n|john|\n## Login\n* login as <user>\n* open dashboard\n"
+
+type received struct {
+	Type    string          `json:"type"`
+	Command string          `json:"command"`
+	Success bool            `json:"success"`
+	Event   string          `json:"event"`
+	Body    json.RawMessage `json:"body"`
+}
+
+type testClient struct {
+	t       *testing.T
+	conn    net.Conn
+	reader  *bufio.Reader
+	seq     int
+	pending []received
+}
+
+func (c *testClient) request(command string, arguments interface{}) {
+	c.seq++
+	args, _ := json.Marshal(arguments)
+	if err := writeMessage(c.conn, request{Seq: c.seq, Type: "request", Command: command, Arguments: args}); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// expect returns the response to the command, or the event, with the given name.
+// Messages received meanwhile are kept, since events of the execution can arrive before the response which started it.
+func (c *testClient) expect(kind, name string) received {
+	for i, m := range c.pending {
+		if m.Type == kind && (m.Command == name || m.Event == name) {
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return m
+		}
+	}
+	for {
+		content, err := readContent(c.reader)
+		if err != nil {
+			c.t.Fatalf("Expected %s %s, got error: %s", kind, name, err.Error())
+		}
+		var m received
+		if err := json.Unmarshal(content, &m); err != nil {
+			c.t.Fatal(err)
+		}
+		if m.Type == kind && (m.Command == name || m.Event == name) {
+			return m
+		}
+		c.pending = append(c.pending, m)
+	}
+}
+
+func (c *testClient) call(command string, arguments interface{}, body interface{}) {
+	c.request(command, arguments)
+	res := c.expect("response", command)
+	if !res.Success {
+		c.t.Fatalf("Request %s failed: %s", command, string(res.Body))
+	}
+	if body != nil {
+		if err := json.Unmarshal(res.Body, body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+}
+
+func TestSessionPausesExecutionAtBreakpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gauge-dap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	spec := filepath.Join(dir, "login.spec")
+	if err := ioutil.WriteFile(spec, []byte(debuggedSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var executed []string
+	execute := func(specDirs []string) int {
+		executed = specDirs
+		for _, p := range loginScenario() {
+			p.SpecFile = spec
+			execution.BeforeStep(p)
+		}
+		return 0
+	}
+	server, conn := net.Pipe()
+	s := newSession(server, []string{"specs"}, execute, func(string) string { return "" })
+	served := make(chan bool)
+	go func() {
+		s.serve()
+		close(served)
+	}()
+	c := &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+
+	c.call("initialize", map[string]string{"adapterID": "gauge"}, nil)
+	c.expect("event", "initialized")
+	c.call("launch", launchArguments{Specs: []string{spec}}, nil)
+	var breakpoints breakpointsBody
+	c.call("setBreakpoints", setBreakpointsArguments{Source: source{Path: spec}, Breakpoints: []sourceBreakpoint{{Line: 6}, {Line: 8}}}, &breakpoints)
+	c.call("configurationDone", nil, nil)
+	c.expect("event", "stopped")
+	var trace stackTraceBody
+	c.call("stackTrace", map[string]int{"threadId": threadID}, &trace)
+	c.call("continue", map[string]int{"threadId": threadID}, nil)
+	c.expect("event", "exited")
+	c.expect("event", "terminated")
+	c.call("disconnect", nil, nil)
+	<-served
+
+	wantBreakpoints := []breakpoint{{Line: 6, Message: "Breakpoints can be set only on steps"}, {Verified: true, Line: 8}}
+	if !reflect.DeepEqual(breakpoints.Breakpoints, wantBreakpoints) {
+		t.Errorf("Wrong breakpoints.\ngot:  %v\nwant: %v", breakpoints.Breakpoints, wantBreakpoints)
+	}
+	if len(trace.StackFrames) != 2 || trace.StackFrames[0].Line != 8 || trace.StackFrames[0].Source.Path != spec {
+		t.Errorf("Expected execution to pause at the breakpoint, got: %v", trace.StackFrames)
+	}
+	if !reflect.DeepEqual(executed, []string{spec}) {
+		t.Errorf("Expected the launched specs to be executed, got: %v", executed)
+	}
+	if execution.DebuggerAttached() {
+		t.Error("Expected debugger to be detached from execution after the run")
+	}
+}
@@ -0,0 +1,260 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type callHierarchyItem struct {
+	Name           string            `json:"name"`
+	Kind           lsp.SymbolKind    `json:"kind"`
+	Detail         string            `json:"detail,omitempty"`
+	URI            lsp.DocumentURI   `json:"uri"`
+	Range          lsp.Range         `json:"range"`
+	SelectionRange lsp.Range         `json:"selectionRange"`
+	Data           callHierarchyData `json:"data"`
+}
+
+// callHierarchyData is kept by the client along with an item and sent back when asking for its calls.
+type callHierarchyData struct {
+	StepValue string `json:"stepValue,omitempty"`
+}
+
+type callHierarchyCallsParams struct {
+	Item callHierarchyItem `json:"item"`
+}
+
+type callHierarchyIncomingCall struct {
+	From       callHierarchyItem `json:"from"`
+	FromRanges []lsp.Range       `json:"fromRanges"`
+}
+
+type callHierarchyOutgoingCall struct {
+	To         callHierarchyItem `json:"to"`
+	FromRanges []lsp.Range       `json:"fromRanges"`
+}
+
+func prepareCallHierarchy(req *jsonrpc2.Request) (interface{}, error) {
+	var params lsp.TextDocumentPositionParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	file := util.ConvertURItoFilePath(params.TextDocument.URI)
+	content := getContent(params.TextDocument.URI)
+	lines := fileLines{file: util.GetLinesFromText(content)}
+	lineNo := params.Position.Line + 1
+	if util.IsConcept(file) {
+		concepts, _ := new(parser.ConceptParser).Parse(content, file)
+		for _, concept := range concepts {
+			if concept.LineNo == lineNo {
+				return []callHierarchyItem{conceptItem(concept, file, lines)}, nil
+			}
+			for _, step := range concept.ConceptSteps {
+				if step.LineNo == lineNo {
+					return []callHierarchyItem{stepItem(step, file, lines)}, nil
+				}
+			}
+		}
+		return nil, nil
+	}
+	spec, _ := new(parser.SpecParser).ParseSpecText(content, file)
+	if spec.Heading != nil && spec.Heading.LineNo == lineNo {
+		return []callHierarchyItem{specItem(spec, file, lines)}, nil
+	}
+	for _, scenario := range spec.Scenarios {
+		if scenario.Heading.LineNo == lineNo {
+			return []callHierarchyItem{scenarioItem(scenario, file, lines)}, nil
+		}
+	}
+	for _, item := range spec.AllItems() {
+		if item.Kind() == gauge.StepKind && item.(*gauge.Step).LineNo == lineNo {
+			return []callHierarchyItem{stepItem(item.(*gauge.Step), file, lines)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// incomingCalls lists the scenarios, specs (for contexts and teardowns) and concepts which use the step or concept of the item.
+func incomingCalls(req *jsonrpc2.Request) (interface{}, error) {
+	var params callHierarchyCallsParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	calls := make([]callHierarchyIncomingCall, 0)
+	stepValue := params.Item.Data.StepValue
+	if stepValue == "" {
+		return calls, nil
+	}
+	lines := fileLines{}
+	add := func(from callHierarchyItem, file string, steps []*gauge.Step) {
+		if ranges := usageRanges(steps, file, stepValue, lines); len(ranges) > 0 {
+			calls = append(calls, callHierarchyIncomingCall{From: from, FromRanges: ranges})
+		}
+	}
+	for _, detail := range provider.GetAvailableSpecDetails([]string{}) {
+		if !detail.HasSpec() {
+			continue
+		}
+		spec := detail.Spec
+		add(specItem(spec, spec.FileName, lines), spec.FileName, append(append([]*gauge.Step{}, spec.Contexts...), spec.TearDownSteps...))
+		for _, scenario := range spec.Scenarios {
+			add(scenarioItem(scenario, spec.FileName, lines), spec.FileName, scenario.Steps)
+		}
+	}
+	for _, concept := range allConcepts() {
+		add(conceptItem(concept.ConceptStep, concept.FileName, lines), concept.FileName, concept.ConceptStep.ConceptSteps)
+	}
+	return calls, nil
+}
+
+// outgoingCalls lists the steps and concepts a concept expands into, or which a scenario is made of.
+func outgoingCalls(req *jsonrpc2.Request) (interface{}, error) {
+	var params callHierarchyCallsParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	item := params.Item
+	var file string
+	var steps []*gauge.Step
+	if item.Data.StepValue != "" {
+		concept := provider.SearchConceptDictionary(item.Data.StepValue)
+		if concept == nil {
+			return []callHierarchyOutgoingCall{}, nil
+		}
+		file, steps = concept.FileName, concept.ConceptStep.ConceptSteps
+	} else {
+		file = util.ConvertURItoFilePath(item.URI)
+		content, err := getContentFromFileOrDisk(file)
+		if err != nil {
+			return nil, err
+		}
+		spec, _ := new(parser.SpecParser).ParseSpecText(content, file)
+		for _, scenario := range spec.Scenarios {
+			if scenario.Heading.LineNo-1 == item.Range.Start.Line {
+				steps = scenario.Steps
+			}
+		}
+	}
+	lines := fileLines{}
+	calls := make([]callHierarchyOutgoingCall, 0)
+	seen := make(map[string]bool)
+	for _, step := range steps {
+		if seen[step.Value] {
+			continue
+		}
+		seen[step.Value] = true
+		calls = append(calls, callHierarchyOutgoingCall{To: stepItem(step, file, lines), FromRanges: usageRanges(steps, file, step.Value, lines)})
+	}
+	return calls, nil
+}
+
+// allConcepts returns the concepts of the project from the concept dictionary, ordered by file and line.
+func allConcepts() []*gauge.Concept {
+	var concepts []*gauge.Concept
+	for _, info := range provider.Concepts() {
+		if concept := provider.SearchConceptDictionary(info.GetStepValue().GetStepValue()); concept != nil {
+			concepts = append(concepts, concept)
+		}
+	}
+	sort.SliceStable(concepts, func(i, j int) bool {
+		if concepts[i].FileName != concepts[j].FileName {
+			return concepts[i].FileName < concepts[j].FileName
+		}
+		return concepts[i].ConceptStep.LineNo < concepts[j].ConceptStep.LineNo
+	})
+	return concepts
+}
+
+func usageRanges(steps []*gauge.Step, file, stepValue string, lines fileLines) []lsp.Range {
+	var ranges []lsp.Range
+	for _, step := range steps {
+		if step.Value == stepValue {
+			ranges = append(ranges, lines.lineRange(file, step.LineNo))
+		}
+	}
+	return ranges
+}
+
+// stepItem returns the item of the concept invoked by the step if there is one, otherwise the item of the step at its usage.
+func stepItem(step *gauge.Step, file string, lines fileLines) callHierarchyItem {
+	if concept := provider.SearchConceptDictionary(step.Value); concept != nil {
+		return conceptItem(concept.ConceptStep, concept.FileName, lines)
+	}
+	return callHierarchyItem{
+		Name:           step.LineText,
+		Kind:           lsp.SKMethod,
+		Detail:         fmt.Sprintf("Step in %s", util.RelPathToProjectRoot(file)),
+		URI:            util.ConvertPathToURI(file),
+		Range:          lines.lineRange(file, step.LineNo),
+		SelectionRange: lines.lineRange(file, step.LineNo),
+		Data:           callHierarchyData{StepValue: step.Value},
+	}
+}
+
+func conceptItem(concept *gauge.Step, file string, lines fileLines) callHierarchyItem {
+	return callHierarchyItem{
+		Name:           concept.LineText,
+		Kind:           lsp.SKFunction,
+		Detail:         fmt.Sprintf("Concept in %s", util.RelPathToProjectRoot(file)),
+		URI:            util.ConvertPathToURI(file),
+		Range:          lines.lineRange(file, concept.LineNo),
+		SelectionRange: lines.lineRange(file, concept.LineNo),
+		Data:           callHierarchyData{StepValue: concept.Value},
+	}
+}
+
+func scenarioItem(scenario *gauge.Scenario, file string, lines fileLines) callHierarchyItem {
+	return callHierarchyItem{
+		Name:           scenario.Heading.Value,
+		Kind:           lsp.SKNamespace,
+		Detail:         fmt.Sprintf("Scenario in %s", util.RelPathToProjectRoot(file)),
+		URI:            util.ConvertPathToURI(file),
+		Range:          lines.lineRange(file, scenario.Heading.LineNo),
+		SelectionRange: lines.lineRange(file, scenario.Heading.LineNo),
+	}
+}
+
+func specItem(spec *gauge.Specification, file string, lines fileLines) callHierarchyItem {
+	return callHierarchyItem{
+		Name:           spec.Heading.Value,
+		Kind:           lsp.SKNamespace,
+		Detail:         fmt.Sprintf("Specification %s", util.RelPathToProjectRoot(file)),
+		URI:            util.ConvertPathToURI(file),
+		Range:          lines.lineRange(file, spec.Heading.LineNo),
+		SelectionRange: lines.lineRange(file, spec.Heading.LineNo),
+	}
+}
+
+// fileLines are the lines of the files of the items in a request, read once from the open files or from the disk.
+type fileLines map[string][]string
+
+// lineRange returns the range of the whole line, with its end counted in UTF-16 code units.
+func (f fileLines) lineRange(file string, lineNo int) lsp.Range {
+	lines, ok := f[file]
+	if !ok {
+		content, _ := getContentFromFileOrDisk(file)
+		lines = util.GetLinesFromText(content)
+		f[file] = lines
+	}
+	end := 0
+	if lineNo > 0 && lineNo <= len(lines) {
+		end = utf16Offset(lines[lineNo-1], len(lines[lineNo-1]))
+	}
+	return lsp.Range{
+		Start: lsp.Position{Line: lineNo - 1, Character: 0},
+		End:   lsp.Position{Line: lineNo - 1, Character: end},
+	}
+}
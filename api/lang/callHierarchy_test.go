@@ -0,0 +1,205 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/api/infoGatherer"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const (
+	callHierarchyConcepts = "# login as <user>\n* open login page\n* enter user <user>\n\n# setup\n* login as \"admin\"\n* open dashboard"
+	callHierarchySpec     = "# Spec\n* login as \"admin\"\n## Scenario one\n* login as \"john\"\n* open dashboard\n## Scenario two\n* open dashboard"
+)
+
+type projectInfoProvider struct {
+	conceptInfoProvider
+}
+
+func (p projectInfoProvider) Concepts() []*gm.ConceptInfo {
+	var infos []*gm.ConceptInfo
+	for value := range p.concepts {
+		infos = append(infos, &gm.ConceptInfo{StepValue: &gm.ProtoStepValue{StepValue: value}})
+	}
+	return infos
+}
+
+func setupCallHierarchyProject() (string, string, func()) {
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = filepath.Join(string(filepath.Separator), "project")
+	cptFile := filepath.Join(config.ProjectRoot, "concepts", "login.cpt")
+	specFile := filepath.Join(config.ProjectRoot, "specs", "login.spec")
+	parsedConcepts, _ := new(parser.ConceptParser).Parse(callHierarchyConcepts, cptFile)
+	concepts := make(map[string]*gauge.Concept)
+	for _, c := range parsedConcepts {
+		concepts[c.Value] = &gauge.Concept{ConceptStep: c, FileName: cptFile}
+	}
+	spec, _ := new(parser.SpecParser).ParseSpecText(callHierarchySpec, specFile)
+	provider = projectInfoProvider{conceptInfoProvider{
+		dummyInfoProvider: dummyInfoProvider{specsFunc: func(specs []string) []*infoGatherer.SpecDetail {
+			return []*infoGatherer.SpecDetail{{Spec: spec}}
+		}},
+		concepts: concepts,
+	}}
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache.add(util.ConvertPathToURI(cptFile), callHierarchyConcepts)
+	openFilesCache.add(util.ConvertPathToURI(specFile), callHierarchySpec)
+	return cptFile, specFile, func() { config.ProjectRoot = oldRoot }
+}
+
+func callHierarchyRequest(v interface{}) *jsonrpc2.Request {
+	b, _ := json.Marshal(v)
+	p := json.RawMessage(b)
+	return &jsonrpc2.Request{Params: &p}
+}
+
+func loginConceptItem(cptFile string) callHierarchyItem {
+	r := lsp.Range{Start: lsp.Position{Line: 0, Character: 0}, End: lsp.Position{Line: 0, Character: len("# login as <user>")}}
+	return callHierarchyItem{
+		Name:           "login as <user>",
+		Kind:           lsp.SKFunction,
+		Detail:         "Concept in " + filepath.Join("concepts", "login.cpt"),
+		URI:            util.ConvertPathToURI(cptFile),
+		Range:          r,
+		SelectionRange: r,
+		Data:           callHierarchyData{StepValue: "login as {}"},
+	}
+}
+
+func TestPrepareCallHierarchyOnConceptInvocation(t *testing.T) {
+	cptFile, specFile, teardown := setupCallHierarchyProject()
+	defer teardown()
+
+	got, err := prepareCallHierarchy(callHierarchyRequest(lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: util.ConvertPathToURI(specFile)},
+		Position:     lsp.Position{Line: 3, Character: 3},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []callHierarchyItem{loginConceptItem(cptFile)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong call hierarchy items.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestIncomingCallsOfConcept(t *testing.T) {
+	cptFile, specFile, teardown := setupCallHierarchyProject()
+	defer teardown()
+
+	got, err := incomingCalls(callHierarchyRequest(callHierarchyCallsParams{Item: loginConceptItem(cptFile)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := got.([]callHierarchyIncomingCall)
+	var from []string
+	var lines []int
+	for _, call := range calls {
+		from = append(from, call.From.Name)
+		for _, r := range call.FromRanges {
+			lines = append(lines, r.Start.Line)
+		}
+	}
+	if !reflect.DeepEqual(from, []string{"Spec", "Scenario one", "setup"}) {
+		t.Errorf("Wrong callers, got: %v", from)
+	}
+	if !reflect.DeepEqual(lines, []int{1, 3, 5}) {
+		t.Errorf("Wrong call sites, got: %v", lines)
+	}
+	if calls[0].From.URI != util.ConvertPathToURI(specFile) || calls[2].From.URI != util.ConvertPathToURI(cptFile) {
+		t.Errorf("Wrong caller files, got: %v and %v", calls[0].From.URI, calls[2].From.URI)
+	}
+}
+
+func TestOutgoingCallsOfConcept(t *testing.T) {
+	cptFile, _, teardown := setupCallHierarchyProject()
+	defer teardown()
+	setup := callHierarchyItem{Name: "setup", Kind: lsp.SKFunction, URI: util.ConvertPathToURI(cptFile), Data: callHierarchyData{StepValue: "setup"}}
+
+	got, err := outgoingCalls(callHierarchyRequest(callHierarchyCallsParams{Item: setup}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := got.([]callHierarchyOutgoingCall)
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 outgoing calls, got: %v", calls)
+	}
+	if !reflect.DeepEqual(calls[0].To, loginConceptItem(cptFile)) {
+		t.Errorf("Expected the nested concept to point to its definition, got: %v", calls[0].To)
+	}
+	if calls[1].To.Name != "open dashboard" || calls[1].To.Kind != lsp.SKMethod || calls[1].FromRanges[0].Start.Line != 6 {
+		t.Errorf("Wrong outgoing call to a step, got: %v", calls[1])
+	}
+}
+
+func TestOutgoingCallsOfScenario(t *testing.T) {
+	_, specFile, teardown := setupCallHierarchyProject()
+	defer teardown()
+	items, _ := prepareCallHierarchy(callHierarchyRequest(lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: util.ConvertPathToURI(specFile)},
+		Position:     lsp.Position{Line: 2, Character: 0},
+	}))
+	scenario := items.([]callHierarchyItem)[0]
+
+	got, err := outgoingCalls(callHierarchyRequest(callHierarchyCallsParams{Item: scenario}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var to []string
+	for _, call := range got.([]callHierarchyOutgoingCall) {
+		to = append(to, call.To.Name)
+	}
+	if !reflect.DeepEqual(to, []string{"login as <user>", "open dashboard"}) {
+		t.Errorf("Wrong outgoing calls of scenario, got: %v", to)
+	}
+}
+
+func TestOutgoingCallsOfScenarioInAFileWhichIsNotOpen(t *testing.T) {
+	_, _, teardown := setupCallHierarchyProject()
+	defer teardown()
+	dir, err := ioutil.TempDir("", "gauge-call-hierarchy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specFile := filepath.Join(dir, "café.spec")
+	if err = ioutil.WriteFile(specFile, []byte("# Café 😀\n## Commande 😀\n* open dashboard 😀"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	scenario := callHierarchyItem{Name: "Commande 😀", Kind: lsp.SKNamespace, URI: util.ConvertPathToURI(specFile), Range: lsp.Range{Start: lsp.Position{Line: 1}}}
+
+	got, err := outgoingCalls(callHierarchyRequest(callHierarchyCallsParams{Item: scenario}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := got.([]callHierarchyOutgoingCall)
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 outgoing call, got: %v", calls)
+	}
+	want := lsp.Range{Start: lsp.Position{Line: 2, Character: 0}, End: lsp.Position{Line: 2, Character: 19}}
+	if calls[0].To.Range != want || calls[0].FromRanges[0] != want {
+		t.Errorf("Expected the range of the step in UTF-16 code units %v, got: %v", want, calls[0])
+	}
+}
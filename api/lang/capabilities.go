@@ -71,19 +71,66 @@ type ClientCapabilities struct {
 	SaveFiles bool `json:"saveFiles,omitempty"`
 }
 
-func gaugeLSPCapabilities() lsp.InitializeResult {
+// initializeResult extends lsp.InitializeResult with the capabilities which were added to the protocol
+// after the lsp package was written.
+type initializeResult struct {
+	Capabilities serverCapabilities `json:"capabilities"`
+}
+
+type serverCapabilities struct {
+	lsp.ServerCapabilities
+	SemanticTokensProvider *semanticTokensOptions `json:"semanticTokensProvider,omitempty"`
+	FoldingRangeProvider   bool                   `json:"foldingRangeProvider,omitempty"`
+	SelectionRangeProvider bool                   `json:"selectionRangeProvider,omitempty"`
+	DocumentLinkProvider   *documentLinkOptions   `json:"documentLinkProvider,omitempty"`
+	InlayHintProvider      bool                   `json:"inlayHintProvider,omitempty"`
+	CallHierarchyProvider  bool                   `json:"callHierarchyProvider,omitempty"`
+}
+
+type documentLinkOptions struct {
+	ResolveProvider bool `json:"resolveProvider"`
+}
+
+type semanticTokensOptions struct {
+	Legend semanticTokensLegend       `json:"legend"`
+	Full   *semanticTokensFullOptions `json:"full,omitempty"`
+}
+
+type semanticTokensLegend struct {
+	TokenTypes     []string `json:"tokenTypes"`
+	TokenModifiers []string `json:"tokenModifiers"`
+}
+
+type semanticTokensFullOptions struct {
+	Delta bool `json:"delta"`
+}
+
+func gaugeLSPCapabilities() initializeResult {
 	kind := lsp.TDSKFull
-	return lsp.InitializeResult{
-		Capabilities: lsp.ServerCapabilities{
-			TextDocumentSync:           &lsp.TextDocumentSyncOptionsOrKind{Kind: &kind, Options: &lsp.TextDocumentSyncOptions{Save: &lsp.SaveOptions{IncludeText: true}}},
-			CompletionProvider:         &lsp.CompletionOptions{ResolveProvider: true, TriggerCharacters: []string{"*", "* ", "\"", "<", ":", ","}},
-			DocumentFormattingProvider: true,
-			CodeLensProvider:           &lsp.CodeLensOptions{ResolveProvider: false},
-			DefinitionProvider:         true,
-			CodeActionProvider:         true,
-			DocumentSymbolProvider:     true,
-			WorkspaceSymbolProvider:    true,
-			RenameProvider:             true,
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			ServerCapabilities: lsp.ServerCapabilities{
+				TextDocumentSync:           &lsp.TextDocumentSyncOptionsOrKind{Kind: &kind, Options: &lsp.TextDocumentSyncOptions{Save: &lsp.SaveOptions{IncludeText: true}}},
+				CompletionProvider:         &lsp.CompletionOptions{ResolveProvider: true, TriggerCharacters: []string{"*", "* ", "\"", "<", ":", ","}},
+				DocumentFormattingProvider: true,
+				CodeLensProvider:           &lsp.CodeLensOptions{ResolveProvider: false},
+				DefinitionProvider:         true,
+				HoverProvider:              true,
+				CodeActionProvider:         true,
+				DocumentSymbolProvider:     true,
+				WorkspaceSymbolProvider:    true,
+				RenameProvider:             true,
+				ExecuteCommandProvider:     &lsp.ExecuteCommandOptions{Commands: []string{quickFixCommand, inlineConceptCommand, parameterizeCommand, runCommand}},
+			},
+			SemanticTokensProvider: &semanticTokensOptions{
+				Legend: semanticTokensLegend{TokenTypes: semanticTokenTypes, TokenModifiers: semanticTokenModifiers},
+				Full:   &semanticTokensFullOptions{Delta: true},
+			},
+			FoldingRangeProvider:   true,
+			SelectionRangeProvider: true,
+			DocumentLinkProvider:   &documentLinkOptions{ResolveProvider: false},
+			InlayHintProvider:      true,
+			CallHierarchyProvider:  true,
 		},
 	}
 }
@@ -7,10 +7,12 @@
 package lang
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/getgauge/gauge/conceptExtractor"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/util"
@@ -23,6 +25,11 @@ const (
 	generateStubTitle      = "Create step implementation"
 	generateConceptCommand = "gauge.generate.concept"
 	generateConceptTitle   = "Create concept"
+	quickFixCommand        = "gauge.quickFix"
+	inlineConceptCommand   = "gauge.inlineConcept"
+	inlineConceptTitle     = "Inline concept"
+	parameterizeCommand    = "gauge.parameterize"
+	parameterizeTitle      = "Parameterize scenario"
 )
 
 func codeActions(req *jsonrpc2.Request) (interface{}, error) {
@@ -37,6 +44,12 @@ func getSpecCodeAction(params lsp.CodeActionParams) ([]lsp.Command, error) {
 	var actions []lsp.Command
 	line := params.Range.Start.Line
 	for _, d := range params.Context.Diagnostics {
+		if d.Source == warningSource {
+			for _, fix := range quickFixes.get(params.TextDocument.URI, d.Code, d.Range.Start.Line) {
+				actions = append(actions, createCodeAction(quickFixCommand, fix.title, []interface{}{fix.edit}))
+			}
+			continue
+		}
 		if d.Code != "" {
 			actions = append(actions, createCodeAction(generateStepCommand, generateStubTitle, []interface{}{d.Code}))
 			cptInfo, err := createConceptInfo(params.TextDocument.URI, line)
@@ -48,7 +61,112 @@ func getSpecCodeAction(params lsp.CodeActionParams) ([]lsp.Command, error) {
 			}
 		}
 	}
-	return actions, nil
+	return append(actions, refactoringCodeActions(params)...), nil
+}
+
+// refactoringCodeActions offers to inline the concept invoked at the start of the range, and to parameterize the
+// static params of the steps in the range. Their edits are computed only when the command of the action is executed.
+func refactoringCodeActions(params lsp.CodeActionParams) []lsp.Command {
+	var actions []lsp.Command
+	uri := params.TextDocument.URI
+	start, end := params.Range.Start.Line, params.Range.End.Line
+	steps := stepsOf(uri)
+	for _, step := range steps {
+		if step.LineNo-1 == start && provider.SearchConceptDictionary(step.Value) != nil {
+			actions = append(actions, createCodeAction(inlineConceptCommand, inlineConceptTitle, []interface{}{uri, start}))
+		}
+	}
+	if !util.IsSpec(util.ConvertURItoFilePath(uri)) {
+		return actions
+	}
+	for _, step := range steps {
+		if step.LineNo-1 >= start && step.LineNo-1 <= end && hasStaticArg(step) {
+			return append(actions, createCodeAction(parameterizeCommand, parameterizeTitle, []interface{}{uri, start, end}))
+		}
+	}
+	return actions
+}
+
+func stepsOf(uri lsp.DocumentURI) []*gauge.Step {
+	file := util.ConvertURItoFilePath(uri)
+	if util.IsConcept(file) {
+		var steps []*gauge.Step
+		concepts, _ := new(parser.ConceptParser).Parse(getContent(uri), file)
+		for _, concept := range concepts {
+			steps = append(steps, concept.ConceptSteps...)
+		}
+		return steps
+	}
+	spec, _ := new(parser.SpecParser).ParseSpecText(getContent(uri), file)
+	return spec.Steps()
+}
+
+func hasStaticArg(step *gauge.Step) bool {
+	for _, arg := range step.Args {
+		if arg.ArgType == gauge.Static {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRefactoring computes the edits of the refactoring code action which was chosen and asks the client to apply them.
+func applyRefactoring(ctx context.Context, conn jsonrpc2.JSONRPC2, params lsp.ExecuteCommandParams) (interface{}, error) {
+	if len(params.Arguments) < 2 {
+		return nil, fmt.Errorf("expected a file and lines to refactor, got %v", params.Arguments)
+	}
+	uri, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a file to refactor, got %v", params.Arguments[0])
+	}
+	var lines []int
+	for _, arg := range params.Arguments[1:] {
+		line, ok := arg.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected a line to refactor, got %v", arg)
+		}
+		lines = append(lines, int(line))
+	}
+	file := util.ConvertURItoFilePath(lsp.DocumentURI(uri))
+	content := getContent(lsp.DocumentURI(uri))
+	var edits []conceptExtractor.LinesEdit
+	switch params.Command {
+	case inlineConceptCommand:
+		edit, err := conceptExtractor.InlineConcept(content, file, lines[0]+1, provider.SearchConceptDictionary)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	case parameterizeCommand:
+		if len(lines) != 2 {
+			return nil, fmt.Errorf("expected the lines of the steps to parameterize, got %v", lines)
+		}
+		var err error
+		if edits, err = conceptExtractor.ParameterizeSteps(content, file, lines[0]+1, lines[1]+1); err != nil {
+			return nil, err
+		}
+	}
+	var result interface{}
+	return nil, conn.Call(ctx, "workspace/applyEdit", applyWorkspaceEditParams{Edit: editOf(file, textEdits(content, edits)...)}, &result)
+}
+
+// textEdits converts the edits of lines to text edits, so that only the lines which change are sent to the client.
+func textEdits(content string, edits []conceptExtractor.LinesEdit) []lsp.TextEdit {
+	lines := util.GetLinesFromText(content)
+	var textEdits []lsp.TextEdit
+	for _, e := range edits {
+		if e.End < len(lines) {
+			text := ""
+			if len(e.Lines) > 0 {
+				text = strings.Join(e.Lines, "\n") + "\n"
+			}
+			textEdits = append(textEdits, createTextEdit(text, e.Start, 0, e.End, 0))
+			continue
+		}
+		last := len(lines) - 1
+		textEdits = append(textEdits, createTextEdit(strings.Join(e.Lines, "\n"), e.Start, 0, last, utf16Offset(lines[last], len(lines[last]))))
+	}
+	return textEdits
 }
 
 func createConceptInfo(uri lsp.DocumentURI, line int) (interface{}, error) {
@@ -109,3 +227,25 @@ func createCodeAction(command, titlle string, params []interface{}) lsp.Command
 		Arguments: params,
 	}
 }
+
+type applyWorkspaceEditParams struct {
+	Label string            `json:"label,omitempty"`
+	Edit  lsp.WorkspaceEdit `json:"edit"`
+}
+
+// applyQuickFix applies the edit of a quick fix, which is sent as the argument of the command, on the client.
+func applyQuickFix(ctx context.Context, conn jsonrpc2.JSONRPC2, params lsp.ExecuteCommandParams) (interface{}, error) {
+	if len(params.Arguments) != 1 {
+		return nil, fmt.Errorf("expected a quick fix, got %v", params.Arguments)
+	}
+	b, err := json.Marshal(params.Arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	var edit lsp.WorkspaceEdit
+	if err := json.Unmarshal(b, &edit); err != nil {
+		return nil, fmt.Errorf("invalid quick fix %v", err)
+	}
+	var result interface{}
+	return nil, conn.Call(ctx, "workspace/applyEdit", applyWorkspaceEditParams{Edit: edit}, &result)
+}
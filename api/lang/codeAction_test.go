@@ -7,16 +7,21 @@
 package lang
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"testing"
 
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 	"github.com/sourcegraph/jsonrpc2"
 )
 
 func TestGetCodeActionForUnimplementedStep(t *testing.T) {
 	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	provider = conceptInfoProvider{}
 	openFilesCache.add(lsp.DocumentURI("foo.spec"), "# spec heading\n## scenario heading\n* foo bar")
 
 	stub := "a stub for unimplemented step"
@@ -68,6 +73,7 @@ func TestGetCodeActionForUnimplementedStep(t *testing.T) {
 
 func TestGetCodeActionForUnimplementedStepWithParam(t *testing.T) {
 	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	provider = conceptInfoProvider{}
 	openFilesCache.add(lsp.DocumentURI("foo.spec"), "# spec heading\n## scenario heading\n* foo bar \"some\"")
 
 	stub := "a stub for unimplemented step"
@@ -104,6 +110,11 @@ func TestGetCodeActionForUnimplementedStepWithParam(t *testing.T) {
 			Title:     generateConceptTitle,
 			Arguments: []interface{}{concpetInfo{ConceptName: "# foo bar <arg0>\n* "}},
 		},
+		{
+			Command:   parameterizeCommand,
+			Title:     parameterizeTitle,
+			Arguments: []interface{}{lsp.DocumentURI("foo.spec"), 2, 2},
+		},
 	}
 
 	got, err := codeActions(&jsonrpc2.Request{Params: &p})
@@ -127,6 +138,7 @@ func TestGetCodeActionForUnimplementedStepWithTableParam(t *testing.T) {
    	|----|
    	|some|`
 	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	provider = conceptInfoProvider{}
 	openFilesCache.add(lsp.DocumentURI("foo.spec"), specText)
 
 	stub := "a stub for unimplemented step"
@@ -183,6 +195,7 @@ func TestGetCodeActionForUnimplementedStepWithFileParameter(t *testing.T) {
 
 * Step text <file:_testdata/dummyFile.txt>`
 	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	provider = conceptInfoProvider{}
 	openFilesCache.add(lsp.DocumentURI("foo.spec"), specText)
 
 	stub := "a stub for unimplemented step"
@@ -234,6 +247,7 @@ func TestGetCodeActionForUnimplementedStepWithFileParameter(t *testing.T) {
 
 func TestNotToPanicForUnimplementedWithInvalidStartLine(t *testing.T) {
 	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	provider = conceptInfoProvider{}
 	openFilesCache.add(lsp.DocumentURI("foo.spec"), "# spec heading\n## scenario heading\n* foo bar")
 
 	stub := "a stub for unimplemented step"
@@ -277,3 +291,75 @@ func TestNotToPanicForUnimplementedWithInvalidStartLine(t *testing.T) {
 		t.Errorf("want: `%s`,\n got: `%s`", want, got)
 	}
 }
+
+// executeRefactoring executes the command of the code action offered for the range and returns the edit it applies.
+func executeRefactoring(t *testing.T, uri lsp.DocumentURI, start, end int, command string) interface{} {
+	b, _ := json.Marshal(lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+		Range:        lsp.Range{Start: lsp.Position{Line: start, Character: 0}, End: lsp.Position{Line: end, Character: 0}},
+	})
+	p := json.RawMessage(b)
+	got, err := codeActions(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var action *lsp.Command
+	for _, a := range got.([]lsp.Command) {
+		if a.Command == command {
+			a := a
+			action = &a
+		}
+	}
+	if action == nil {
+		t.Fatalf("Expected a code action to %s, got: %+v", command, got)
+	}
+	b, _ = json.Marshal(lsp.ExecuteCommandParams{Command: action.Command, Arguments: action.Arguments})
+	p = json.RawMessage(b)
+	conn := &recordingConn{}
+	if _, err := executeWorkspaceCommand(context.Background(), conn, &jsonrpc2.Request{Params: &p}); err != nil {
+		t.Fatal(err)
+	}
+	if conn.method != "workspace/applyEdit" {
+		t.Fatalf("Expected the edit to be applied, got: %s", conn.method)
+	}
+	return conn.params
+}
+
+func TestCodeActionToInlineConceptReplacesOnlyTheInvocation(t *testing.T) {
+	setup()
+	defer tearDown()
+	cpt := "# login as <user>\n* enter user <user>\n* submit\n"
+	concepts, _ := new(parser.ConceptParser).Parse(cpt, conceptFile)
+	dictionary := gauge.NewConceptDictionary()
+	if _, err := parser.AddConcept(concepts, conceptFile, dictionary); err != nil {
+		t.Fatal(err)
+	}
+	provider = conceptInfoProvider{concepts: map[string]*gauge.Concept{"login as {}": dictionary.Search("login as {}")}}
+	specURI := util.ConvertPathToURI(specFile)
+	openFilesCache.add(specURI, "# Spec\n## Login\n* login as \"john\"\n* logout\n")
+
+	got := executeRefactoring(t, specURI, 2, 2, inlineConceptCommand)
+
+	want := applyWorkspaceEditParams{Edit: editOf(specFile, createTextEdit("* enter user \"john\"\n* submit\n", 2, 0, 3, 0))}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
+
+func TestCodeActionToParameterizeEditsOnlyTheChangedLines(t *testing.T) {
+	setup()
+	defer tearDown()
+	provider = conceptInfoProvider{}
+	specURI := util.ConvertPathToURI(specFile)
+	openFilesCache.add(specURI, "# Spec\n## Login\n* login as \"john\"")
+
+	got := executeRefactoring(t, specURI, 2, 2, parameterizeCommand)
+
+	want := applyWorkspaceEditParams{Edit: editOf(specFile,
+		createTextEdit("\n   |arg0|\n   |----|\n   |john|\n\n", 1, 0, 1, 0),
+		createTextEdit("* login as <arg0>", 2, 0, 2, len("* login as \"john\"")),
+	)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/getgauge/gauge/i18n"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -17,15 +18,14 @@ import (
 type insertTextFormat int
 
 const (
-	text          insertTextFormat = 1
-	snippet       insertTextFormat = 2
-	concept                        = "Concept"
-	step                           = "Step"
-	tag                            = "Tag"
-	tagIdentifier                  = "tags:"
-	emptyString                    = ""
-	colon                          = ":"
-	comma                          = ","
+	text        insertTextFormat = 1
+	snippet     insertTextFormat = 2
+	concept                      = "Concept"
+	step                         = "Step"
+	tag                          = "Tag"
+	emptyString                  = ""
+	colon                        = ":"
+	comma                        = ","
 )
 
 type completionItem struct {
@@ -67,7 +67,7 @@ func completion(req *jsonrpc2.Request) (interface{}, error) {
 }
 
 func isInTagsContext(line int, uri lsp.DocumentURI) bool {
-	if strings.HasPrefix(strings.ToLower(strings.Join(strings.Fields(getLine(uri, line)), "")), tagIdentifier) {
+	if _, ok := i18n.TrimTagsKeyword(strings.TrimSpace(getLine(uri, line))); ok {
 		return true
 	} else if line != 0 && (endsWithComma(getLine(uri, line-1)) && isInTagsContext(line-1, uri)) {
 		return true
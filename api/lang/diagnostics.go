@@ -63,9 +63,12 @@ func getDiagnostics() (map[lsp.DocumentURI][]lsp.Diagnostic, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err = validateSpecs(conceptDictionary, diagnostics); err != nil {
+	specs, err := validateSpecs(conceptDictionary, diagnostics)
+	if err != nil {
 		return nil, err
 	}
+	createWarningDiagnostics(specs, conceptDictionary, diagnostics)
+	executionFailures.addTo(diagnostics)
 	return diagnostics, nil
 }
 
@@ -89,7 +92,7 @@ func validateSpecifications(specs []*gauge.Specification, conceptDictionary *gau
 	return validation.FilterDuplicates(vErrs)
 }
 
-func validateSpecs(conceptDictionary *gauge.ConceptDictionary, diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) error {
+func validateSpecs(conceptDictionary *gauge.ConceptDictionary, diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) ([]*gauge.Specification, error) {
 	specFiles := util.GetSpecFiles(util.GetSpecDirs())
 	specs := make([]*gauge.Specification, 0)
 	for _, specFile := range specFiles {
@@ -99,11 +102,11 @@ func validateSpecs(conceptDictionary *gauge.ConceptDictionary, diagnostics map[l
 		}
 		content, err := getContentFromFileOrDisk(specFile)
 		if err != nil {
-			return fmt.Errorf("unable to read file %s", err)
+			return nil, fmt.Errorf("unable to read file %s", err)
 		}
 		spec, res, err := new(parser.SpecParser).Parse(content, conceptDictionary, specFile)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		createDiagnostics(res, diagnostics)
 		if res.Ok {
@@ -111,7 +114,7 @@ func validateSpecs(conceptDictionary *gauge.ConceptDictionary, diagnostics map[l
 		}
 	}
 	createValidationDiagnostics(validateSpecifications(specs, conceptDictionary), diagnostics)
-	return nil
+	return specs, nil
 }
 
 func validateConcepts(diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) (*gauge.ConceptDictionary, error) {
@@ -141,7 +144,9 @@ func validateConcepts(diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) (*gauge.
 func createDiagnostics(res *parser.ParseResult, diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) {
 	for _, err := range res.ParseErrors {
 		uri := util.ConvertPathToURI(err.FileName)
-		diagnostics[uri] = append(diagnostics[uri], createDiagnostic(uri, err.Message, err.LineNo-1, err.SpanEnd-1, 1))
+		d := createDiagnostic(uri, err.Message, err.LineNo-1, err.SpanEnd-1, 1)
+		d.Code = err.Code
+		diagnostics[uri] = append(diagnostics[uri], d)
 	}
 	for _, warning := range res.Warnings {
 		uri := util.ConvertPathToURI(warning.FileName)
@@ -41,6 +41,7 @@ func documentChange(req *jsonrpc2.Request, ctx context.Context, conn jsonrpc2.JS
 	file := params.TextDocument.URI
 	if util.IsGaugeFile(string(file)) {
 		changeFile(params)
+		executionFailures.remove(file)
 	} else if lRunner.runner != nil {
 		err = cacheFileOnRunner(params.TextDocument.URI, params.ContentChanges[0].Text, false, gm.CacheFileRequest_CHANGED)
 	}
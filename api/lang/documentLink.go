@@ -0,0 +1,86 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type documentLinkParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+}
+
+type documentLink struct {
+	Range  lsp.Range       `json:"range"`
+	Target lsp.DocumentURI `json:"target,omitempty"`
+}
+
+// specialParamPattern matches the `<file:...>` and `<table:...>` params, with the keywords in English and in the
+// configured language.
+func specialParamPattern() *regexp.Regexp {
+	var types []string
+	for _, k := range i18n.SpecialParamKeywords() {
+		types = append(types, regexp.QuoteMeta(k))
+	}
+	return regexp.MustCompile(`<\s*(?i:` + strings.Join(types, "|") + `)\s*:([^>]+)>`)
+}
+
+// documentLinks makes the files referred by `<file:...>` and `<table:...>` params and by external data tables clickable.
+func documentLinks(req *jsonrpc2.Request) (interface{}, error) {
+	var params documentLinkParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	uri := params.TextDocument.URI
+	tokens, _ := new(parser.SpecParser).GenerateTokens(getContent(uri), util.ConvertURItoFilePath(uri))
+	links := make([]documentLink, 0)
+	pattern := specialParamPattern()
+	for _, t := range tokens {
+		switch t.Kind {
+		case gauge.StepKind, gauge.TableRow:
+			for line := t.LineNo - 1; line < t.SpanEnd; line++ {
+				text := getLine(uri, line)
+				for _, m := range pattern.FindAllStringSubmatchIndex(text, -1) {
+					links = appendDocumentLink(links, line, text, m[2], m[3])
+				}
+			}
+		case gauge.DataTableKind:
+			line := t.LineNo - 1
+			text := getLine(uri, line)
+			if colon := strings.Index(text, ":"); colon != -1 {
+				links = appendDocumentLink(links, line, text, colon+1, len(text))
+			}
+		}
+	}
+	return links, nil
+}
+
+// appendDocumentLink adds a link for the path between the byte offsets start and end of the line, excluding surrounding
+// whitespace.
+func appendDocumentLink(links []documentLink, line int, text string, start, end int) []documentLink {
+	path := strings.TrimSpace(text[start:end])
+	if path == "" {
+		return links
+	}
+	start += strings.Index(text[start:end], path)
+	return append(links, documentLink{
+		Range: lsp.Range{
+			Start: lsp.Position{Line: line, Character: utf16Offset(text, start)},
+			End:   lsp.Position{Line: line, Character: utf16Offset(text, start+len(path))},
+		},
+		Target: util.ConvertPathToURI(util.GetPathToFile(path)),
+	})
+}
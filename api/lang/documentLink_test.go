@@ -0,0 +1,83 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func TestDocumentLinks(t *testing.T) {
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = filepath.Join(string(filepath.Separator), "project")
+	defer func() { config.ProjectRoot = oldRoot }()
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec\ntable: data/users.csv\n## Scenario\n* post <file:payload.json> and <table:rows.csv>\n   |a|\n   |-|\n   |<file:b.txt>|")
+	b, _ := json.Marshal(documentLinkParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	p := json.RawMessage(b)
+
+	got, err := documentLinks(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link := func(line, start int, path string) documentLink {
+		return documentLink{
+			Range:  lsp.Range{Start: lsp.Position{Line: line, Character: start}, End: lsp.Position{Line: line, Character: start + len(path)}},
+			Target: util.ConvertPathToURI(filepath.Join(config.ProjectRoot, path)),
+		}
+	}
+	want := []documentLink{
+		link(1, len("table: "), "data/users.csv"),
+		link(3, len("* post <file:"), "payload.json"),
+		link(3, len("* post <file:payload.json> and <table:"), "rows.csv"),
+		link(6, len("   |<file:"), "b.txt"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong document links.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestDocumentLinksWithLocalizedKeywordsAndNonASCIIText(t *testing.T) {
+	oldRoot, oldLanguage := config.ProjectRoot, env.SpecLanguage
+	config.ProjectRoot = filepath.Join(string(filepath.Separator), "project")
+	env.SpecLanguage = func() string { return "de" }
+	defer func() { config.ProjectRoot, env.SpecLanguage = oldRoot, oldLanguage }()
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec\n## Szenario\n* sende 😀 <Datei:grüße.txt> und <tabelle:zeilen.csv>")
+	b, _ := json.Marshal(documentLinkParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	p := json.RawMessage(b)
+
+	got, err := documentLinks(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	link := func(start, end int, path string) documentLink {
+		return documentLink{
+			Range:  lsp.Range{Start: lsp.Position{Line: 2, Character: start}, End: lsp.Position{Line: 2, Character: end}},
+			Target: util.ConvertPathToURI(filepath.Join(config.ProjectRoot, path)),
+		}
+	}
+	want := []documentLink{
+		link(18, 27, "grüße.txt"),
+		link(42, 52, "zeilen.csv"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong document links.\ngot:  %v\nwant: %v", got, want)
+	}
+}
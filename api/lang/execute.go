@@ -0,0 +1,287 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/filter"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/getgauge/gauge/validation"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const (
+	runCommand = "gauge.lsp.run"
+	// The failures of steps are reported with executionSource as source, so that they are not taken for warnings.
+	executionSource      = "gauge-execution"
+	executionFailureCode = "execution-failure"
+
+	executionProgressNotification = "gauge/executionProgress"
+)
+
+// executionProgress is notified to the client as a scenario or spec run by the language server goes on.
+type executionProgress struct {
+	Event   string          `json:"event"`
+	URI     lsp.DocumentURI `json:"uri,omitempty"`
+	Line    int             `json:"line"`
+	Text    string          `json:"text,omitempty"`
+	Status  string          `json:"status,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+type executionSummary struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// executeSpecs runs the specs with the runner of the language server.
+var executeSpecs = execution.ExecuteWithRunner
+
+// failures holds the diagnostics of the steps which failed in the last run, till their file changes.
+type failures struct {
+	sync.Mutex
+	diagnostics map[lsp.DocumentURI][]lsp.Diagnostic
+}
+
+var executionFailures = &failures{diagnostics: make(map[lsp.DocumentURI][]lsp.Diagnostic)}
+
+func (f *failures) replace(diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) {
+	f.Lock()
+	defer f.Unlock()
+	f.diagnostics = diagnostics
+}
+
+func (f *failures) remove(uri lsp.DocumentURI) {
+	f.Lock()
+	defer f.Unlock()
+	delete(f.diagnostics, uri)
+}
+
+func (f *failures) addTo(diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) {
+	f.Lock()
+	defer f.Unlock()
+	for uri, d := range f.diagnostics {
+		diagnostics[uri] = append(diagnostics[uri], d...)
+	}
+}
+
+type runState struct {
+	sync.Mutex
+	inProgress bool
+}
+
+var runs = &runState{}
+
+func (s *runState) start() bool {
+	s.Lock()
+	defer s.Unlock()
+	if s.inProgress {
+		return false
+	}
+	s.inProgress = true
+	return true
+}
+
+func (s *runState) finish() {
+	s.Lock()
+	defer s.Unlock()
+	s.inProgress = false
+}
+
+func executeWorkspaceCommand(ctx context.Context, conn jsonrpc2.JSONRPC2, req *jsonrpc2.Request) (interface{}, error) {
+	var params lsp.ExecuteCommandParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse request %v", err)
+	}
+	switch params.Command {
+	case quickFixCommand:
+		return applyQuickFix(ctx, conn, params)
+	case inlineConceptCommand, parameterizeCommand:
+		return applyRefactoring(ctx, conn, params)
+	case runCommand:
+		return run(ctx, conn, params)
+	}
+	return nil, fmt.Errorf("unknown command %s", params.Command)
+}
+
+// run executes a spec, or the scenario at a line of it given as <spec>:<line>, streaming the results to the client.
+func run(ctx context.Context, conn jsonrpc2.JSONRPC2, params lsp.ExecuteCommandParams) (interface{}, error) {
+	if len(params.Arguments) != 1 {
+		return nil, fmt.Errorf("expected a spec or scenario to run, got %v", params.Arguments)
+	}
+	id, ok := params.Arguments[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a spec or scenario to run, got %v", params.Arguments[0])
+	}
+	if lRunner.runner == nil {
+		return nil, fmt.Errorf("cannot run %s, the language runner is not started", id)
+	}
+	if !runs.start() {
+		return nil, fmt.Errorf("cannot run %s, another run is in progress", id)
+	}
+	defer runs.finish()
+	specs, conceptDictionary, err := specsToRun(id)
+	if err != nil {
+		return nil, err
+	}
+	specs, errMap := validation.ValidateSpecsWithRunner(specs, lRunner.runner, conceptDictionary)
+	l := newExecutionListener(ctx, conn, conceptDictionary)
+	done := l.listen()
+	executeSpecs(specs, lRunner.runner, errMap)
+	<-done
+	event.Unregister(l.events)
+	executionFailures.replace(l.failures)
+	publishDiagnostics(ctx, conn)
+	return l.summary, nil
+}
+
+func specsToRun(id string) ([]*gauge.Specification, *gauge.ConceptDictionary, error) {
+	file, line := id, 0
+	if i := strings.LastIndex(id, ":"); i > 0 {
+		if n, err := strconv.Atoi(id[i+1:]); err == nil {
+			file, line = id[:i], n
+		}
+	}
+	conceptDictionary, err := validateConcepts(make(map[lsp.DocumentURI][]lsp.Diagnostic))
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := getContentFromFileOrDisk(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read file %s", err)
+	}
+	spec, res, err := new(parser.SpecParser).Parse(content, conceptDictionary, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !res.Ok {
+		return nil, nil, concatenateErrors(res, file)
+	}
+	if line > 0 {
+		spec, _ = spec.Filter(filter.NewScenarioFilterBasedOnSpan([]int{line}))
+	}
+	return []*gauge.Specification{spec}, conceptDictionary, nil
+}
+
+// executionListener turns the execution events into progress notifications and failure diagnostics.
+type executionListener struct {
+	ctx               context.Context
+	conn              jsonrpc2.JSONRPC2
+	conceptDictionary *gauge.ConceptDictionary
+	events            chan event.ExecutionEvent
+	concepts          []*gauge.Step
+	failures          map[lsp.DocumentURI][]lsp.Diagnostic
+	summary           executionSummary
+}
+
+func newExecutionListener(ctx context.Context, conn jsonrpc2.JSONRPC2, conceptDictionary *gauge.ConceptDictionary) *executionListener {
+	return &executionListener{ctx: ctx, conn: conn, conceptDictionary: conceptDictionary, events: make(chan event.ExecutionEvent), failures: make(map[lsp.DocumentURI][]lsp.Diagnostic)}
+}
+
+func (l *executionListener) listen() chan bool {
+	event.Register(l.events, event.SuiteStart, event.ScenarioStart, event.ConceptStart, event.ConceptEnd, event.StepEnd, event.ScenarioEnd, event.SuiteEnd)
+	done := make(chan bool)
+	go func() {
+		for {
+			e := <-l.events
+			l.handle(e)
+			if e.Topic == event.SuiteEnd {
+				close(done)
+				return
+			}
+		}
+	}()
+	return done
+}
+
+func (l *executionListener) handle(e event.ExecutionEvent) {
+	switch e.Topic {
+	case event.SuiteStart:
+		l.notify(executionProgress{Event: "suiteStart"})
+	case event.ScenarioStart:
+		scenario := e.Item.(*gauge.Scenario)
+		l.notify(executionProgress{Event: "scenarioStart", URI: specURI(e), Line: scenario.Heading.LineNo - 1, Text: scenario.Heading.Value})
+	case event.ConceptStart:
+		l.concepts = append(l.concepts, e.Item.(*gauge.Step))
+	case event.ConceptEnd:
+		l.concepts = l.concepts[:len(l.concepts)-1]
+	case event.StepEnd:
+		l.stepEnd(e)
+	case event.ScenarioEnd:
+		scenario := e.Item.(*gauge.Scenario)
+		status := e.Result.(*result.ScenarioResult).ProtoScenario.GetExecutionStatus()
+		switch status {
+		case gm.ExecutionStatus_FAILED:
+			l.summary.Failed++
+		case gm.ExecutionStatus_SKIPPED:
+			l.summary.Skipped++
+		default:
+			l.summary.Passed++
+		}
+		l.notify(executionProgress{Event: "scenarioEnd", URI: specURI(e), Line: scenario.Heading.LineNo - 1, Text: scenario.Heading.Value, Status: strings.ToLower(status.String())})
+	case event.SuiteEnd:
+		l.notify(executionProgress{Event: "suiteEnd", Status: l.suiteStatus()})
+	}
+}
+
+func (l *executionListener) stepEnd(e event.ExecutionEvent) {
+	step := e.Item.(gauge.Step)
+	res := e.Result.(*result.StepResult)
+	uri := l.stepURI(e)
+	progress := executionProgress{Event: "stepEnd", URI: uri, Line: step.LineNo - 1, Text: step.LineText, Status: "passed"}
+	if res.ProtoStepExecResult().GetSkipped() {
+		progress.Status = "skipped"
+	}
+	if res.GetFailed() {
+		progress.Status, progress.Message = "failed", res.GetErrorMessage()
+		d := createDiagnostic(uri, fmt.Sprintf("Step failed: %s", res.GetErrorMessage()), step.LineNo-1, step.LineNo-1, 1)
+		d.Source, d.Code = executionSource, executionFailureCode
+		l.failures[uri] = append(l.failures[uri], d)
+	}
+	l.notify(progress)
+}
+
+// stepURI returns the file of the concept the step is in, or the spec when it is not in a concept.
+func (l *executionListener) stepURI(e event.ExecutionEvent) lsp.DocumentURI {
+	if len(l.concepts) > 0 {
+		if concept := l.conceptDictionary.Search(l.concepts[len(l.concepts)-1].Value); concept != nil {
+			return util.ConvertPathToURI(concept.FileName)
+		}
+	}
+	return specURI(e)
+}
+
+func (l *executionListener) suiteStatus() string {
+	if l.summary.Failed > 0 {
+		return "failed"
+	}
+	return "passed"
+}
+
+func (l *executionListener) notify(p executionProgress) {
+	if err := l.conn.Notify(l.ctx, executionProgressNotification, p); err != nil {
+		logDebug(nil, "failed to notify execution progress. %s", err.Error())
+	}
+}
+
+func specURI(e event.ExecutionEvent) lsp.DocumentURI {
+	return util.ConvertPathToURI(e.ExecutionInfo.GetCurrentSpec().GetFileName())
+}
@@ -0,0 +1,142 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/execution"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type notificationsConn struct {
+	recordingConn
+	sync.Mutex
+	progress []executionProgress
+}
+
+func (c *notificationsConn) Notify(ctx context.Context, method string, params interface{}, opt ...jsonrpc2.CallOption) error {
+	c.Lock()
+	defer c.Unlock()
+	if p, ok := params.(executionProgress); ok && method == executionProgressNotification {
+		c.progress = append(c.progress, p)
+	}
+	return nil
+}
+
+func stepResult(failed bool, message string) *result.StepResult {
+	return result.NewStepResult(&gm.ProtoStep{StepExecutionResult: &gm.ProtoStepExecutionResult{
+		ExecutionResult: &gm.ProtoExecutionResult{Failed: failed, ErrorMessage: message},
+	}})
+}
+
+// fakeExecution raises the events of a run in which the step of the concept in the first scenario fails.
+func fakeExecution(executed *[]*gauge.Specification) func([]*gauge.Specification, runner.Runner, *gauge.BuildErrors) *result.SuiteResult {
+	return func(specs []*gauge.Specification, r runner.Runner, errMap *gauge.BuildErrors) *result.SuiteResult {
+		*executed = specs
+		scenario := specs[0].Scenarios[0]
+		concept, step := scenario.Steps[0], scenario.Steps[1]
+		info := &gm.ExecutionInfo{CurrentSpec: &gm.SpecInfo{FileName: specFile}}
+		notify := func(t event.Topic, i gauge.Item, r result.Result) {
+			event.Notify(event.NewExecutionEvent(t, i, r, 0, info))
+		}
+		notify(event.SuiteStart, nil, nil)
+		notify(event.ScenarioStart, scenario, nil)
+		notify(event.ConceptStart, concept, nil)
+		notify(event.StepEnd, *concept.ConceptSteps[0], stepResult(true, "user not found"))
+		notify(event.ConceptEnd, nil, nil)
+		notify(event.StepEnd, *step, stepResult(false, ""))
+		notify(event.ScenarioEnd, scenario, result.NewScenarioResult(&gm.ProtoScenario{ExecutionStatus: gm.ExecutionStatus_FAILED}))
+		notify(event.SuiteEnd, nil, nil)
+		return &result.SuiteResult{}
+	}
+}
+
+func TestRunScenarioStreamsProgressAndPublishesFailures(t *testing.T) {
+	setup()
+	defer tearDown()
+	cptURI, specURI := util.ConvertPathToURI(conceptFile), util.ConvertPathToURI(specFile)
+	openFilesCache.add(cptURI, "# login as <user>\n* enter user <user>\n")
+	openFilesCache.add(specURI, "# Spec\n## Login\n* login as \"john\"\n* open dashboard\n## Other\n* open dashboard\n")
+	var executed []*gauge.Specification
+	executeSpecs = fakeExecution(&executed)
+	defer func() {
+		executeSpecs = execution.ExecuteWithRunner
+		executionFailures.replace(make(map[lsp.DocumentURI][]lsp.Diagnostic))
+	}()
+	b, _ := json.Marshal(lsp.ExecuteCommandParams{Command: runCommand, Arguments: []interface{}{specFile + ":2"}})
+	p := json.RawMessage(b)
+	conn := &notificationsConn{}
+	other := make(chan event.ExecutionEvent, 1)
+	event.Register(other, event.SuiteEnd)
+	defer event.Unregister(other)
+
+	got, err := executeWorkspaceCommand(context.Background(), conn, &jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, executionSummary{Failed: 1}) {
+		t.Errorf("Wrong summary, got: %+v", got)
+	}
+	if len(other) != 1 {
+		t.Error("Expected the listeners registered by others to be notified of the run")
+	}
+	if len(executed) != 1 || len(executed[0].Scenarios) != 1 || executed[0].Scenarios[0].Heading.Value != "Login" {
+		t.Fatalf("Expected only the scenario at the line to run, got: %v", executed)
+	}
+	want := []executionProgress{
+		{Event: "suiteStart"},
+		{Event: "scenarioStart", URI: specURI, Line: 1, Text: "Login"},
+		{Event: "stepEnd", URI: cptURI, Line: 1, Text: "enter user <user>", Status: "failed", Message: "user not found"},
+		{Event: "stepEnd", URI: specURI, Line: 3, Text: "open dashboard", Status: "passed"},
+		{Event: "scenarioEnd", URI: specURI, Line: 1, Text: "Login", Status: "failed"},
+		{Event: "suiteEnd", Status: "failed"},
+	}
+	conn.Lock()
+	defer conn.Unlock()
+	if !reflect.DeepEqual(conn.progress, want) {
+		t.Errorf("Wrong progress notifications.\ngot:  %+v\nwant: %+v", conn.progress, want)
+	}
+	diagnostics, err := getDiagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failures []lsp.Diagnostic
+	for _, d := range diagnostics[cptURI] {
+		if d.Code == executionFailureCode {
+			failures = append(failures, d)
+		}
+	}
+	if len(failures) != 1 || failures[0].Range.Start.Line != 1 || failures[0].Message != "Step failed: user not found" || failures[0].Source != executionSource {
+		t.Errorf("Expected the failure to be published on the step of the concept, got: %+v", failures)
+	}
+}
+
+func TestFailuresOfRunAreClearedWhenFileChanges(t *testing.T) {
+	uri := util.ConvertPathToURI(specFile)
+	executionFailures.replace(map[lsp.DocumentURI][]lsp.Diagnostic{uri: {{Code: executionFailureCode}}})
+
+	executionFailures.remove(uri)
+
+	diagnostics := make(map[lsp.DocumentURI][]lsp.Diagnostic)
+	executionFailures.addTo(diagnostics)
+	if len(diagnostics[uri]) != 0 {
+		t.Errorf("Expected no failures, got: %+v", diagnostics[uri])
+	}
+}
@@ -8,7 +8,6 @@ package lang
 
 import (
 	"strings"
-
 	"sync"
 
 	"github.com/getgauge/gauge/util"
@@ -62,6 +61,8 @@ func openFile(params lsp.DidOpenTextDocumentParams) {
 
 func closeFile(params lsp.DidCloseTextDocumentParams) {
 	openFilesCache.remove(params.TextDocument.URI)
+	semanticTokensResults.remove(params.TextDocument.URI)
+	dataTableSelection.remove(params.TextDocument.URI)
 }
 
 func changeFile(params lsp.DidChangeTextDocumentParams) {
@@ -79,3 +80,36 @@ func getContent(uri lsp.DocumentURI) string {
 func isOpen(uri lsp.DocumentURI) bool {
 	return openFilesCache.exists(uri)
 }
+
+// utf16Offset converts a byte offset in the line to the number of UTF-16 code units before it, which is how the
+// character of an LSP position is counted.
+func utf16Offset(line string, offset int) int {
+	if offset > len(line) {
+		offset = len(line)
+	}
+	units := 0
+	for _, r := range line[:offset] {
+		units += utf16Len(r)
+	}
+	return units
+}
+
+// byteOffset converts the character of an LSP position in the line, in UTF-16 code units, to a byte offset. A
+// character past the end of the line is the end of the line.
+func byteOffset(line string, character int) int {
+	units := 0
+	for i, r := range line {
+		if units >= character {
+			return i
+		}
+		units += utf16Len(r)
+	}
+	return len(line)
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,37 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import "testing"
+
+func TestConvertingBetweenByteOffsetsAndUTF16(t *testing.T) {
+	line := "* grüße 😀 <user>"
+	tests := []struct {
+		bytes, units int
+	}{
+		{0, 0},
+		{len("* gr"), 4},
+		{len("* grü"), 5},
+		{len("* grüße "), 8},
+		{len("* grüße 😀"), 10},
+		{len(line), 17},
+	}
+	for _, test := range tests {
+		if got := utf16Offset(line, test.bytes); got != test.units {
+			t.Errorf("utf16Offset(%d) = %d, want %d", test.bytes, got, test.units)
+		}
+		if got := byteOffset(line, test.units); got != test.bytes {
+			t.Errorf("byteOffset(%d) = %d, want %d", test.units, got, test.bytes)
+		}
+	}
+	if got := byteOffset(line, 100); got != len(line) {
+		t.Errorf("byteOffset past the end = %d, want %d", got, len(line))
+	}
+	if got := utf16Offset(line, 100); got != 17 {
+		t.Errorf("utf16Offset past the end = %d, want 17", got)
+	}
+}
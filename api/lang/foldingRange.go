@@ -0,0 +1,179 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type blockKind int
+
+const (
+	specBlock blockKind = iota
+	scenarioBlock
+	tearDownBlock
+	tableBlock
+	frontMatterBlock
+	stepBlock
+)
+
+// textBlock is a range of lines of a spec or concept file, both inclusive and zero based.
+type textBlock struct {
+	kind  blockKind
+	start int
+	end   int
+}
+
+type foldingRangeParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+}
+
+type foldingRange struct {
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	Kind      string `json:"kind,omitempty"`
+}
+
+type selectionRangeParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+	Positions    []lsp.Position             `json:"positions"`
+}
+
+type selectionRange struct {
+	Range  lsp.Range       `json:"range"`
+	Parent *selectionRange `json:"parent,omitempty"`
+}
+
+func foldingRanges(req *jsonrpc2.Request) (interface{}, error) {
+	var params foldingRangeParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	ranges := make([]foldingRange, 0)
+	for _, b := range documentBlocks(params.TextDocument.URI) {
+		if b.kind == stepBlock || b.end <= b.start {
+			continue
+		}
+		kind := "region"
+		if b.kind == frontMatterBlock {
+			kind = "comment"
+		}
+		ranges = append(ranges, foldingRange{StartLine: b.start, EndLine: b.end, Kind: kind})
+	}
+	return ranges, nil
+}
+
+func selectionRanges(req *jsonrpc2.Request) (interface{}, error) {
+	var params selectionRangeParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	blocks := documentBlocks(params.TextDocument.URI)
+	ranges := make([]selectionRange, 0, len(params.Positions))
+	for _, position := range params.Positions {
+		ranges = append(ranges, selectionRangeAt(params.TextDocument.URI, blocks, position))
+	}
+	return ranges, nil
+}
+
+// selectionRangeAt returns the blocks enclosing the position, innermost first, i.e. step, then scenario, then spec.
+func selectionRangeAt(uri lsp.DocumentURI, blocks []textBlock, position lsp.Position) selectionRange {
+	var enclosing []textBlock
+	for _, b := range blocks {
+		if b.start <= position.Line && position.Line <= b.end {
+			enclosing = append(enclosing, b)
+		}
+	}
+	sort.SliceStable(enclosing, func(i, j int) bool {
+		return enclosing[i].end-enclosing[i].start < enclosing[j].end-enclosing[j].start
+	})
+	var outer *selectionRange
+	for i := len(enclosing) - 1; i >= 0; i-- {
+		b := enclosing[i]
+		if outer != nil && outer.Range.Start.Line == b.start && outer.Range.End.Line == b.end {
+			continue
+		}
+		end := getLine(uri, b.end)
+		outer = &selectionRange{Range: lsp.Range{
+			Start: lsp.Position{Line: b.start, Character: 0},
+			End:   lsp.Position{Line: b.end, Character: utf16Offset(end, len(end))},
+		}, Parent: outer}
+	}
+	if outer == nil {
+		return selectionRange{Range: lsp.Range{Start: position, End: position}}
+	}
+	return *outer
+}
+
+// documentBlocks returns the spec, scenario, teardown, table, front matter and step blocks of a document,
+// computed from the tokens of the parser. Trailing blank lines are not part of a block.
+func documentBlocks(uri lsp.DocumentURI) []textBlock {
+	file := util.ConvertURItoFilePath(uri)
+	content := getContent(uri)
+	lines := util.GetLinesFromText(content)
+	tokens, _ := new(parser.SpecParser).GenerateTokens(content, file)
+	isConcept := util.IsConcept(file)
+	last := len(lines) - 1
+	var blocks []textBlock
+	add := func(kind blockKind, start, end int) {
+		for end > start && strings.TrimSpace(lines[end]) == "" {
+			end--
+		}
+		blocks = append(blocks, textBlock{kind: kind, start: start, end: end})
+	}
+	nextLineOf := func(from int, kinds ...gauge.TokenKind) int {
+		for _, t := range tokens[from+1:] {
+			for _, kind := range kinds {
+				if t.Kind == kind {
+					return t.LineNo - 1
+				}
+			}
+		}
+		return last + 1
+	}
+	tableEnd := func(from int) int {
+		end := from
+		for end+1 < len(tokens) && (tokens[end+1].Kind == gauge.TableHeader || tokens[end+1].Kind == gauge.TableRow) {
+			end++
+		}
+		return end
+	}
+	for i, t := range tokens {
+		start := t.LineNo - 1
+		switch t.Kind {
+		case gauge.SpecKind:
+			end := last
+			if isConcept {
+				end = nextLineOf(i, gauge.SpecKind) - 1
+			}
+			add(specBlock, start, end)
+		case gauge.ScenarioKind:
+			add(scenarioBlock, start, nextLineOf(i, gauge.ScenarioKind, gauge.TearDownKind)-1)
+		case gauge.TearDownKind:
+			add(tearDownBlock, start, last)
+		case gauge.FrontMatterKind:
+			add(frontMatterBlock, start, t.SpanEnd-1)
+		case gauge.TableHeader:
+			add(tableBlock, start, tokens[tableEnd(i)].LineNo-1)
+		case gauge.StepKind:
+			end := t.SpanEnd - 1
+			if i+1 < len(tokens) && tokens[i+1].Kind == gauge.TableHeader {
+				end = tokens[tableEnd(i+1)].LineNo - 1
+			}
+			add(stepBlock, start, end)
+		}
+	}
+	return blocks
+}
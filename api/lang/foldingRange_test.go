@@ -0,0 +1,119 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+var foldingSpec = `# Spec
+
+|id|name|
+|--|----|
+|1 |foo |
+
+## First scenario
+* step with table
+   |a|
+   |-|
+   |b|
+* another step
+
+## Second scenario
+* step
+
+___
+* cleanup
+`
+
+func TestFoldingRanges(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, foldingSpec)
+	b, _ := json.Marshal(foldingRangeParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	p := json.RawMessage(b)
+
+	got, err := foldingRanges(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []foldingRange{
+		{StartLine: 0, EndLine: 17, Kind: "region"},
+		{StartLine: 2, EndLine: 4, Kind: "region"},
+		{StartLine: 6, EndLine: 11, Kind: "region"},
+		{StartLine: 8, EndLine: 10, Kind: "region"},
+		{StartLine: 13, EndLine: 14, Kind: "region"},
+		{StartLine: 16, EndLine: 17, Kind: "region"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong folding ranges.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestFoldingRangesForConcepts(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.cpt"))
+	openFilesCache.add(uri, "# first concept\n* step one\n* step two\n\n# second concept\n* step three")
+	b, _ := json.Marshal(foldingRangeParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+	p := json.RawMessage(b)
+
+	got, err := foldingRanges(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []foldingRange{{StartLine: 0, EndLine: 2, Kind: "region"}, {StartLine: 4, EndLine: 5, Kind: "region"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong folding ranges.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestSelectionRanges(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, foldingSpec)
+	b, _ := json.Marshal(selectionRangeParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Positions: []lsp.Position{{Line: 7, Character: 4}, {Line: 1, Character: 0}}})
+	p := json.RawMessage(b)
+
+	got, err := selectionRanges(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := &selectionRange{Range: lsp.Range{Start: lsp.Position{Line: 0}, End: lsp.Position{Line: 17, Character: len("* cleanup")}}}
+	scenario := &selectionRange{Range: lsp.Range{Start: lsp.Position{Line: 6}, End: lsp.Position{Line: 11, Character: len("* another step")}}, Parent: spec}
+	step := selectionRange{Range: lsp.Range{Start: lsp.Position{Line: 7}, End: lsp.Position{Line: 10, Character: len("   |b|")}}, Parent: scenario}
+	want := []selectionRange{step, *spec}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong selection ranges.\ngot:  %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestSelectionRangesEndAtTheLineInUTF16CodeUnits(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec\n\n## Scenario\n* grüße 😀\n")
+	b, _ := json.Marshal(selectionRangeParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Positions: []lsp.Position{{Line: 2, Character: 0}}})
+	p := json.RawMessage(b)
+
+	got, err := selectionRanges(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ranges := got.([]selectionRange)
+	if end := ranges[0].Range.End; end.Line != 3 || end.Character != 10 {
+		t.Errorf("Expected the scenario to end at 3:10, got %d:%d", end.Line, end.Character)
+	}
+}
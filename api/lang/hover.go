@@ -0,0 +1,227 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/formatter"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const (
+	maxHoverTableRows    = 10
+	maxHoverFileLines    = 20
+	maxHoverSignatureLen = 5
+)
+
+func hover(req *jsonrpc2.Request) (interface{}, error) {
+	var params lsp.TextDocumentPositionParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	file := util.ConvertURItoFilePath(params.TextDocument.URI)
+	fileContent := getContent(params.TextDocument.URI)
+	var step *gauge.Step
+	var table *gauge.Table
+	if util.IsConcept(file) {
+		step = conceptStepAt(fileContent, params.Position.Line)
+	} else {
+		spec, _ := new(parser.SpecParser).ParseSpecText(fileContent, "")
+		step, table = specStepAt(spec, params.Position.Line)
+	}
+	if step == nil {
+		return nil, nil
+	}
+	line := getLine(params.TextDocument.URI, params.Position.Line)
+	if param, start, end, ok := paramAt(line, byteOffset(line, params.Position.Character)); ok {
+		contents := paramHoverContents(param, table)
+		if contents == "" {
+			return nil, nil
+		}
+		r := lsp.Range{Start: lsp.Position{Line: params.Position.Line, Character: utf16Offset(line, start)}, End: lsp.Position{Line: params.Position.Line, Character: utf16Offset(line, end)}}
+		return lsp.Hover{Contents: []lsp.MarkedString{lsp.RawMarkedString(contents)}, Range: &r}, nil
+	}
+	contents, err := stepHoverContents(step)
+	if err != nil || contents == "" {
+		return nil, err
+	}
+	r := lsp.Range{Start: lsp.Position{Line: params.Position.Line, Character: 0}, End: lsp.Position{Line: params.Position.Line, Character: utf16Offset(line, len(line))}}
+	return lsp.Hover{Contents: []lsp.MarkedString{lsp.RawMarkedString(contents)}, Range: &r}, nil
+}
+
+func conceptStepAt(content string, line int) *gauge.Step {
+	concepts, _ := new(parser.ConceptParser).Parse(content, "")
+	for _, concept := range concepts {
+		for _, step := range concept.ConceptSteps {
+			if (step.LineNo - 1) == line {
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// specStepAt returns the step at the given line along with the data table its dynamic params refer to.
+func specStepAt(spec *gauge.Specification, line int) (*gauge.Step, *gauge.Table) {
+	for _, scenario := range spec.Scenarios {
+		for _, step := range scenario.Steps {
+			if (step.LineNo - 1) == line {
+				if scenario.DataTable.IsInitialized() {
+					return step, scenario.DataTable.Table
+				}
+				return step, spec.DataTable.Table
+			}
+		}
+	}
+	for _, item := range spec.AllItems() {
+		if item.Kind() == gauge.StepKind && (item.(*gauge.Step).LineNo-1) == line {
+			return item.(*gauge.Step), spec.DataTable.Table
+		}
+	}
+	return nil, nil
+}
+
+// paramAt returns the text between the angle brackets enclosing the given byte offset of the line,
+// along with the byte range of the param including the brackets.
+func paramAt(line string, character int) (string, int, int, bool) {
+	if character > len(line) {
+		return "", 0, 0, false
+	}
+	start := strings.LastIndex(line[:character], "<")
+	if start == -1 || strings.Contains(line[start:character], ">") {
+		return "", 0, 0, false
+	}
+	end := strings.Index(line[start:], ">")
+	if end == -1 {
+		return "", 0, 0, false
+	}
+	end += start
+	return line[start+1 : end], start, end + 1, true
+}
+
+func paramHoverContents(param string, table *gauge.Table) string {
+	if filePath, ok := i18n.TrimFileKeyword(param); ok {
+		return fileHoverContents(strings.TrimSpace(filePath))
+	}
+	if table == nil || !table.IsInitialized() {
+		return ""
+	}
+	cells, err := table.Get(param)
+	if err != nil {
+		return ""
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Values of `<%s>`:\n\n", param)
+	for i, cell := range cells {
+		if i == maxHoverTableRows {
+			fmt.Fprintf(&b, "\n... and %d more\n", len(cells)-maxHoverTableRows)
+			break
+		}
+		fmt.Fprintf(&b, "%d. %s\n", i+1, cell.GetValue())
+	}
+	return b.String()
+}
+
+func fileHoverContents(filePath string) string {
+	content, err := util.GetFileContents(filePath)
+	if err != nil {
+		return fmt.Sprintf("Unable to read `%s`: %s", filePath, err.Error())
+	}
+	lines := util.GetLinesFromText(content)
+	truncated := len(lines) > maxHoverFileLines
+	if truncated {
+		lines = lines[:maxHoverFileLines]
+	}
+	text := fmt.Sprintf("`%s`\n\n```\n%s\n```", filePath, strings.Join(lines, "\n"))
+	if truncated {
+		text += "\n..."
+	}
+	return text
+}
+
+func stepHoverContents(step *gauge.Step) (string, error) {
+	if concept := provider.SearchConceptDictionary(step.Value); concept != nil {
+		return conceptHoverContents(concept), nil
+	}
+	return implementationHoverContents(step)
+}
+
+func conceptHoverContents(concept *gauge.Concept) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "**Concept** defined in `%s:%d`\n\n```gauge\n", util.RelPathToProjectRoot(concept.FileName), concept.ConceptStep.LineNo)
+	b.WriteString(strings.TrimSpace(strings.Replace(formatter.FormatStep(concept.ConceptStep), "*", "#", 1)) + "\n")
+	for _, step := range concept.ConceptStep.ConceptSteps {
+		b.WriteString(formatter.FormatStep(step))
+	}
+	b.WriteString("```")
+	return b.String()
+}
+
+func implementationHoverContents(step *gauge.Step) (string, error) {
+	if lRunner.runner == nil {
+		return "", nil
+	}
+	res, err := getStepNameResponse(step.Value)
+	if err != nil {
+		return "", err
+	}
+	if res == nil || !res.GetIsStepPresent() {
+		return "**Step implementation not found**", nil
+	}
+	if res.GetIsExternal() {
+		return "Step implementation referred from an external project or library", nil
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "**Step implementation** in `%s:%d`\n\n", util.RelPathToProjectRoot(res.GetFileName()), res.GetSpan().GetStart())
+	content, err := getContentFromFileOrDisk(res.GetFileName())
+	if err != nil {
+		return b.String(), nil
+	}
+	fmt.Fprintf(&b, "```%s\n%s\n```", lRunner.lspID, implementationSnippet(util.GetLinesFromText(content), int(res.GetSpan().GetStart())))
+	return b.String(), nil
+}
+
+// implementationSnippet returns the doc comment preceding the implementation starting at the given line
+// followed by the signature of the implementation.
+func implementationSnippet(lines []string, startLine int) string {
+	start := startLine - 1
+	if start < 0 || start >= len(lines) {
+		return ""
+	}
+	docStart := start
+	for docStart > 0 && isCommentLine(lines[docStart-1]) {
+		docStart--
+	}
+	end := start
+	for end < len(lines)-1 && end < start+maxHoverSignatureLen-1 {
+		trimmed := strings.TrimSpace(lines[end])
+		if strings.Contains(trimmed, "{") || strings.HasSuffix(trimmed, ":") {
+			break
+		}
+		end++
+	}
+	return strings.Join(lines[docStart:end+1], "\n")
+}
+
+func isCommentLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"//", "#", "/*", "*", "'''", `"""`} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type conceptInfoProvider struct {
+	dummyInfoProvider
+	concepts map[string]*gauge.Concept
+}
+
+func (p conceptInfoProvider) SearchConceptDictionary(stepValue string) *gauge.Concept {
+	return p.concepts[stepValue]
+}
+
+func hoverAt(t *testing.T, uri lsp.DocumentURI, position lsp.Position) lsp.Hover {
+	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: position})
+	p := json.RawMessage(b)
+	got, err := hover(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatalf("Failed to get hover, err: `%v`", err)
+	}
+	if got == nil {
+		t.Fatalf("Expected hover at %v, got nil", position)
+	}
+	return got.(lsp.Hover)
+}
+
+func TestHoverOnConceptShowsConceptSteps(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, "# Specification\n## Scenario\n* login as \"admin\"")
+	provider = conceptInfoProvider{concepts: map[string]*gauge.Concept{
+		"login as {}": {FileName: "login.cpt", ConceptStep: &gauge.Step{
+			Value: "login as {}", LineNo: 1, IsConcept: true,
+			Args: []*gauge.StepArg{{Name: "user", Value: "user", ArgType: gauge.Dynamic}},
+			ConceptSteps: []*gauge.Step{
+				{Value: "open login page"},
+				{Value: "enter username {}", Args: []*gauge.StepArg{{Name: "user", Value: "user", ArgType: gauge.Dynamic}}},
+			},
+		}},
+	}}
+
+	got := hoverAt(t, uri, lsp.Position{Line: 2, Character: 4})
+
+	want := "**Concept** defined in `login.cpt:1`\n\n```gauge\n# login as <user>\n* open login page\n* enter username <user>\n```"
+	if got.Contents[0].Value != want {
+		t.Errorf("Wrong hover contents.\ngot:  `%s`\nwant: `%s`", got.Contents[0].Value, want)
+	}
+	if got.Range.Start.Line != 2 || got.Range.End.Character != len("* login as \"admin\"") {
+		t.Errorf("Wrong hover range, got: `%v`", got.Range)
+	}
+}
+
+func TestHoverOnDynamicParamShowsDataTableValues(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, "# Specification\n\n|user|role|\n|----|----|\n|john|admin|\n|mary|guest|\n\n## Scenario\n* login as <user>")
+	provider = conceptInfoProvider{}
+
+	got := hoverAt(t, uri, lsp.Position{Line: 8, Character: len("* login as <us")})
+
+	want := "Values of `<user>`:\n\n1. john\n2. mary\n"
+	if got.Contents[0].Value != want {
+		t.Errorf("Wrong hover contents.\ngot:  `%s`\nwant: `%s`", got.Contents[0].Value, want)
+	}
+	wantRange := lsp.Range{Start: lsp.Position{Line: 8, Character: len("* login as ")}, End: lsp.Position{Line: 8, Character: len("* login as <user>")}}
+	if *got.Range != wantRange {
+		t.Errorf("Wrong hover range, got: `%v`, want: `%v`", got.Range, wantRange)
+	}
+}
+
+func TestHoverPositionsAreInUTF16CodeUnits(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, "# Specification\n\n|user|\n|----|\n|john|\n\n## Scenario\n* 😀😀😀 <user>")
+	provider = conceptInfoProvider{}
+
+	// "* 😀😀😀 " is 9 UTF-16 code units, though 15 bytes.
+	got := hoverAt(t, uri, lsp.Position{Line: 7, Character: 9 + len("<u")})
+
+	want := "Values of `<user>`:\n\n1. john\n"
+	if got.Contents[0].Value != want {
+		t.Errorf("Wrong hover contents.\ngot:  `%s`\nwant: `%s`", got.Contents[0].Value, want)
+	}
+	wantRange := lsp.Range{Start: lsp.Position{Line: 7, Character: 9}, End: lsp.Position{Line: 7, Character: 9 + len("<user>")}}
+	if *got.Range != wantRange {
+		t.Errorf("Wrong hover range, got: `%v`, want: `%v`", got.Range, wantRange)
+	}
+}
+
+func TestHoverOnFileParamShowsFileContents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gauge-hover")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = dir
+	defer func() { config.ProjectRoot = oldRoot }()
+	if err := ioutil.WriteFile(filepath.Join(dir, "payload.json"), []byte("{\n  \"id\": 1\n}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI(filepath.Join(dir, "uri.spec")))
+	openFilesCache.add(uri, "# Specification\n## Scenario\n* post <file:payload.json>")
+	provider = conceptInfoProvider{}
+
+	got := hoverAt(t, uri, lsp.Position{Line: 2, Character: len("* post <fi")})
+
+	want := "`payload.json`\n\n```\n{\n  \"id\": 1\n}\n```"
+	if got.Contents[0].Value != want {
+		t.Errorf("Wrong hover contents.\ngot:  `%s`\nwant: `%s`", got.Contents[0].Value, want)
+	}
+}
+
+func TestHoverOnImplementedStepShowsImplementation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gauge-hover")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	implFile := filepath.Join(dir, "StepImpl.java")
+	impl := "class StepImpl {\n    // Opens the home page.\n    @Step(\"open home page\")\n    public void openHomePage() {\n    }\n}"
+	if err := ioutil.WriteFile(implFile, []byte(impl), 0644); err != nil {
+		t.Fatal(err)
+	}
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, "# Specification\n## Scenario\n* open home page")
+	provider = conceptInfoProvider{}
+	responses := map[gm.Message_MessageType]interface{}{}
+	responses[gm.Message_StepNameResponse] = &gm.StepNameResponse{
+		IsStepPresent: true,
+		StepName:      []string{"open home page"},
+		FileName:      implFile,
+		Span:          &gm.Span{Start: 3, End: 5},
+	}
+	lRunner.runner = &runner.GrpcRunner{LegacyClient: &mockClient{responses: responses}, Timeout: time.Second * 30}
+	lRunner.lspID = "java"
+	defer func() { lRunner.runner = nil; lRunner.lspID = "" }()
+
+	got := hoverAt(t, uri, lsp.Position{Line: 2, Character: 4})
+
+	wantSnippet := "```java\n    // Opens the home page.\n    @Step(\"open home page\")\n    public void openHomePage() {\n```"
+	if !strings.HasPrefix(got.Contents[0].Value, "**Step implementation** in ") || !strings.HasSuffix(got.Contents[0].Value, wantSnippet) {
+		t.Errorf("Wrong hover contents.\ngot:  `%s`\nwant suffix: `%s`", got.Contents[0].Value, wantSnippet)
+	}
+}
+
+func TestHoverOutsideStepsReturnsNothing(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, "# Specification\n## Scenario\n* a step")
+	provider = conceptInfoProvider{}
+	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: lsp.Position{Line: 1, Character: 3}})
+	p := json.RawMessage(b)
+
+	got, err := hover(&jsonrpc2.Request{Params: &p})
+
+	if err != nil || got != nil {
+		t.Errorf("Expected no hover, got: `%v`, err: `%v`", got, err)
+	}
+}
@@ -0,0 +1,272 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const maxInlayHintValueLen = 30
+
+type inlayHintKind int
+
+const (
+	typeInlayHint      inlayHintKind = 1
+	parameterInlayHint inlayHintKind = 2
+)
+
+type inlayHintParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+	Range        lsp.Range                  `json:"range"`
+}
+
+type inlayHint struct {
+	Position     lsp.Position  `json:"position"`
+	Label        string        `json:"label"`
+	Kind         inlayHintKind `json:"kind,omitempty"`
+	Tooltip      string        `json:"tooltip,omitempty"`
+	PaddingLeft  bool          `json:"paddingLeft,omitempty"`
+	PaddingRight bool          `json:"paddingRight,omitempty"`
+}
+
+// selectedRows holds the line of the cursor in each document, as sent by the client with gauge/selectDataTableRow.
+// When the line is a row of a data table, the values of that row are shown instead of the ones of the first row.
+type selectedRows struct {
+	sync.Mutex
+	lines map[lsp.DocumentURI]int
+}
+
+var dataTableSelection = &selectedRows{lines: make(map[lsp.DocumentURI]int)}
+
+func (s *selectedRows) put(uri lsp.DocumentURI, line int) {
+	s.Lock()
+	defer s.Unlock()
+	s.lines[uri] = line
+}
+
+func (s *selectedRows) get(uri lsp.DocumentURI) (int, bool) {
+	s.Lock()
+	defer s.Unlock()
+	line, ok := s.lines[uri]
+	return line, ok
+}
+
+func (s *selectedRows) remove(uri lsp.DocumentURI) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.lines, uri)
+}
+
+func inlayHints(req *jsonrpc2.Request) (interface{}, error) {
+	var params inlayHintParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	uri := params.TextDocument.URI
+	file := util.ConvertURItoFilePath(uri)
+	content := getContent(uri)
+	hints := make([]inlayHint, 0)
+	inRange := func(step *gauge.Step) bool {
+		line := step.LineNo - 1
+		return params.Range.Start.Line <= line && line <= params.Range.End.Line
+	}
+	if util.IsConcept(file) {
+		concepts, _ := new(parser.ConceptParser).Parse(content, file)
+		for _, concept := range concepts {
+			for _, step := range concept.ConceptSteps {
+				if inRange(step) {
+					hints = append(hints, stepInlayHints(uri, step, new(gauge.ArgLookup))...)
+				}
+			}
+		}
+		return hints, nil
+	}
+	spec, _ := new(parser.SpecParser).ParseSpecText(content, file)
+	tokens, _ := new(parser.SpecParser).GenerateTokens(content, file)
+	selectedLine, selected := dataTableSelection.get(uri)
+	rowOf := func(table *gauge.Table) int {
+		if selected {
+			if row, ok := dataTableRowAt(tokens, table, selectedLine); ok {
+				return row
+			}
+		}
+		return 0
+	}
+	specLookup := dataTableLookup(new(gauge.ArgLookup), spec.DataTable.Table, rowOf(spec.DataTable.Table))
+	for _, steps := range [][]*gauge.Step{spec.Contexts, spec.TearDownSteps} {
+		for _, step := range steps {
+			if inRange(step) {
+				hints = append(hints, stepInlayHints(uri, step, specLookup)...)
+			}
+		}
+	}
+	for _, scenario := range spec.Scenarios {
+		lookup := specLookup
+		if scenario.DataTable.IsInitialized() {
+			lookup = dataTableLookup(specLookup, scenario.DataTable.Table, rowOf(scenario.DataTable.Table))
+		}
+		for _, step := range scenario.Steps {
+			if inRange(step) {
+				hints = append(hints, stepInlayHints(uri, step, lookup)...)
+			}
+		}
+	}
+	return hints, nil
+}
+
+// selectDataTableRow records the line of the cursor and asks the client to refresh the inlay hints,
+// so that the values shown follow the data table row under the cursor.
+func selectDataTableRow(req *jsonrpc2.Request, ctx context.Context, conn jsonrpc2.JSONRPC2) error {
+	var params lsp.TextDocumentPositionParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return err
+	}
+	dataTableSelection.put(params.TextDocument.URI, params.Position.Line)
+	var result interface{}
+	return conn.Call(ctx, "workspace/inlayHint/refresh", nil, &result)
+}
+
+// dataTableLookup returns a copy of the lookup with the values of the given row of the table added to it.
+func dataTableLookup(lookup *gauge.ArgLookup, table *gauge.Table, row int) *gauge.ArgLookup {
+	l, err := lookup.GetCopy()
+	if err != nil {
+		return lookup
+	}
+	if table.IsInitialized() && row < table.GetRowCount() {
+		if err := l.ReadDataTableRow(table, row); err != nil {
+			return lookup
+		}
+	}
+	return l
+}
+
+// dataTableRowAt returns the index of the row of the table written at the given line,
+// skipping the separator below the header.
+func dataTableRowAt(tokens []*parser.Token, table *gauge.Table, line int) (int, bool) {
+	if !table.IsInitialized() {
+		return 0, false
+	}
+	for i, t := range tokens {
+		if t.Kind != gauge.TableHeader || t.LineNo != table.LineNo {
+			continue
+		}
+		row := 0
+		for _, r := range tokens[i+1:] {
+			if r.Kind != gauge.TableRow {
+				break
+			}
+			if row == 0 && isTableSeparator(r.Args) {
+				continue
+			}
+			if r.LineNo-1 == line {
+				return row, true
+			}
+			row++
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+func isTableSeparator(cells []string) bool {
+	for _, cell := range cells {
+		if strings.Trim(cell, "-") != "" {
+			return false
+		}
+	}
+	return len(cells) > 0
+}
+
+// stepInlayHints returns the name of the concept param each argument binds to, when the step is a concept invocation,
+// and the value each dynamic param takes from the data table lookup.
+func stepInlayHints(uri lsp.DocumentURI, step *gauge.Step, lookup *gauge.ArgLookup) []inlayHint {
+	line := step.LineNo - 1
+	text := getLine(uri, line)
+	marker := strings.Index(text, "*")
+	if marker == -1 {
+		return nil
+	}
+	params := inlineParamRanges(text, marker+1)
+	var conceptParams []string
+	values := lookup
+	if concept := provider.SearchConceptDictionary(step.Value); concept != nil {
+		conceptParams, values = conceptInvocationLookup(concept, step, lookup)
+	}
+	var hints []inlayHint
+	for i, param := range params {
+		if i >= len(step.Args) {
+			break
+		}
+		if i < len(conceptParams) {
+			hints = append(hints, inlayHint{
+				Position:     lsp.Position{Line: line, Character: utf16Offset(text, param.start)},
+				Label:        conceptParams[i] + ":",
+				Kind:         parameterInlayHint,
+				PaddingRight: true,
+			})
+		}
+		if step.Args[i].ArgType != gauge.Dynamic {
+			continue
+		}
+		name := step.Args[i].Value
+		if i < len(conceptParams) {
+			name = conceptParams[i]
+		}
+		if arg, err := values.GetArg(name); err == nil && arg != nil && arg.ArgType != gauge.Dynamic {
+			hints = append(hints, inlayHint{
+				Position:    lsp.Position{Line: line, Character: utf16Offset(text, param.end)},
+				Label:       "= " + truncateInlayHintValue(arg.Value),
+				Kind:        typeInlayHint,
+				Tooltip:     fmt.Sprintf("<%s> = %s", step.Args[i].Value, arg.Value),
+				PaddingLeft: true,
+			})
+		}
+	}
+	return hints
+}
+
+// conceptInvocationLookup binds the arguments of the step to the params of the concept it invokes,
+// resolving dynamic arguments from the data table lookup the same way the execution does.
+// It returns the names of the concept params in the order of the arguments along with the resolved lookup.
+func conceptInvocationLookup(concept *gauge.Concept, step *gauge.Step, dataTableLookup *gauge.ArgLookup) ([]string, *gauge.ArgLookup) {
+	var names []string
+	invocation := &gauge.Step{Value: step.Value, LineText: step.LineText, Args: step.Args, IsConcept: true}
+	for i, param := range concept.ConceptStep.Args {
+		if i >= len(step.Args) {
+			break
+		}
+		names = append(names, param.Value)
+		invocation.Lookup.AddArgName(param.Value)
+		if err := invocation.Lookup.AddArgValue(param.Value, step.Args[i]); err != nil {
+			return names, &invocation.Lookup
+		}
+	}
+	if err := parser.PopulateConceptDynamicParams(invocation, dataTableLookup); err != nil {
+		logDebug(nil, "unable to resolve the params of concept '%s'. %s", step.Value, err.Error())
+	}
+	return names, &invocation.Lookup
+}
+
+// truncateInlayHintValue shortens the value to maxInlayHintValueLen characters, cutting between runes so that the hint
+// stays valid UTF-8.
+func truncateInlayHintValue(value string) string {
+	value = strings.Replace(value, "\n", " ", -1)
+	if runes := []rune(value); len(runes) > maxInlayHintValueLen {
+		return string(runes[:maxInlayHintValueLen]) + "..."
+	}
+	return value
+}
@@ -0,0 +1,161 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const inlayHintSpec = "# Specification\n\n|user|role|\n|----|----|\n|john|admin|\n|mary|guest|\n\n## Scenario\n* login as <user> with \"secret\"\n* check role <role>"
+
+func inlayHintsFor(t *testing.T, uri lsp.DocumentURI) []inlayHint {
+	b, _ := json.Marshal(inlayHintParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+		Range:        lsp.Range{Start: lsp.Position{Line: 0, Character: 0}, End: lsp.Position{Line: 20, Character: 0}},
+	})
+	p := json.RawMessage(b)
+	got, err := inlayHints(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatalf("Failed to get inlay hints, err: `%v`", err)
+	}
+	return got.([]inlayHint)
+}
+
+func valueHint(line, character int, param, value string) inlayHint {
+	return inlayHint{
+		Position:    lsp.Position{Line: line, Character: character},
+		Label:       "= " + value,
+		Kind:        typeInlayHint,
+		Tooltip:     "<" + param + "> = " + value,
+		PaddingLeft: true,
+	}
+}
+
+func TestInlayHintsShowValuesOfFirstDataTableRow(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, inlayHintSpec)
+	provider = conceptInfoProvider{}
+
+	got := inlayHintsFor(t, uri)
+
+	want := []inlayHint{
+		valueHint(8, len("* login as <user>"), "user", "john"),
+		valueHint(9, len("* check role <role>"), "role", "admin"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong inlay hints.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestInlayHintsShowValuesOfSelectedDataTableRow(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, inlayHintSpec)
+	provider = conceptInfoProvider{}
+	dataTableSelection.put(uri, 5)
+	defer dataTableSelection.remove(uri)
+
+	got := inlayHintsFor(t, uri)
+
+	want := []inlayHint{
+		valueHint(8, len("* login as <user>"), "user", "mary"),
+		valueHint(9, len("* check role <role>"), "role", "guest"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong inlay hints.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestInlayHintsShowConceptParamsOfArguments(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, inlayHintSpec)
+	provider = conceptInfoProvider{concepts: map[string]*gauge.Concept{
+		"login as {} with {}": {FileName: "login.cpt", ConceptStep: &gauge.Step{
+			Value: "login as {} with {}", LineNo: 1, IsConcept: true,
+			Args: []*gauge.StepArg{
+				{Name: "username", Value: "username", ArgType: gauge.Dynamic},
+				{Name: "password", Value: "password", ArgType: gauge.Dynamic},
+			},
+		}},
+	}}
+
+	got := inlayHintsFor(t, uri)
+
+	want := []inlayHint{
+		{Position: lsp.Position{Line: 8, Character: len("* login as ")}, Label: "username:", Kind: parameterInlayHint, PaddingRight: true},
+		valueHint(8, len("* login as <user>"), "user", "john"),
+		{Position: lsp.Position{Line: 8, Character: len("* login as <user> with ")}, Label: "password:", Kind: parameterInlayHint, PaddingRight: true},
+		valueHint(9, len("* check role <role>"), "role", "admin"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong inlay hints.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestInlayHintsOutsideRequestedRange(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, inlayHintSpec)
+	provider = conceptInfoProvider{}
+	b, _ := json.Marshal(inlayHintParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+		Range:        lsp.Range{Start: lsp.Position{Line: 9, Character: 0}, End: lsp.Position{Line: 9, Character: 0}},
+	})
+	p := json.RawMessage(b)
+
+	got, err := inlayHints(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []inlayHint{valueHint(9, len("* check role <role>"), "role", "admin")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong inlay hints.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestInlayHintPositionsAreInUTF16CodeUnits(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, "# Specification\n\n|user|\n|----|\n|jürgen|\n\n## Scenario\n* grüße 😀 <user>")
+	provider = conceptInfoProvider{}
+
+	got := inlayHintsFor(t, uri)
+
+	// "* grüße " is 8 UTF-16 code units, though 10 bytes, and the emoji is 2.
+	want := []inlayHint{valueHint(7, 8+2+len(" <user>"), "user", "jürgen")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong inlay hints.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestTruncateInlayHintValueCutsBetweenRunes(t *testing.T) {
+	value := strings.Repeat("ä", maxInlayHintValueLen) + "😀 more"
+
+	got := truncateInlayHintValue(value)
+
+	if want := strings.Repeat("ä", maxInlayHintValueLen) + "..."; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if !utf8.ValidString(truncateInlayHintValue(strings.Repeat("a", maxInlayHintValueLen-1) + "😀😀")) {
+		t.Errorf("Expected the truncated value to be valid UTF-8")
+	}
+	if got := truncateInlayHintValue("short\nvalue"); got != "short value" {
+		t.Errorf("Expected short values to be kept, got %s", got)
+	}
+}
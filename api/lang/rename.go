@@ -22,6 +22,14 @@ import (
 )
 
 func rename(ctx context.Context, conn jsonrpc2.JSONRPC2, req *jsonrpc2.Request) (interface{}, error) {
+	var params lsp.RenameParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		logDebug(req, "failed to parse rename request %s", err.Error())
+		return nil, err
+	}
+	if edit, ok, err := renameSymbol(params); ok {
+		return edit, err
+	}
 	if err := sendSaveFilesRequest(ctx, conn); err != nil {
 		return nil, err
 	}
@@ -41,7 +49,7 @@ func renameStep(req *jsonrpc2.Request) (interface{}, error) {
 		return nil, err
 	}
 	if step == nil {
-		return nil, fmt.Errorf("refactoring is supported for steps only")
+		return nil, fmt.Errorf("refactoring is supported for steps, concepts, tags and data table columns only")
 	}
 	newName := getNewStepName(params, step)
 
@@ -0,0 +1,304 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+)
+
+// renamer collects the edits of a rename across the files of the project.
+type renamer struct {
+	fileContents
+	edit lsp.WorkspaceEdit
+}
+
+func newRenamer() *renamer {
+	return &renamer{fileContents: make(fileContents), edit: lsp.WorkspaceEdit{Changes: make(map[string][]lsp.TextEdit)}}
+}
+
+// replace replaces the text between the byte offsets start and end of the line.
+func (r *renamer) replace(file string, line, start, end int, text string) {
+	uri := string(util.ConvertPathToURI(file))
+	lineText := r.line(file, line)
+	r.edit.Changes[uri] = append(r.edit.Changes[uri], lsp.TextEdit{Range: lsp.Range{
+		Start: lsp.Position{Line: line, Character: utf16Offset(lineText, start)},
+		End:   lsp.Position{Line: line, Character: utf16Offset(lineText, end)},
+	}, NewText: text})
+}
+
+// renameSymbol renames the concept heading, tag or data table column at the position across the project.
+// It returns false when there is none at the position, leaving the step there to be rephrased by the runner.
+func renameSymbol(params lsp.RenameParams) (lsp.WorkspaceEdit, bool, error) {
+	file := util.ConvertURItoFilePath(params.TextDocument.URI)
+	r := newRenamer()
+	line := params.Position.Line
+	character := byteOffset(r.line(file, line), params.Position.Character)
+	continuation := false
+	for _, t := range r.tokens(file) {
+		if t.LineNo-1 != line {
+			continuation = t.Kind == gauge.TagKind
+			continue
+		}
+		switch {
+		case t.Kind == gauge.SpecKind && util.IsConcept(file):
+			return r.result(r.renameConcept(file, line, params.NewName))
+		case t.Kind == gauge.TagKind && util.IsSpec(file):
+			return r.result(r.renameTag(file, line, character, continuation, params.NewName))
+		case t.Kind == gauge.TableHeader && util.IsSpec(file):
+			handled, err := r.renameColumn(file, line, character, params.NewName)
+			if !handled {
+				return lsp.WorkspaceEdit{}, false, nil
+			}
+			return r.result(err)
+		}
+		return lsp.WorkspaceEdit{}, false, nil
+	}
+	return lsp.WorkspaceEdit{}, false, nil
+}
+
+func (r *renamer) result(err error) (lsp.WorkspaceEdit, bool, error) {
+	if err != nil {
+		return lsp.WorkspaceEdit{}, true, err
+	}
+	return r.edit, true, nil
+}
+
+func (r *renamer) specs() []*gauge.Specification {
+	var specs []*gauge.Specification
+	for _, file := range util.GetSpecFiles(util.GetSpecDirs()) {
+		spec, _ := new(parser.SpecParser).ParseSpecText(r.content(file), file)
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+func (r *renamer) concepts() []*gauge.Step {
+	var concepts []*gauge.Step
+	for _, file := range util.GetConceptFiles() {
+		steps, _ := new(parser.ConceptParser).Parse(r.content(file), file)
+		concepts = append(concepts, steps...)
+	}
+	return concepts
+}
+
+// renameConcept rewrites the heading of the concept and all its usages. The params of the concept can be
+// reordered but not added or removed, and the usages pass the same values in the new order.
+func (r *renamer) renameConcept(file string, line int, newName string) error {
+	var concept *gauge.Step
+	steps, _ := new(parser.ConceptParser).Parse(r.content(file), file)
+	for _, step := range steps {
+		if step.LineNo-1 == line {
+			concept = step
+		}
+	}
+	if concept == nil {
+		return fmt.Errorf("concept at line %d of %s has parse errors", line+1, file)
+	}
+	newName = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(newName), "#"))
+	oldValue, err := parser.ExtractStepValueAndParams(concept.LineText, false)
+	if err != nil {
+		return err
+	}
+	newValue, err := parser.ExtractStepValueAndParams(newName, false)
+	if err != nil {
+		return err
+	}
+	order, err := paramOrder(oldValue.Args, newValue.Args)
+	if err != nil {
+		return err
+	}
+	for _, c := range r.concepts() {
+		if c.Value == newValue.StepValue && c.Value != concept.Value {
+			return fmt.Errorf("concept '%s' already exists", newName)
+		}
+	}
+	text := r.line(file, line)
+	if marker := strings.Index(text, "#"); marker != -1 {
+		r.replace(file, line, marker+1, len(strings.TrimRight(text, " \t")), " "+newName)
+	} else {
+		r.replace(file, line, 0, len(strings.TrimRight(text, " \t")), newName)
+	}
+
+	parts := strings.Split(newValue.StepValue, gauge.ParameterPlaceholder)
+	renameUsage := func(file string, step *gauge.Step) error {
+		if step.Value != concept.Value {
+			return nil
+		}
+		return r.reorderStep(file, step, parts, order)
+	}
+	for _, spec := range r.specs() {
+		for _, step := range spec.Steps() {
+			if err := renameUsage(spec.FileName, step); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range r.concepts() {
+		for _, step := range c.ConceptSteps {
+			if err := renameUsage(c.FileName, step); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// paramOrder returns for each of the new params the index of the old param with the same name.
+func paramOrder(oldParams, newParams []string) ([]int, error) {
+	if len(oldParams) != len(newParams) {
+		return nil, fmt.Errorf("concept params can only be reordered, expected %d params but got %d", len(oldParams), len(newParams))
+	}
+	order := make([]int, len(newParams))
+	for i, p := range newParams {
+		order[i] = -1
+		for j, old := range oldParams {
+			if old == p {
+				order[i] = j
+			}
+		}
+		if order[i] == -1 {
+			return nil, fmt.Errorf("concept param <%s> is not one of the params of the concept", p)
+		}
+	}
+	return order, nil
+}
+
+// reorderStep rewrites the text of a step as the parts of a new step value, passing its params in the given order.
+func (r *renamer) reorderStep(file string, step *gauge.Step, parts []string, order []int) error {
+	line := step.LineNo - 1
+	if step.LineSpanEnd > step.LineNo {
+		return fmt.Errorf("cannot rename the step spanning lines at line %d of %s", step.LineNo, file)
+	}
+	text := r.line(file, line)
+	marker := strings.Index(text, "*")
+	params := inlineParamRanges(text, marker+1)
+	if step.HasInlineTable {
+		table := len(order) - 1
+		if len(order) == 0 || order[table] != table {
+			return fmt.Errorf("cannot move the table param of the step at line %d of %s", step.LineNo, file)
+		}
+		params = append(params, textRange{})
+	}
+	if marker == -1 || len(params) != len(order) {
+		return fmt.Errorf("unable to rename the step at line %d of %s", step.LineNo, file)
+	}
+	var b strings.Builder
+	for i, part := range parts {
+		b.WriteString(part)
+		if i < len(order) {
+			b.WriteString(text[params[order[i]].start:params[order[i]].end])
+		}
+	}
+	end := len(strings.TrimRight(text, " \t"))
+	r.replace(file, line, marker+1, end, " "+strings.TrimSpace(b.String()))
+	return nil
+}
+
+// renameTag rewrites the tag at the byte offset of the line in all the specs.
+func (r *renamer) renameTag(file string, line, character int, continuation bool, newName string) error {
+	text := r.line(file, line)
+	tag := ""
+	for _, v := range tagRanges(text, continuation) {
+		if v.start <= character && character <= v.end {
+			tag = text[v.start:v.end]
+		}
+	}
+	if tag == "" {
+		return fmt.Errorf("no tag at line %d character %d of %s", line+1, character, file)
+	}
+	newName = strings.TrimSpace(newName)
+	if newName == "" || strings.Contains(newName, ",") {
+		return fmt.Errorf("invalid tag name '%s'", newName)
+	}
+	for _, specFile := range util.GetSpecFiles(util.GetSpecDirs()) {
+		continuation := false
+		for _, t := range r.tokens(specFile) {
+			if t.Kind != gauge.TagKind {
+				continuation = false
+				continue
+			}
+			text := r.line(specFile, t.LineNo-1)
+			for _, v := range tagRanges(text, continuation) {
+				if text[v.start:v.end] == tag {
+					r.replace(specFile, t.LineNo-1, v.start, v.end, newName)
+				}
+			}
+			continuation = true
+		}
+	}
+	return nil
+}
+
+// renameColumn rewrites the header of the data table column at the byte offset of the line and the dynamic params which refer to it.
+// It returns false when the header is not one of a data table of the spec or its scenarios.
+func (r *renamer) renameColumn(file string, line, character int, newName string) (bool, error) {
+	spec, _ := new(parser.SpecParser).ParseSpecText(r.content(file), file)
+	var table *gauge.Table
+	var steps []*gauge.Step
+	if spec.DataTable.IsInitialized() && spec.DataTable.Table.LineNo-1 == line {
+		table, steps = spec.DataTable.Table, spec.Steps()
+	}
+	for _, scenario := range spec.Scenarios {
+		if scenario.DataTable.IsInitialized() && scenario.DataTable.Table.LineNo-1 == line {
+			table, steps = scenario.DataTable.Table, scenario.Steps
+		}
+	}
+	if table == nil {
+		return false, nil
+	}
+	text := r.line(file, line)
+	separators := cellSeparators(text)
+	column := -1
+	for i := 0; i+1 < len(separators); i++ {
+		if separators[i] < character && character <= separators[i+1] {
+			column = i
+		}
+	}
+	if column == -1 {
+		return true, fmt.Errorf("no data table column at line %d character %d of %s", line+1, character, file)
+	}
+	cell := text[separators[column]+1 : separators[column+1]]
+	old := strings.TrimSpace(cell)
+	newName = strings.TrimSpace(newName)
+	if newName == "" || strings.ContainsAny(newName, "|<>") {
+		return true, fmt.Errorf("invalid column name '%s'", newName)
+	}
+	if containsString(table.Headers, newName) {
+		return true, fmt.Errorf("data table column '%s' already exists", newName)
+	}
+	start := separators[column] + 1 + strings.Index(cell, old)
+	r.replace(file, line, start, start+len(old), newName)
+
+	param := "<" + old + ">"
+	for _, step := range steps {
+		for l := step.LineNo - 1; l < step.LineNo || l < step.LineSpanEnd; l++ {
+			r.replaceParams(file, l, param, newName)
+		}
+		if !step.HasInlineTable {
+			continue
+		}
+		for _, l := range r.tableLines(file, r.nextLineOf(file, step.LineNo-1, gauge.TableHeader)) {
+			r.replaceParams(file, l, param, newName)
+		}
+	}
+	return true, nil
+}
+
+func (r *renamer) replaceParams(file string, line int, param, name string) {
+	text := r.line(file, line)
+	for _, p := range inlineParamRanges(text, 0) {
+		if text[p.start:p.end] == param {
+			r.replace(file, line, p.start+1, p.end-1, name)
+		}
+	}
+}
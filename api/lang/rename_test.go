@@ -181,3 +181,134 @@ func TestRenameConceptStep(t *testing.T) {
 		}
 	}
 }
+
+func renameAt(t *testing.T, uri lsp.DocumentURI, line, character int, newName string) (lsp.WorkspaceEdit, bool, error) {
+	return renameSymbol(lsp.RenameParams{
+		NewName:      newName,
+		Position:     lsp.Position{Line: line, Character: character},
+		TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+	})
+}
+
+func edit(line, start, end int, text string) lsp.TextEdit {
+	return lsp.TextEdit{Range: lsp.Range{
+		Start: lsp.Position{Line: line, Character: start},
+		End:   lsp.Position{Line: line, Character: end},
+	}, NewText: text}
+}
+
+func TestRenameConceptHeadingReordersParamsOfUsages(t *testing.T) {
+	setup()
+	defer tearDown()
+	cptURI, specURI := util.ConvertPathToURI(conceptFile), util.ConvertPathToURI(specFile)
+	openFilesCache.add(cptURI, "# login as <user> with <password>\n* enter <user>\n\n# login twice\n* login as <user> with \"secret\"\n")
+	openFilesCache.add(specURI, "# Spec\n## Login\n* login as \"john\" with \"pass\"\n* open dashboard\n")
+
+	got, ok, err := renameAt(t, cptURI, 0, 3, "sign in with <password> as <user>")
+
+	if !ok || err != nil {
+		t.Fatalf("Expected the concept to be renamed, got: %v, %v", ok, err)
+	}
+	want := lsp.WorkspaceEdit{Changes: map[string][]lsp.TextEdit{
+		string(cptURI): {
+			edit(0, 1, 33, " sign in with <password> as <user>"),
+			edit(4, 1, 31, " sign in with \"secret\" as <user>"),
+		},
+		string(specURI): {edit(2, 1, 29, " sign in with \"pass\" as \"john\"")},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong edits.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestRenameConceptHeadingFailsWhenParamsChange(t *testing.T) {
+	setup()
+	defer tearDown()
+	cptURI := util.ConvertPathToURI(conceptFile)
+	openFilesCache.add(cptURI, "# login as <user>\n* enter <user>\n")
+
+	_, ok, err := renameAt(t, cptURI, 0, 3, "login as <name>")
+
+	if !ok || err == nil {
+		t.Errorf("Expected rename to fail when a param is not one of the concept, got: %v, %v", ok, err)
+	}
+}
+
+func TestRenameTagAcrossSpecs(t *testing.T) {
+	setup()
+	defer tearDown()
+	otherFile := "bar.spec"
+	util.GetSpecFiles = func(paths []string) []string { return []string{specFile, otherFile} }
+	specURI, otherURI := util.ConvertPathToURI(specFile), util.ConvertPathToURI(otherFile)
+	openFilesCache.add(specURI, "# Spec\ntags: smoke, login,\n  slow\n## Login\ntags: slow\n* open dashboard\n")
+	openFilesCache.add(otherURI, "# Other\ntags: slow\n## Logout\n* logout\n")
+
+	got, ok, err := renameAt(t, specURI, 2, 3, "nightly")
+
+	if !ok || err != nil {
+		t.Fatalf("Expected the tag to be renamed, got: %v, %v", ok, err)
+	}
+	want := lsp.WorkspaceEdit{Changes: map[string][]lsp.TextEdit{
+		string(specURI):  {edit(2, 2, 6, "nightly"), edit(4, 6, 10, "nightly")},
+		string(otherURI): {edit(1, 6, 10, "nightly")},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong edits.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestRenameDataTableColumnUpdatesDynamicParams(t *testing.T) {
+	setup()
+	defer tearDown()
+	specURI := util.ConvertPathToURI(specFile)
+	openFilesCache.add(specURI, "# Spec\n\n| user | role |\n|------|------|\n| john | admin|\n\n## Login\n* login as <user>\n* check\n   |name  |\n   |------|\n   |<user>|\n")
+
+	got, ok, err := renameAt(t, specURI, 2, 3, "name")
+
+	if !ok || err != nil {
+		t.Fatalf("Expected the column to be renamed, got: %v, %v", ok, err)
+	}
+	want := lsp.WorkspaceEdit{Changes: map[string][]lsp.TextEdit{
+		string(specURI): {edit(2, 2, 6, "name"), edit(7, 12, 16, "name"), edit(11, 5, 9, "name")},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong edits.\ngot:  %v\nwant: %v", got, want)
+	}
+
+	if _, _, err := renameAt(t, specURI, 2, 3, "role"); err == nil {
+		t.Error("Expected rename to fail when the column already exists")
+	}
+}
+
+func TestRenamePositionsAreInUTF16CodeUnits(t *testing.T) {
+	setup()
+	defer tearDown()
+	specURI := util.ConvertPathToURI(specFile)
+	openFilesCache.add(specURI, "# Spec\n\n| größe | 😀 |\n|-------|----|\n| klein | ja |\n\n## Smile\n* smile <😀>\n")
+
+	// "| größe | " is 10 UTF-16 code units, though 12 bytes, and the emoji is 2.
+	got, ok, err := renameAt(t, specURI, 2, 11, "face")
+
+	if !ok || err != nil {
+		t.Fatalf("Expected the column to be renamed, got: %v, %v", ok, err)
+	}
+	want := lsp.WorkspaceEdit{Changes: map[string][]lsp.TextEdit{
+		string(specURI): {edit(2, 10, 12, "face"), edit(7, 9, 11, "face")},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong edits.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestRenameFallsBackToStepsOutsideSymbols(t *testing.T) {
+	setup()
+	defer tearDown()
+	specURI := util.ConvertPathToURI(specFile)
+	openFilesCache.add(specURI, "# Spec\n## Login\n* open dashboard\n   |name|\n   |----|\n   |john|\n")
+
+	for _, line := range []int{2, 3} {
+		if _, ok, _ := renameAt(t, specURI, line, 3, "anything"); ok {
+			t.Errorf("Expected no symbol to rename at line %d", line)
+		}
+	}
+}
@@ -79,6 +79,7 @@ func cacheFileOnRunner(uri lsp.DocumentURI, text string, isClosed bool, status g
 		},
 	}
 	_, err := lRunner.runner.ExecuteMessageWithTimeout(r)
+	implementedStepValues.clear()
 	return err
 }
 
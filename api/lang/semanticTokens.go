@@ -0,0 +1,458 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type semanticTokenType int
+
+// The order of the token types has to match semanticTokenTypes, which is sent to the client as the legend.
+const (
+	specHeadingTokenType semanticTokenType = iota
+	scenarioHeadingTokenType
+	stepTokenType
+	conceptTokenType
+	commentTokenType
+	staticParamTokenType
+	dynamicParamTokenType
+	specialParamTokenType
+	keywordTokenType
+	tagTokenType
+	tableHeaderTokenType
+)
+
+var semanticTokenTypes = []string{"namespace", "class", "function", "macro", "comment", "string", "parameter", "variable", "keyword", "decorator", "property"}
+
+// The modifiers are bit flags, in the order of semanticTokenModifiers.
+const (
+	declarationModifier = 1 << iota
+	unimplementedModifier
+)
+
+var semanticTokenModifiers = []string{"declaration", "unimplemented"}
+
+type semanticTokensParams struct {
+	TextDocument lsp.TextDocumentIdentifier `json:"textDocument"`
+}
+
+type semanticTokensDeltaParams struct {
+	TextDocument     lsp.TextDocumentIdentifier `json:"textDocument"`
+	PreviousResultID string                     `json:"previousResultId"`
+}
+
+type semanticTokens struct {
+	ResultID string `json:"resultId,omitempty"`
+	Data     []int  `json:"data"`
+}
+
+type semanticTokensEdit struct {
+	Start       int   `json:"start"`
+	DeleteCount int   `json:"deleteCount"`
+	Data        []int `json:"data,omitempty"`
+}
+
+type semanticTokensDelta struct {
+	ResultID string               `json:"resultId,omitempty"`
+	Edits    []semanticTokensEdit `json:"edits"`
+}
+
+type semanticToken struct {
+	line      int
+	start     int
+	length    int
+	tokenType semanticTokenType
+	modifiers int
+}
+
+// semanticTokensCache holds the last result sent for each document, which the client refers to when asking for a delta.
+type semanticTokensCache struct {
+	sync.Mutex
+	lastID  int
+	results map[lsp.DocumentURI]*semanticTokens
+}
+
+var semanticTokensResults = &semanticTokensCache{results: make(map[lsp.DocumentURI]*semanticTokens)}
+
+func (c *semanticTokensCache) put(uri lsp.DocumentURI, data []int) *semanticTokens {
+	c.Lock()
+	defer c.Unlock()
+	c.lastID++
+	result := &semanticTokens{ResultID: strconv.Itoa(c.lastID), Data: data}
+	c.results[uri] = result
+	return result
+}
+
+func (c *semanticTokensCache) get(uri lsp.DocumentURI) *semanticTokens {
+	c.Lock()
+	defer c.Unlock()
+	return c.results[uri]
+}
+
+func (c *semanticTokensCache) remove(uri lsp.DocumentURI) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.results, uri)
+}
+
+func semanticTokensFull(req *jsonrpc2.Request) (interface{}, error) {
+	var params semanticTokensParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	return semanticTokensResults.put(params.TextDocument.URI, encodeSemanticTokens(documentSemanticTokens(params.TextDocument.URI))), nil
+}
+
+func semanticTokensFullDelta(req *jsonrpc2.Request) (interface{}, error) {
+	var params semanticTokensDeltaParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		return nil, err
+	}
+	previous := semanticTokensResults.get(params.TextDocument.URI)
+	result := semanticTokensResults.put(params.TextDocument.URI, encodeSemanticTokens(documentSemanticTokens(params.TextDocument.URI)))
+	if previous == nil || previous.ResultID != params.PreviousResultID {
+		return result, nil
+	}
+	return semanticTokensDelta{ResultID: result.ResultID, Edits: diffSemanticTokens(previous.Data, result.Data)}, nil
+}
+
+// diffSemanticTokens returns a single edit replacing the part between the common prefix and the common suffix.
+func diffSemanticTokens(old, new []int) []semanticTokensEdit {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+	if prefix == len(old) && prefix == len(new) {
+		return []semanticTokensEdit{}
+	}
+	return []semanticTokensEdit{{Start: prefix, DeleteCount: len(old) - prefix - suffix, Data: new[prefix : len(new)-suffix]}}
+}
+
+// encodeSemanticTokens converts the tokens into the relative integer encoding of the protocol.
+func encodeSemanticTokens(tokens []semanticToken) []int {
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].line != tokens[j].line {
+			return tokens[i].line < tokens[j].line
+		}
+		return tokens[i].start < tokens[j].start
+	})
+	data := make([]int, 0, len(tokens)*5)
+	prevLine, prevStart := 0, 0
+	for _, t := range tokens {
+		deltaStart := t.start
+		if t.line == prevLine {
+			deltaStart = t.start - prevStart
+		}
+		data = append(data, t.line-prevLine, deltaStart, t.length, int(t.tokenType), t.modifiers)
+		prevLine, prevStart = t.line, t.start
+	}
+	return data
+}
+
+type semanticTokensBuilder struct {
+	lines       []string
+	tokens      []semanticToken
+	implemented map[string]bool
+}
+
+func documentSemanticTokens(uri lsp.DocumentURI) []semanticToken {
+	file := util.ConvertURItoFilePath(uri)
+	content := getContent(uri)
+	b := &semanticTokensBuilder{lines: util.GetLinesFromText(content), implemented: implementedStepValues.get()}
+	steps := make(map[int]*gauge.Step)
+	isConcept := util.IsConcept(file)
+	if isConcept {
+		concepts, _ := new(parser.ConceptParser).Parse(content, file)
+		for _, concept := range concepts {
+			steps[concept.LineNo] = concept
+			for _, step := range concept.ConceptSteps {
+				steps[step.LineNo] = step
+			}
+		}
+	} else {
+		spec, _ := new(parser.SpecParser).ParseSpecText(content, file)
+		for _, item := range spec.AllItems() {
+			if item.Kind() == gauge.StepKind {
+				steps[item.(*gauge.Step).LineNo] = item.(*gauge.Step)
+			}
+		}
+	}
+	tokens, _ := new(parser.SpecParser).GenerateTokens(content, file)
+	for _, token := range tokens {
+		line := token.LineNo - 1
+		switch token.Kind {
+		case gauge.SpecKind:
+			if isConcept {
+				b.addStep(line, headingTextStart(b.line(line)), steps[token.LineNo], conceptTokenType, declarationModifier)
+			} else {
+				b.addTrimmed(line, 0, len(b.line(line)), specHeadingTokenType, 0)
+			}
+		case gauge.ScenarioKind:
+			b.addTrimmed(line, 0, len(b.line(line)), scenarioHeadingTokenType, 0)
+		case gauge.StepKind:
+			b.addStepUsage(line, steps[token.LineNo])
+		case gauge.CommentKind:
+			b.addTrimmed(line, 0, len(b.line(line)), commentTokenType, 0)
+		case gauge.FrontMatterKind:
+			for l := line; l < token.SpanEnd; l++ {
+				b.addTrimmed(l, 0, len(b.line(l)), commentTokenType, 0)
+			}
+		case gauge.TagKind:
+			b.addKeywordAndValues(line, ",", tagTokenType)
+		case gauge.DataTableKind:
+			b.addKeywordAndValues(line, "", specialParamTokenType)
+		case gauge.TearDownKind:
+			b.addTrimmed(line, 0, len(b.line(line)), keywordTokenType, 0)
+		case gauge.TableHeader:
+			b.addTableCells(line, func(string) (semanticTokenType, bool) { return tableHeaderTokenType, true })
+		case gauge.TableRow:
+			b.addTableCells(line, func(cell string) (semanticTokenType, bool) {
+				return dynamicParamTokenType, strings.HasPrefix(cell, "<") && strings.HasSuffix(cell, ">")
+			})
+		}
+	}
+	return b.tokens
+}
+
+// implementedStepsCache holds the step values implemented by the runner, so that the runner is not asked for them on
+// every request. It is cleared whenever a file is sent to the runner, since that is when the implementations change,
+// and when the runner is restarted.
+type implementedStepsCache struct {
+	sync.Mutex
+	runner runner.Runner
+	steps  map[string]bool
+}
+
+var implementedStepValues = &implementedStepsCache{}
+
+// get returns the step values implemented by the runner, or nil if the runner is not available.
+func (c *implementedStepsCache) get() map[string]bool {
+	c.Lock()
+	defer c.Unlock()
+	if lRunner.runner == nil {
+		return nil
+	}
+	if c.steps != nil && c.runner == lRunner.runner {
+		return c.steps
+	}
+	stepValues, err := allImplementedStepValues()
+	if err != nil {
+		return nil
+	}
+	c.steps = make(map[string]bool, len(stepValues))
+	for _, stepValue := range stepValues {
+		c.steps[stepValue.StepValue] = true
+	}
+	c.runner = lRunner.runner
+	return c.steps
+}
+
+func (c *implementedStepsCache) clear() {
+	c.Lock()
+	defer c.Unlock()
+	c.steps = nil
+}
+
+func (b *semanticTokensBuilder) line(line int) string {
+	if line < 0 || line >= len(b.lines) {
+		return ""
+	}
+	return b.lines[line]
+}
+
+// add adds a token for the given byte range of the line. The start and length of tokens are sent in UTF-16 code units.
+func (b *semanticTokensBuilder) add(line, start, length int, tokenType semanticTokenType, modifiers int) {
+	if length <= 0 {
+		return
+	}
+	text := b.line(line)
+	start, end := utf16Offset(text, start), utf16Offset(text, start+length)
+	b.tokens = append(b.tokens, semanticToken{line: line, start: start, length: end - start, tokenType: tokenType, modifiers: modifiers})
+}
+
+// addTrimmed adds a token for the text between start and end of the line, excluding surrounding whitespace.
+func (b *semanticTokensBuilder) addTrimmed(line, start, end int, tokenType semanticTokenType, modifiers int) {
+	text := b.line(line)[start:end]
+	trimmedLeft := strings.TrimLeft(text, " \t")
+	trimmed := strings.TrimRight(trimmedLeft, " \t")
+	b.add(line, start+len(text)-len(trimmedLeft), len(trimmed), tokenType, modifiers)
+}
+
+func (b *semanticTokensBuilder) addStepUsage(line int, step *gauge.Step) {
+	tokenType, modifiers := stepTokenType, 0
+	if step != nil && provider.SearchConceptDictionary(step.Value) != nil {
+		tokenType = conceptTokenType
+	} else if step != nil && b.implemented != nil && !b.implemented[step.Value] {
+		modifiers = unimplementedModifier
+	}
+	b.addStep(line, strings.Index(b.line(line), "*")+1, step, tokenType, modifiers)
+}
+
+// headingTextStart returns where the text of a heading starts, after the '#', or at the start of the line for
+// headings underlined with '='.
+func headingTextStart(text string) int {
+	if strings.HasPrefix(strings.TrimSpace(text), "#") {
+		return strings.Index(text, "#") + 1
+	}
+	return 0
+}
+
+// addStep adds tokens for the text of a step or a concept heading, which starts at the given byte offset of the
+// line, and for each of its inline params. The kind of each param is taken from the fragments of the parsed step.
+func (b *semanticTokensBuilder) addStep(line int, textStart int, step *gauge.Step, tokenType semanticTokenType, modifiers int) {
+	text := b.line(line)
+	paramTypes := fragmentParamTypes(step)
+	for i, param := range inlineParamRanges(text, textStart) {
+		b.addTrimmed(line, textStart, param.start, tokenType, modifiers)
+		b.add(line, param.start, param.end-param.start, paramTokenType(text[param.start:param.end], paramTypes, i), 0)
+		textStart = param.end
+	}
+	b.addTrimmed(line, textStart, len(text), tokenType, modifiers)
+}
+
+// textRange is a range of characters of a line, start inclusive and end exclusive.
+type textRange struct {
+	start int
+	end   int
+}
+
+// inlineParamRanges returns the ranges of the quoted and angle bracketed params of a step line,
+// including the quotes and brackets, scanning the text from the given character.
+func inlineParamRanges(text string, from int) []textRange {
+	var ranges []textRange
+	for i := from; i < len(text); {
+		var end int
+		switch text[i] {
+		case '"':
+			end = closingQuote(text, i)
+		case '<':
+			end = strings.Index(text[i:], ">")
+			if end != -1 {
+				end += i
+			}
+		default:
+			i++
+			continue
+		}
+		if end == -1 {
+			break
+		}
+		ranges = append(ranges, textRange{start: i, end: end + 1})
+		i = end + 1
+	}
+	return ranges
+}
+
+func closingQuote(text string, start int) int {
+	for i := start + 1; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+		if text[i] == '"' {
+			return i
+		}
+	}
+	return -1
+}
+
+func fragmentParamTypes(step *gauge.Step) []semanticTokenType {
+	if step == nil {
+		return nil
+	}
+	var types []semanticTokenType
+	for _, f := range step.GetFragments() {
+		if f.GetFragmentType() != gm.Fragment_Parameter {
+			continue
+		}
+		switch f.GetParameter().GetParameterType() {
+		case gm.Parameter_Static:
+			types = append(types, staticParamTokenType)
+		case gm.Parameter_Dynamic:
+			types = append(types, dynamicParamTokenType)
+		case gm.Parameter_Special_String, gm.Parameter_Special_Table:
+			types = append(types, specialParamTokenType)
+		}
+	}
+	return types
+}
+
+// paramTokenType returns the type of the param at the given index, falling back to its syntax if the step
+// could not be parsed. Special params which could not be resolved are still reported as special params.
+func paramTokenType(param string, paramTypes []semanticTokenType, index int) semanticTokenType {
+	if strings.HasPrefix(param, "<file:") || strings.HasPrefix(param, "<table:") {
+		return specialParamTokenType
+	}
+	if index < len(paramTypes) {
+		return paramTypes[index]
+	}
+	if strings.HasPrefix(param, "\"") {
+		return staticParamTokenType
+	}
+	return dynamicParamTokenType
+}
+
+// addKeywordAndValues adds a keyword token for the text up to the first ':' and a token of valueType for
+// each of the values following it, separated by the given separator.
+func (b *semanticTokensBuilder) addKeywordAndValues(line int, separator string, valueType semanticTokenType) {
+	text := b.line(line)
+	colon := strings.Index(text, ":")
+	if colon == -1 {
+		return
+	}
+	b.addTrimmed(line, 0, colon+1, keywordTokenType, 0)
+	start := colon + 1
+	if separator == "" {
+		b.addTrimmed(line, start, len(text), valueType, 0)
+		return
+	}
+	for _, value := range strings.Split(text[start:], separator) {
+		b.addTrimmed(line, start, start+len(value), valueType, 0)
+		start += len(value) + len(separator)
+	}
+}
+
+func (b *semanticTokensBuilder) addTableCells(line int, tokenTypeOf func(cell string) (semanticTokenType, bool)) {
+	text := b.line(line)
+	first := strings.Index(text, "|")
+	if first == -1 {
+		return
+	}
+	start := first + 1
+	for start < len(text) {
+		end := strings.Index(text[start:], "|")
+		if end == -1 {
+			end = len(text)
+		} else {
+			end += start
+		}
+		cell := strings.TrimSpace(text[start:end])
+		if cell != "" && strings.Trim(cell, "-") != "" {
+			if tokenType, ok := tokenTypeOf(cell); ok {
+				b.addTrimmed(line, start, end, tokenType, 0)
+			}
+		}
+		start = end + 1
+	}
+}
@@ -0,0 +1,218 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func semanticTokensRequest(t *testing.T, params interface{}) *jsonrpc2.Request {
+	b, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := json.RawMessage(b)
+	return &jsonrpc2.Request{Params: &p}
+}
+
+func TestSemanticTokensForSpec(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec\nTags: smoke, fast\n## Scenario\n* say \"hi\" to <name>\n* login as <file:user.txt>")
+	provider = conceptInfoProvider{}
+	lRunner.runner = nil
+
+	got := documentSemanticTokens(uri)
+
+	want := []semanticToken{
+		{line: 0, start: 0, length: 6, tokenType: specHeadingTokenType},
+		{line: 1, start: 0, length: 5, tokenType: keywordTokenType},
+		{line: 1, start: 6, length: 5, tokenType: tagTokenType},
+		{line: 1, start: 13, length: 4, tokenType: tagTokenType},
+		{line: 2, start: 0, length: 11, tokenType: scenarioHeadingTokenType},
+		{line: 3, start: 2, length: 3, tokenType: stepTokenType},
+		{line: 3, start: 6, length: 4, tokenType: staticParamTokenType},
+		{line: 3, start: 11, length: 2, tokenType: stepTokenType},
+		{line: 3, start: 14, length: 6, tokenType: dynamicParamTokenType},
+		{line: 4, start: 2, length: 8, tokenType: stepTokenType},
+		{line: 4, start: 11, length: 15, tokenType: specialParamTokenType},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong semantic tokens.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestSemanticTokensMarkConceptsAndUnimplementedSteps(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec\n## Scenario\n* a concept\n* implemented\n* missing")
+	provider = conceptInfoProvider{concepts: map[string]*gauge.Concept{"a concept": {FileName: "foo.cpt", ConceptStep: &gauge.Step{Value: "a concept"}}}}
+	responses := map[gm.Message_MessageType]interface{}{}
+	responses[gm.Message_StepNamesResponse] = &gm.StepNamesResponse{Steps: []string{"implemented"}}
+	lRunner.runner = &runner.GrpcRunner{LegacyClient: &mockClient{responses: responses}, Timeout: time.Second * 30}
+	defer func() { lRunner.runner = nil }()
+
+	got := documentSemanticTokens(uri)[2:]
+
+	want := []semanticToken{
+		{line: 2, start: 2, length: 9, tokenType: conceptTokenType},
+		{line: 3, start: 2, length: 11, tokenType: stepTokenType},
+		{line: 4, start: 2, length: 7, tokenType: stepTokenType, modifiers: unimplementedModifier},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong semantic tokens.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestSemanticTokensForConcept(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.cpt"))
+	openFilesCache.add(uri, "# login as <user>\n* enter <user>")
+	provider = conceptInfoProvider{}
+	lRunner.runner = nil
+
+	got := documentSemanticTokens(uri)
+
+	want := []semanticToken{
+		{line: 0, start: 2, length: 8, tokenType: conceptTokenType, modifiers: declarationModifier},
+		{line: 0, start: 11, length: 6, tokenType: dynamicParamTokenType},
+		{line: 1, start: 2, length: 5, tokenType: stepTokenType},
+		{line: 1, start: 8, length: 6, tokenType: dynamicParamTokenType},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong semantic tokens.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestSemanticTokensForConceptWithUnderlinedHeading(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.cpt"))
+	openFilesCache.add(uri, "login as <user>\n===============\n* enter <user>")
+	provider = conceptInfoProvider{}
+	lRunner.runner = nil
+
+	got := documentSemanticTokens(uri)[:2]
+
+	want := []semanticToken{
+		{line: 0, start: 0, length: 8, tokenType: conceptTokenType, modifiers: declarationModifier},
+		{line: 0, start: 9, length: 6, tokenType: dynamicParamTokenType},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong semantic tokens.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestSemanticTokenPositionsAreInUTF16CodeUnits(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec\n## Scenario\n* grüße 😀 \"hi\"")
+	provider = conceptInfoProvider{}
+	lRunner.runner = nil
+
+	got := documentSemanticTokens(uri)[2:]
+
+	// "grüße 😀" is 8 UTF-16 code units, though 12 bytes.
+	want := []semanticToken{
+		{line: 2, start: 2, length: 8, tokenType: stepTokenType},
+		{line: 2, start: 11, length: 4, tokenType: staticParamTokenType},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong semantic tokens.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestImplementedStepsAreCachedUntilAFileIsSentToTheRunner(t *testing.T) {
+	responses := map[gm.Message_MessageType]interface{}{}
+	responses[gm.Message_StepNamesResponse] = &gm.StepNamesResponse{Steps: []string{"first"}}
+	lRunner.runner = &runner.GrpcRunner{LegacyClient: &mockClient{responses: responses}, Timeout: time.Second * 30}
+	defer func() { lRunner.runner = nil }()
+	implementedStepValues.clear()
+
+	if got := implementedStepValues.get(); !got["first"] {
+		t.Fatalf("Expected the steps of the runner, got %v", got)
+	}
+	responses[gm.Message_StepNamesResponse] = &gm.StepNamesResponse{Steps: []string{"second"}}
+	if got := implementedStepValues.get(); !got["first"] || got["second"] {
+		t.Errorf("Expected the cached steps, got %v", got)
+	}
+
+	if err := cacheFileOnRunner(lsp.DocumentURI(util.ConvertPathToURI("StepImpl.java")), "", false, gm.CacheFileRequest_CHANGED); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := implementedStepValues.get(); got["first"] || !got["second"] {
+		t.Errorf("Expected the steps of the runner after a change, got %v", got)
+	}
+}
+
+func TestEncodeSemanticTokens(t *testing.T) {
+	tokens := []semanticToken{
+		{line: 2, start: 5, length: 3, tokenType: staticParamTokenType},
+		{line: 0, start: 0, length: 6, tokenType: specHeadingTokenType},
+		{line: 2, start: 2, length: 2, tokenType: stepTokenType, modifiers: unimplementedModifier},
+	}
+
+	got := encodeSemanticTokens(tokens)
+
+	want := []int{0, 0, 6, int(specHeadingTokenType), 0, 2, 2, 2, int(stepTokenType), unimplementedModifier, 0, 3, 3, int(staticParamTokenType), 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong encoding.\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestSemanticTokensDelta(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	semanticTokensResults = &semanticTokensCache{results: make(map[lsp.DocumentURI]*semanticTokens)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec\n## Scenario\n* a step")
+	provider = conceptInfoProvider{}
+	lRunner.runner = nil
+
+	full, err := semanticTokensFull(semanticTokensRequest(t, semanticTokensParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	openFilesCache.add(uri, "# Spec\n## Scenario\n* a step\n* another step")
+	delta, err := semanticTokensFullDelta(semanticTokensRequest(t, semanticTokensDeltaParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, PreviousResultID: full.(*semanticTokens).ResultID}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := semanticTokensDelta{ResultID: "2", Edits: []semanticTokensEdit{{Start: 15, DeleteCount: 0, Data: []int{1, 2, 12, int(stepTokenType), 0}}}}
+	if !reflect.DeepEqual(delta, want) {
+		t.Errorf("Wrong delta.\ngot:  %v\nwant: %v", delta, want)
+	}
+}
+
+func TestSemanticTokensDeltaWithUnknownPreviousResultReturnsFullTokens(t *testing.T) {
+	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	semanticTokensResults = &semanticTokensCache{results: make(map[lsp.DocumentURI]*semanticTokens)}
+	uri := lsp.DocumentURI(util.ConvertPathToURI("foo.spec"))
+	openFilesCache.add(uri, "# Spec")
+	provider = conceptInfoProvider{}
+	lRunner.runner = nil
+
+	got, err := semanticTokensFullDelta(semanticTokensRequest(t, semanticTokensDeltaParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, PreviousResultID: "42"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &semanticTokens{ResultID: "1", Data: []int{0, 0, 6, int(specHeadingTokenType), 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong result.\ngot:  %v\nwant: %v", got, want)
+	}
+}
@@ -138,6 +138,66 @@ func (h *LangHandler) Handle(ctx context.Context, conn jsonrpc2.JSONRPC2, req *j
 
 		}
 		return val, err
+	case "textDocument/hover":
+		val, err := hover(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "textDocument/semanticTokens/full":
+		val, err := semanticTokensFull(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "textDocument/semanticTokens/full/delta":
+		val, err := semanticTokensFullDelta(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "textDocument/foldingRange":
+		val, err := foldingRanges(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "textDocument/selectionRange":
+		val, err := selectionRanges(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "textDocument/documentLink":
+		val, err := documentLinks(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "textDocument/inlayHint":
+		val, err := inlayHints(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "textDocument/prepareCallHierarchy":
+		val, err := prepareCallHierarchy(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "callHierarchy/incomingCalls":
+		val, err := incomingCalls(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
+	case "callHierarchy/outgoingCalls":
+		val, err := outgoingCalls(req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
 	case "textDocument/formatting":
 		data, err := format(req)
 		if err != nil {
@@ -160,6 +220,12 @@ func (h *LangHandler) Handle(ctx context.Context, conn jsonrpc2.JSONRPC2, req *j
 			logDebug(req, err.Error())
 		}
 		return val, err
+	case "workspace/executeCommand":
+		val, err := executeWorkspaceCommand(ctx, conn, req)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return val, err
 	case "textDocument/rename":
 		result, err := rename(ctx, conn, req)
 		if err != nil {
@@ -242,6 +308,12 @@ func (h *LangHandler) Handle(ctx context.Context, conn jsonrpc2.JSONRPC2, req *j
 			return nil, err
 		}
 		return generateConcept(req)
+	case "gauge/selectDataTableRow":
+		err := selectDataTableRow(req, ctx, conn)
+		if err != nil {
+			logDebug(req, err.Error())
+		}
+		return nil, err
 	case "gauge/getRunnerLanguage":
 		return lRunner.lspID, nil
 	case "gauge/specDirs":
@@ -0,0 +1,560 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+)
+
+// The warnings are reported with warningSource as source and one of these codes, which identify the quick fixes.
+const (
+	warningSource         = "gauge"
+	unusedConceptCode     = "unused-concept"
+	duplicateScenarioCode = parser.DuplicateScenarioCode
+	emptyScenarioCode     = parser.EmptyScenarioCode
+	unusedTableColumnCode = "unused-table-column"
+	disallowedTagCode     = "disallowed-tag"
+	similarStepCode       = "similar-step"
+)
+
+type quickFix struct {
+	code  string
+	line  int
+	title string
+	edit  lsp.WorkspaceEdit
+}
+
+// quickFixCache holds the quick fixes of the warnings last published, they are offered as code actions.
+type quickFixCache struct {
+	sync.Mutex
+	fixes map[lsp.DocumentURI][]quickFix
+}
+
+var quickFixes = &quickFixCache{fixes: make(map[lsp.DocumentURI][]quickFix)}
+
+func (c *quickFixCache) replace(fixes map[lsp.DocumentURI][]quickFix) {
+	c.Lock()
+	defer c.Unlock()
+	c.fixes = fixes
+}
+
+func (c *quickFixCache) get(uri lsp.DocumentURI, code string, line int) []quickFix {
+	c.Lock()
+	defer c.Unlock()
+	var fixes []quickFix
+	for _, f := range c.fixes[uri] {
+		if f.code == code && f.line == line {
+			fixes = append(fixes, f)
+		}
+	}
+	return fixes
+}
+
+type warnings struct {
+	fileContents
+	diagnostics map[lsp.DocumentURI][]lsp.Diagnostic
+	fixes       map[lsp.DocumentURI][]quickFix
+}
+
+// fileContents reads the files of the project once, preferring the text of the files open in the editor. The lines
+// and the tokens of a file are also computed once.
+type fileContents map[string]*fileContent
+
+type fileContent struct {
+	text   string
+	lines  []string
+	tokens []*parser.Token
+}
+
+// createWarningDiagnostics adds the warnings enabled in the environment for the specs and concepts, which are parsed without errors.
+func createWarningDiagnostics(specs []*gauge.Specification, conceptDictionary *gauge.ConceptDictionary, diagnostics map[lsp.DocumentURI][]lsp.Diagnostic) {
+	w := &warnings{fileContents: make(fileContents), diagnostics: diagnostics, fixes: make(map[lsp.DocumentURI][]quickFix)}
+	concepts := sortedConcepts(conceptDictionary)
+	if env.WarnUnusedConcepts() {
+		w.unusedConcepts(specs, concepts)
+	}
+	for _, spec := range specs {
+		if env.WarnDuplicateScenarios() {
+			w.duplicateScenarios(spec)
+		}
+		if env.WarnUnusedTableColumns() {
+			w.unusedTableColumns(spec)
+		}
+		if allowed := env.AllowedTags(); len(allowed) > 0 {
+			w.disallowedTags(spec.FileName, allowed)
+		}
+	}
+	if env.WarnSimilarSteps() {
+		w.similarSteps(specs, concepts)
+	}
+	w.parseErrorQuickFixes()
+	quickFixes.replace(w.fixes)
+}
+
+func sortedConcepts(conceptDictionary *gauge.ConceptDictionary) []*gauge.Concept {
+	var concepts []*gauge.Concept
+	for _, concept := range conceptDictionary.ConceptsMap {
+		concepts = append(concepts, concept)
+	}
+	sort.Slice(concepts, func(i, j int) bool {
+		if concepts[i].FileName != concepts[j].FileName {
+			return concepts[i].FileName < concepts[j].FileName
+		}
+		return concepts[i].ConceptStep.LineNo < concepts[j].ConceptStep.LineNo
+	})
+	return concepts
+}
+
+func (w *warnings) add(file, code string, line int, message string, fixes ...quickFix) {
+	uri := util.ConvertPathToURI(file)
+	d := createDiagnostic(uri, message, line, line, 2)
+	d.Source = warningSource
+	d.Code = code
+	w.diagnostics[uri] = append(w.diagnostics[uri], d)
+	for _, f := range fixes {
+		f.code, f.line = code, line
+		w.fixes[uri] = append(w.fixes[uri], f)
+	}
+}
+
+func (f fileContents) file(file string) *fileContent {
+	if c, ok := f[file]; ok {
+		return c
+	}
+	text, err := getContentFromFileOrDisk(file)
+	if err != nil {
+		logDebug(nil, "unable to read %s. %s", file, err.Error())
+	}
+	c := &fileContent{text: text, lines: util.GetLinesFromText(text)}
+	f[file] = c
+	return c
+}
+
+func (f fileContents) content(file string) string {
+	return f.file(file).text
+}
+
+func (f fileContents) line(file string, line int) string {
+	lines := f.file(file).lines
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return lines[line]
+}
+
+func (f fileContents) tokens(file string) []*parser.Token {
+	c := f.file(file)
+	if c.tokens == nil {
+		c.tokens, _ = new(parser.SpecParser).GenerateTokens(c.text, file)
+		if c.tokens == nil {
+			c.tokens = []*parser.Token{}
+		}
+	}
+	return c.tokens
+}
+
+// nextLineOf returns the zero based line of the first token of the given kinds after the line, or the number of lines of the file.
+func (f fileContents) nextLineOf(file string, line int, kinds ...gauge.TokenKind) int {
+	for _, t := range f.tokens(file) {
+		if t.LineNo-1 <= line {
+			continue
+		}
+		for _, kind := range kinds {
+			if t.Kind == kind {
+				return t.LineNo - 1
+			}
+		}
+	}
+	return len(f.file(file).lines)
+}
+
+func editOf(file string, edits ...lsp.TextEdit) lsp.WorkspaceEdit {
+	return lsp.WorkspaceEdit{Changes: map[string][]lsp.TextEdit{string(util.ConvertPathToURI(file)): edits}}
+}
+
+func deleteLines(file string, start, end int) lsp.WorkspaceEdit {
+	return editOf(file, lsp.TextEdit{Range: lsp.Range{
+		Start: lsp.Position{Line: start, Character: 0},
+		End:   lsp.Position{Line: end, Character: 0},
+	}})
+}
+
+// replaceText replaces the text between the byte offsets start and end of the line.
+func (f fileContents) replaceText(file string, line, start, end int, text string) lsp.WorkspaceEdit {
+	lineText := f.line(file, line)
+	return editOf(file, lsp.TextEdit{Range: lsp.Range{
+		Start: lsp.Position{Line: line, Character: utf16Offset(lineText, start)},
+		End:   lsp.Position{Line: line, Character: utf16Offset(lineText, end)},
+	}, NewText: text})
+}
+
+func (w *warnings) unusedConcepts(specs []*gauge.Specification, concepts []*gauge.Concept) {
+	used := make(map[string]bool)
+	for _, spec := range specs {
+		for _, step := range spec.Steps() {
+			used[step.Value] = true
+		}
+	}
+	for _, concept := range concepts {
+		for _, step := range concept.ConceptStep.ConceptSteps {
+			used[step.Value] = true
+		}
+	}
+	for _, concept := range concepts {
+		if used[concept.ConceptStep.Value] {
+			continue
+		}
+		line := concept.ConceptStep.LineNo - 1
+		end := w.nextLineOf(concept.FileName, line, gauge.SpecKind)
+		w.add(concept.FileName, unusedConceptCode, line,
+			fmt.Sprintf("Concept '%s' is not used by any specification or concept", concept.ConceptStep.LineText),
+			quickFix{title: "Remove unused concept", edit: deleteLines(concept.FileName, line, end)})
+	}
+}
+
+// duplicateScenarios warns about scenario headings which differ from an earlier one in the spec only by whitespace.
+// Headings which differ only by case are already reported by the parser.
+func (w *warnings) duplicateScenarios(spec *gauge.Specification) {
+	seen := make(map[string]int)
+	for _, scenario := range spec.Scenarios {
+		heading, line := scenario.Heading.Value, scenario.Heading.LineNo-1
+		key := normalizedText(heading)
+		first, ok := seen[key]
+		if !ok {
+			seen[key] = line
+			continue
+		}
+		w.add(spec.FileName, duplicateScenarioCode, line, fmt.Sprintf("Scenario heading '%s' duplicates the one at line %d", heading, first+1), w.renameScenario(spec.FileName, line)...)
+	}
+}
+
+// parseErrorQuickFixes offers quick fixes for the parse errors of empty and duplicate scenarios,
+// which are found by the parser and hence not reported as warnings.
+func (w *warnings) parseErrorQuickFixes() {
+	for uri, diagnostics := range w.diagnostics {
+		file := util.ConvertURItoFilePath(uri)
+		for i, d := range diagnostics {
+			if d.Severity != 1 || d.Source != "" {
+				continue
+			}
+			line := d.Range.Start.Line
+			var fixes []quickFix
+			switch d.Code {
+			case emptyScenarioCode:
+				end := w.nextLineOf(file, line, gauge.ScenarioKind, gauge.TearDownKind)
+				fixes = append(fixes, quickFix{title: "Remove empty scenario", edit: deleteLines(file, line, end)})
+			case duplicateScenarioCode:
+				fixes = w.renameScenario(file, line)
+			default:
+				continue
+			}
+			diagnostics[i].Source = warningSource
+			for _, f := range fixes {
+				f.code, f.line = d.Code, line
+				w.fixes[uri] = append(w.fixes[uri], f)
+			}
+		}
+	}
+}
+
+// renameScenario offers to rename the scenario heading at the line by numbering it, e.g. `Login 2`.
+func (w *warnings) renameScenario(file string, line int) []quickFix {
+	var headings []string
+	heading := ""
+	for _, t := range w.tokens(file) {
+		if t.Kind != gauge.ScenarioKind {
+			continue
+		}
+		headings = append(headings, normalizedText(t.Value))
+		if t.LineNo-1 == line {
+			heading = t.Value
+		}
+	}
+	text := w.line(file, line)
+	start := strings.Index(text, heading)
+	if heading == "" || start == -1 {
+		return nil
+	}
+	name := heading
+	for n := 2; containsString(headings, normalizedText(name)); n++ {
+		name = fmt.Sprintf("%s %d", strings.Join(strings.Fields(heading), " "), n)
+	}
+	return []quickFix{{
+		title: fmt.Sprintf("Rename scenario to '%s'", name),
+		edit:  w.replaceText(file, line, start, start+len(heading), name),
+	}}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *warnings) unusedTableColumns(spec *gauge.Specification) {
+	w.unusedColumnsOf(spec.FileName, spec.DataTable, spec.Steps())
+	for _, scenario := range spec.Scenarios {
+		w.unusedColumnsOf(spec.FileName, scenario.DataTable, scenario.Steps)
+	}
+}
+
+func (w *warnings) unusedColumnsOf(file string, dataTable gauge.DataTable, steps []*gauge.Step) {
+	if !dataTable.IsInitialized() {
+		return
+	}
+	used := make(map[string]bool)
+	for _, step := range steps {
+		for _, arg := range step.Args {
+			switch arg.ArgType {
+			case gauge.Dynamic:
+				used[arg.Value] = true
+			case gauge.TableArg:
+				for _, name := range arg.Table.GetDynamicArgs() {
+					used[name] = true
+				}
+			}
+		}
+	}
+	table := dataTable.Table
+	rows := w.tableLines(file, table.LineNo-1)
+	for i, header := range table.Headers {
+		if used[header] {
+			continue
+		}
+		var fixes []quickFix
+		if len(rows) > 0 {
+			fixes = append(fixes, quickFix{title: fmt.Sprintf("Remove column '%s'", header), edit: w.removeColumn(file, rows, i)})
+		}
+		w.add(file, unusedTableColumnCode, table.LineNo-1, fmt.Sprintf("Data table column '%s' is not used by any step", header), fixes...)
+	}
+}
+
+// tableLines returns the lines of the inline table with the header at the given line, or nil if the table is an external one.
+func (f fileContents) tableLines(file string, header int) []int {
+	var lines []int
+	for _, t := range f.tokens(file) {
+		switch {
+		case t.LineNo-1 == header && t.Kind == gauge.TableHeader:
+			lines = append(lines, header)
+		case len(lines) > 0 && t.Kind == gauge.TableRow:
+			lines = append(lines, t.LineNo-1)
+		case len(lines) > 0:
+			return lines
+		}
+	}
+	return lines
+}
+
+// removeColumn deletes the cell at the index, along with its leading separator, from each of the lines of a table.
+func (f fileContents) removeColumn(file string, lines []int, index int) lsp.WorkspaceEdit {
+	var edits []lsp.TextEdit
+	for _, line := range lines {
+		text := f.line(file, line)
+		separators := cellSeparators(text)
+		if len(separators) <= index+1 {
+			continue
+		}
+		edits = append(edits, lsp.TextEdit{Range: lsp.Range{
+			Start: lsp.Position{Line: line, Character: utf16Offset(text, separators[index])},
+			End:   lsp.Position{Line: line, Character: utf16Offset(text, separators[index+1])},
+		}})
+	}
+	return editOf(file, edits...)
+}
+
+// cellSeparators returns the positions of the unescaped '|' of a table row.
+func cellSeparators(text string) []int {
+	var positions []int
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+		if text[i] == '|' {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+func (w *warnings) disallowedTags(file string, allowed []string) {
+	isAllowed := make(map[string]bool)
+	for _, tag := range allowed {
+		isAllowed[tag] = true
+	}
+	continuation := false
+	for _, t := range w.tokens(file) {
+		if t.Kind != gauge.TagKind {
+			continuation = false
+			continue
+		}
+		line := t.LineNo - 1
+		for _, tag := range t.Args {
+			if isAllowed[tag] {
+				continue
+			}
+			var fixes []quickFix
+			if edit, ok := w.removeTag(file, line, tag, continuation); ok {
+				fixes = append(fixes, quickFix{title: fmt.Sprintf("Remove tag '%s'", tag), edit: edit})
+			}
+			w.add(file, disallowedTagCode, line, fmt.Sprintf("Tag '%s' is not one of the allowed tags: %s", tag, strings.Join(allowed, ", ")), fixes...)
+		}
+		continuation = true
+	}
+}
+
+// removeTag deletes the tag along with a separating comma from the line, or the whole line if it is the only tag.
+func (w *warnings) removeTag(file string, line int, tag string, continuation bool) (lsp.WorkspaceEdit, bool) {
+	text := w.line(file, line)
+	values := tagRanges(text, continuation)
+	for i, v := range values {
+		if text[v.start:v.end] != tag {
+			continue
+		}
+		switch {
+		case len(values) == 1:
+			return deleteLines(file, line, line+1), true
+		case i < len(values)-1:
+			return w.replaceText(file, line, v.start, values[i+1].start, ""), true
+		default:
+			return w.replaceText(file, line, values[i-1].end, v.end, ""), true
+		}
+	}
+	return lsp.WorkspaceEdit{}, false
+}
+
+// tagRanges returns the ranges of the comma separated tags of a line. Only the first line of the tags starts with the keyword.
+func tagRanges(text string, continuation bool) []textRange {
+	start := 0
+	if !continuation {
+		colon := strings.Index(text, ":")
+		if colon == -1 {
+			return nil
+		}
+		start = colon + 1
+	}
+	var values []textRange
+	for i := start; i <= len(text); i++ {
+		if i == len(text) || text[i] == ',' {
+			raw := text[start:i]
+			trimmed := strings.TrimSpace(raw)
+			s := start + strings.Index(raw, trimmed)
+			values = append(values, textRange{s, s + len(trimmed)})
+			start = i + 1
+		}
+	}
+	return values
+}
+
+type stepUsage struct {
+	file string
+	step *gauge.Step
+}
+
+// similarSteps warns about steps which differ from another step only by whitespace or case. The concept with the same
+// normalized text, or else the most used variant, is suggested as the replacement.
+func (w *warnings) similarSteps(specs []*gauge.Specification, concepts []*gauge.Concept) {
+	var usages []stepUsage
+	for _, spec := range specs {
+		for _, step := range spec.Steps() {
+			usages = append(usages, stepUsage{spec.FileName, step})
+		}
+	}
+	for _, concept := range concepts {
+		for _, step := range concept.ConceptStep.ConceptSteps {
+			usages = append(usages, stepUsage{concept.FileName, step})
+		}
+	}
+	counts := make(map[string]map[string]int)
+	texts := make(map[string]string)
+	count := func(step *gauge.Step, n int) {
+		key := normalizedText(step.Value)
+		if counts[key] == nil {
+			counts[key] = make(map[string]int)
+		}
+		counts[key][step.Value] += n
+		if _, ok := texts[step.Value]; !ok {
+			texts[step.Value] = step.LineText
+		}
+	}
+	for _, concept := range concepts {
+		count(concept.ConceptStep, len(usages)+1)
+	}
+	for _, u := range usages {
+		count(u.step, 1)
+	}
+	for _, u := range usages {
+		variants := counts[normalizedText(u.step.Value)]
+		if len(variants) < 2 {
+			continue
+		}
+		preferred := preferredVariant(variants)
+		if preferred == u.step.Value {
+			continue
+		}
+		line := u.step.LineNo - 1
+		var fixes []quickFix
+		if edit, ok := w.replaceStepText(u.file, u.step, preferred); ok {
+			fixes = append(fixes, quickFix{title: fmt.Sprintf("Change step to '%s'", texts[preferred]), edit: edit})
+		}
+		w.add(u.file, similarStepCode, line, fmt.Sprintf("Step '%s' differs from '%s' only by whitespace or case", u.step.LineText, texts[preferred]), fixes...)
+	}
+}
+
+// normalizedText ignores the case and the amount of whitespace of the text.
+func normalizedText(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+func preferredVariant(variants map[string]int) string {
+	var preferred string
+	for value, n := range variants {
+		if n > variants[preferred] || (n == variants[preferred] && value < preferred) {
+			preferred = value
+		}
+	}
+	return preferred
+}
+
+// replaceStepText rewrites the text of the step as the given step value, keeping the params written in the step.
+func (w *warnings) replaceStepText(file string, step *gauge.Step, stepValue string) (lsp.WorkspaceEdit, bool) {
+	line := step.LineNo - 1
+	text := w.line(file, line)
+	marker := strings.Index(text, "*")
+	if marker == -1 || step.LineSpanEnd > step.LineNo {
+		return lsp.WorkspaceEdit{}, false
+	}
+	if step.HasInlineTable {
+		stepValue = strings.TrimSuffix(stepValue, " "+gauge.ParameterPlaceholder)
+	}
+	params := inlineParamRanges(text, marker+1)
+	parts := strings.Split(stepValue, gauge.ParameterPlaceholder)
+	if len(parts) != len(params)+1 {
+		return lsp.WorkspaceEdit{}, false
+	}
+	var b strings.Builder
+	for i, part := range parts {
+		b.WriteString(part)
+		if i < len(params) {
+			b.WriteString(text[params[i].start:params[i].end])
+		}
+	}
+	end := len(strings.TrimRight(text, " \t"))
+	return w.replaceText(file, line, marker+1, end, " "+strings.TrimSpace(b.String())), true
+}
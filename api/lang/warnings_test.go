@@ -0,0 +1,276 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package lang
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type recordingConn struct {
+	method string
+	params interface{}
+}
+
+func (c *recordingConn) Call(ctx context.Context, method string, params, result interface{}, opt ...jsonrpc2.CallOption) error {
+	c.method, c.params = method, params
+	return nil
+}
+
+func (c *recordingConn) Notify(ctx context.Context, method string, params interface{}, opt ...jsonrpc2.CallOption) error {
+	c.method, c.params = method, params
+	return nil
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func warningsFor(t *testing.T, uri lsp.DocumentURI) []lsp.Diagnostic {
+	diagnostics, err := getDiagnostics()
+	if err != nil {
+		t.Fatalf("Expected no error, got : %s", err.Error())
+	}
+	var warnings []lsp.Diagnostic
+	for _, d := range diagnostics[uri] {
+		if d.Source == warningSource {
+			warnings = append(warnings, d)
+		}
+	}
+	return warnings
+}
+
+func assertWarning(t *testing.T, d lsp.Diagnostic, code string, line int, message string) {
+	if d.Code != code || d.Range.Start.Line != line || d.Message != message || d.Severity != 2 {
+		t.Errorf("Wrong warning, want: `%s` at %d with `%s`, got: `%+v`", code, line, message, d)
+	}
+}
+
+func quickFixEdit(t *testing.T, uri lsp.DocumentURI, code string, line int) []lsp.TextEdit {
+	fixes := quickFixes.get(uri, code, line)
+	if len(fixes) != 1 {
+		t.Fatalf("Expected one quick fix for %s at %d, got: %v", code, line, fixes)
+	}
+	return fixes[0].edit.Changes[string(uri)]
+}
+
+func TestWarningForUnusedConcept(t *testing.T) {
+	setup()
+	cptURI := util.ConvertPathToURI(conceptFile)
+	openFilesCache.add(cptURI, "# used\n* a\n\n# unused\n* b\n\n# another\n* c\n")
+	openFilesCache.add(util.ConvertPathToURI(specFile), "# Spec\n## Scenario\n* used\n* another\n")
+
+	got := warningsFor(t, cptURI)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected one warning, got: %+v", got)
+	}
+	assertWarning(t, got[0], unusedConceptCode, 3, "Concept 'unused' is not used by any specification or concept")
+	want := []lsp.TextEdit{{Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 0}, End: lsp.Position{Line: 6, Character: 0}}}}
+	if edits := quickFixEdit(t, cptURI, unusedConceptCode, 3); !reflect.DeepEqual(edits, want) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", want, edits)
+	}
+}
+
+func TestWarningForScenarioHeadingsDifferingOnlyByWhitespace(t *testing.T) {
+	setup()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\n## Login works\n* step\n## Login  works\n* step\n")
+
+	got := warningsFor(t, uri)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected one warning, got: %+v", got)
+	}
+	assertWarning(t, got[0], duplicateScenarioCode, 3, "Scenario heading 'Login  works' duplicates the one at line 2")
+	want := []lsp.TextEdit{{Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 3}, End: lsp.Position{Line: 3, Character: 15}}, NewText: "Login works 2"}}
+	if edits := quickFixEdit(t, uri, duplicateScenarioCode, 3); !reflect.DeepEqual(edits, want) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", want, edits)
+	}
+}
+
+func TestQuickFixesForParseErrorsOfScenarios(t *testing.T) {
+	setup()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\n## Nothing yet\n\n## Last\n* step\n")
+
+	got := warningsFor(t, uri)
+
+	if len(got) != 1 || got[0].Code != emptyScenarioCode || got[0].Severity != 1 {
+		t.Fatalf("Expected the parse error of the empty scenario, got: %+v", got)
+	}
+	remove := []lsp.TextEdit{{Range: lsp.Range{Start: lsp.Position{Line: 1, Character: 0}, End: lsp.Position{Line: 3, Character: 0}}}}
+	if edits := quickFixEdit(t, uri, emptyScenarioCode, 1); !reflect.DeepEqual(edits, remove) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", remove, edits)
+	}
+
+	openFilesCache.add(uri, "# Spec\n## Login\n* step\n## login\n* step\n")
+
+	got = warningsFor(t, uri)
+
+	if len(got) != 1 || got[0].Code != duplicateScenarioCode || got[0].Severity != 1 {
+		t.Fatalf("Expected the parse error of the duplicate scenario, got: %+v", got)
+	}
+	rename := []lsp.TextEdit{{Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 3}, End: lsp.Position{Line: 3, Character: 8}}, NewText: "login 2"}}
+	if edits := quickFixEdit(t, uri, duplicateScenarioCode, 3); !reflect.DeepEqual(edits, rename) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", rename, edits)
+	}
+}
+
+func TestQuickFixesForParseErrorsDoNotDependOnTheLanguageOfTheMessage(t *testing.T) {
+	setup()
+	oldLanguage := env.SpecLanguage
+	env.SpecLanguage = func() string { return "de" }
+	defer func() { env.SpecLanguage = oldLanguage }()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\n## Noch nichts\n\n## Letztes\n* step\n")
+
+	got := warningsFor(t, uri)
+
+	if len(got) != 1 || got[0].Code != emptyScenarioCode || got[0].Message != "Das Szenario muss mindestens einen Schritt enthalten" {
+		t.Fatalf("Expected the localized parse error of the empty scenario, got: %+v", got)
+	}
+	quickFixEdit(t, uri, emptyScenarioCode, 1)
+}
+
+func TestWarningForUnusedDataTableColumn(t *testing.T) {
+	setup()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\n\n|user|unused|\n|----|------|\n|john|x|\n\n## Scenario\n* login as <user>\n")
+
+	got := warningsFor(t, uri)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected one warning, got: %+v", got)
+	}
+	assertWarning(t, got[0], unusedTableColumnCode, 2, "Data table column 'unused' is not used by any step")
+	cell := func(line, start, end int) lsp.TextEdit {
+		return lsp.TextEdit{Range: lsp.Range{Start: lsp.Position{Line: line, Character: start}, End: lsp.Position{Line: line, Character: end}}}
+	}
+	want := []lsp.TextEdit{cell(2, 5, 12), cell(3, 5, 12), cell(4, 5, 7)}
+	if edits := quickFixEdit(t, uri, unusedTableColumnCode, 2); !reflect.DeepEqual(edits, want) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", want, edits)
+	}
+}
+
+func TestWarningForTagsNotInAllowList(t *testing.T) {
+	setup()
+	old := env.AllowedTags
+	env.AllowedTags = func() []string { return []string{"smoke", "regression"} }
+	defer func() { env.AllowedTags = old }()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\ntags: smoke, wip, regression\n## Scenario\ntags: flaky\n* step\n")
+
+	got := warningsFor(t, uri)
+
+	if len(got) != 2 {
+		t.Fatalf("Expected two warnings, got: %+v", got)
+	}
+	assertWarning(t, got[0], disallowedTagCode, 1, "Tag 'wip' is not one of the allowed tags: smoke, regression")
+	assertWarning(t, got[1], disallowedTagCode, 3, "Tag 'flaky' is not one of the allowed tags: smoke, regression")
+	want := []lsp.TextEdit{{Range: lsp.Range{Start: lsp.Position{Line: 1, Character: 13}, End: lsp.Position{Line: 1, Character: 18}}}}
+	if edits := quickFixEdit(t, uri, disallowedTagCode, 1); !reflect.DeepEqual(edits, want) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", want, edits)
+	}
+	wholeLine := []lsp.TextEdit{{Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 0}, End: lsp.Position{Line: 4, Character: 0}}}}
+	if edits := quickFixEdit(t, uri, disallowedTagCode, 3); !reflect.DeepEqual(edits, wholeLine) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", wholeLine, edits)
+	}
+}
+
+func TestQuickFixRangesAreInUTF16CodeUnits(t *testing.T) {
+	setup()
+	old := env.AllowedTags
+	env.AllowedTags = func() []string { return []string{"größe", "x"} }
+	defer func() { env.AllowedTags = old }()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\ntags: größe, wip, x\n## Scenario\n* step\n")
+
+	got := warningsFor(t, uri)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected one warning, got: %+v", got)
+	}
+	// "tags: größe, " is 13 UTF-16 code units, though 15 bytes.
+	want := []lsp.TextEdit{{Range: lsp.Range{Start: lsp.Position{Line: 1, Character: 13}, End: lsp.Position{Line: 1, Character: 18}}}}
+	if edits := quickFixEdit(t, uri, disallowedTagCode, 1); !reflect.DeepEqual(edits, want) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", want, edits)
+	}
+}
+
+func TestWarningForStepsDifferingOnlyByWhitespaceOrCase(t *testing.T) {
+	setup()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\n## Scenario\n* Say \"hi\" to \"john\"\n* Say \"hello\" to \"mary\"\n* say  \"bye\" TO \"jane\"\n")
+
+	got := warningsFor(t, uri)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected one warning, got: %+v", got)
+	}
+	assertWarning(t, got[0], similarStepCode, 4, "Step 'say  \"bye\" TO \"jane\"' differs from 'Say \"hi\" to \"john\"' only by whitespace or case")
+	want := []lsp.TextEdit{{
+		Range:   lsp.Range{Start: lsp.Position{Line: 4, Character: 1}, End: lsp.Position{Line: 4, Character: len("* say  \"bye\" TO \"jane\"")}},
+		NewText: " Say \"bye\" to \"jane\"",
+	}}
+	if edits := quickFixEdit(t, uri, similarStepCode, 4); !reflect.DeepEqual(edits, want) {
+		t.Errorf("Wrong quick fix, want: %+v, got: %+v", want, edits)
+	}
+}
+
+func TestWarningsCanBeTurnedOff(t *testing.T) {
+	setup()
+	old := env.WarnUnusedTableColumns
+	env.WarnUnusedTableColumns = func() bool { return false }
+	defer func() { env.WarnUnusedTableColumns = old }()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\n\n|user|unused|\n|----|------|\n|john|x|\n\n## Scenario\n* login as <user>\n")
+
+	if got := warningsFor(t, uri); len(got) != 0 {
+		t.Errorf("Expected no warnings, got: %+v", got)
+	}
+}
+
+func TestCodeActionsForWarningsApplyQuickFixes(t *testing.T) {
+	setup()
+	uri := util.ConvertPathToURI(specFile)
+	openFilesCache.add(uri, "# Spec\n## Nothing yet\n\n## Scenario\n* step\n")
+	warnings := warningsFor(t, uri)
+	b, _ := json.Marshal(lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: uri},
+		Range:        warnings[0].Range,
+		Context:      lsp.CodeActionContext{Diagnostics: warnings},
+	})
+	p := json.RawMessage(b)
+
+	got, err := codeActions(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := got.([]lsp.Command)
+	if len(actions) != 1 || actions[0].Command != quickFixCommand || actions[0].Title != "Remove empty scenario" {
+		t.Fatalf("Wrong code actions, got: %+v", actions)
+	}
+	b, _ = json.Marshal(lsp.ExecuteCommandParams{Command: actions[0].Command, Arguments: actions[0].Arguments})
+	p = json.RawMessage(b)
+	conn := &recordingConn{}
+	if _, err := executeWorkspaceCommand(context.Background(), conn, &jsonrpc2.Request{Params: &p}); err != nil {
+		t.Fatal(err)
+	}
+	want := applyWorkspaceEditParams{Edit: deleteLines(specFile, 1, 3)}
+	if conn.method != "workspace/applyEdit" || !reflect.DeepEqual(conn.params, want) {
+		t.Errorf("Wrong edit applied, got: %s %+v", conn.method, conn.params)
+	}
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/order"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/plugin/install"
 	"github.com/getgauge/gauge/reporter"
 	"github.com/getgauge/gauge/skel"
 	"github.com/getgauge/gauge/util"
@@ -108,6 +110,9 @@ Complete manual is available at https://manpage.gauge.org/.{{end}}
 }
 
 func Parse() error {
+	if !isGaugeCommand(GaugeCmd, os.Args[1:]) {
+		addPluginSubcommands(GaugeCmd, plugin.Subcommands())
+	}
 	InitHelp(GaugeCmd)
 	return GaugeCmd.Execute()
 }
@@ -155,6 +160,8 @@ func initPackageFlags() {
 	filter.NumberOfExecutionStreams = streams
 	reporter.NumberOfExecutionStreams = streams
 	validation.HideSuggestion = hideSuggestion
+	install.AllowUnverified = allowUnverified
+	install.FromDir = fromDir
 	if group != -1 {
 		execution.Strategy = execution.Eager
 	}
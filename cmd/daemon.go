@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/getgauge/gauge/api"
+	"github.com/getgauge/gauge/api/dap"
 	"github.com/getgauge/gauge/api/infoGatherer"
 	"github.com/getgauge/gauge/api/lang"
 	"github.com/getgauge/gauge/config"
@@ -27,7 +28,7 @@ var (
 		Use:     "daemon [flags] <port> [args]",
 		Short:   "Run as a daemon",
 		Long:    `Run as a daemon.`,
-		Example: "  gauge daemon 1234",
+		Example: "  gauge daemon 1234\n  gauge daemon --dap 1234 specs",
 		Run: func(cmd *cobra.Command, args []string) {
 			err := os.Setenv(isDaemon, "true")
 			if err != nil {
@@ -47,17 +48,23 @@ var (
 				port = args[0]
 				specs = getSpecsDir(args[1:])
 			}
+			if debugAdapter {
+				dap.Start(port, specs)
+				return
+			}
 			api.RunInBackground(port, specs)
 		},
 		PersistentPostRun: func(cmd *cobra.Command, args []string) { /* noop */ },
 		DisableAutoGenTag: true,
 	}
-	lsp bool
+	lsp          bool
+	debugAdapter bool
 )
 
 func init() {
 	GaugeCmd.AddCommand(daemonCmd)
 	daemonCmd.Flags().BoolVarP(&lsp, "lsp", "", false, "Start language server")
+	daemonCmd.Flags().BoolVarP(&debugAdapter, "dap", "", false, "Start debug adapter for stepping through specs, listening on the given port")
 	err := daemonCmd.Flags().MarkHidden("lsp")
 	if err != nil {
 		logger.Fatalf(true, "Unable to hide `--lsp` flag: %s", err.Error())
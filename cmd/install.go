@@ -20,7 +20,8 @@ var (
 		Long:  `Download and install specified plugin or all plugins in the project's 'manifest.json' file.`,
 		Example: `  gauge install
   gauge install java
-  gauge install java -f gauge-java-0.6.3-darwin.x86_64.zip`,
+  gauge install java -f gauge-java-0.6.3-darwin.x86_64.zip
+  gauge install --from-dir /mnt/gauge-mirror`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) < 1 {
 				install.AllPlugins(machineReadable, false)
@@ -39,12 +40,16 @@ var (
 		},
 		DisableAutoGenTag: true,
 	}
-	zip      string
-	pVersion string
+	zip             string
+	pVersion        string
+	allowUnverified bool
+	fromDir         string
 )
 
 func init() {
 	GaugeCmd.AddCommand(installCmd)
 	installCmd.Flags().StringVarP(&zip, "file", "f", "", "Installs the plugin from zip file")
 	installCmd.Flags().StringVarP(&pVersion, "version", "v", "", "Version of plugin to be installed")
+	installCmd.Flags().StringVarP(&fromDir, "from-dir", "", "", "Installs plugins from a local mirror instead of the plugin repositories")
+	installCmd.Flags().BoolVarP(&allowUnverified, "allow-unverified", "", false, "Installs plugins whose checksum or signature cannot be verified")
 }
@@ -16,19 +16,24 @@ import (
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
 	"github.com/spf13/cobra"
 )
 
 var (
 	listCmd = &cobra.Command{
-		Use:     "list [flags] [args]",
-		Short:   "List specifications, scenarios or tags for a gauge project",
-		Long:    `List specifications, scenarios or tags for a gauge project`,
-		Example: `  gauge list --tags specs`,
+		Use:   "list [flags] [args]",
+		Short: "List specifications, scenarios or tags for a gauge project",
+		Long:  `List specifications, scenarios or tags for a gauge project`,
+		Example: `  gauge list --tags specs
+  gauge list --tags --expr "smoke* & !wip" specs`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := config.SetProjectRoot(args); err != nil {
 				exit(err, cmd.UsageString())
 			}
+			if tagExpression != "" && !tagsFlag {
+				exit(fmt.Errorf("--expr can only be used along with --tags"), cmd.UsageString())
+			}
 			loadEnvAndReinitLogger(cmd)
 			specs, failed := parser.ParseSpecs(getSpecsDir(args), gauge.NewConceptDictionary(), gauge.NewBuildErrors())
 			if failed {
@@ -42,7 +47,14 @@ var (
 				logger.Info(true, "[Scenarios]")
 				listScenarios(specs, print)
 			}
-			if tagsFlag {
+			if tagsFlag && tagExpression != "" {
+				exp, err := filter.ParseTagExpression(tagExpression)
+				if err != nil {
+					exit(err, "")
+				}
+				logger.Infof(true, "[Scenarios matching '%s']", tagExpression)
+				listScenariosMatchingTags(specs, exp, print)
+			} else if tagsFlag {
 				logger.Info(true, "[Tags]")
 				listTags(specs, print)
 			}
@@ -55,6 +67,7 @@ var (
 	tagsFlag      bool
 	specsFlag     bool
 	scenariosFlag bool
+	tagExpression string
 )
 
 func init() {
@@ -62,6 +75,7 @@ func init() {
 	listCmd.Flags().BoolVarP(&tagsFlag, "tags", "", false, "List the tags in projects")
 	listCmd.Flags().BoolVarP(&specsFlag, "specs", "", false, "List the specifications in projects")
 	listCmd.Flags().BoolVarP(&scenariosFlag, "scenarios", "", false, "List the scenarios in projects")
+	listCmd.Flags().StringVarP(&tagExpression, "expr", "", "", "List the scenarios matching the given tag expression. Used along with --tags")
 }
 
 type handleResult func([]string)
@@ -83,6 +97,19 @@ func listTags(s []*gauge.Specification, f handleResult) {
 	f(sortedDistinctElements(allTags))
 }
 
+func listScenariosMatchingTags(s []*gauge.Specification, exp *filter.TagExpression, f handleResult) {
+	matching := []string{}
+	for _, spec := range s {
+		specTags := filter.SpecTags(spec)
+		for _, scenario := range spec.Scenarios {
+			if exp.EvaluateWithMetadata(appendTags(specTags, scenario.Tags), spec.Metadata) {
+				matching = append(matching, fmt.Sprintf("%s:%d %s", util.RelPathToProjectRoot(spec.FileName), scenario.Heading.LineNo, scenario.Heading.Value))
+			}
+		}
+	}
+	f(matching)
+}
+
 func listScenarios(s []*gauge.Specification, f handleResult) {
 	allScenarios := filter.GetAllScenarios(s)
 	f(sortedDistinctElements(allScenarios))
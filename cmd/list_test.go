@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 )
 
@@ -40,6 +41,7 @@ func buildTestSpecification() *gauge.Specification {
 		Heading: &gauge.Heading{
 			Value: "Spec1",
 		},
+		FileName: "spec1.spec",
 		Scenarios: []*gauge.Scenario{
 			{
 				Heading: &gauge.Heading{
@@ -57,7 +59,8 @@ func buildTestSpecification() *gauge.Specification {
 				}},
 			{
 				Heading: &gauge.Heading{
-					Value: "scenario1",
+					Value:  "scenario1",
+					LineNo: 3,
 				},
 				Tags: &gauge.Tags{
 					RawValues: [][]string{{"foo"}},
@@ -79,3 +82,13 @@ func verifyUniqueness(actual []string, wanted []string, t *testing.T) {
 		t.Errorf("wanted: `%s`,\n got: `%s` ", wanted, actual)
 	}
 }
+
+func TestScenariosMatchingTagExpressionAreReturned(t *testing.T) {
+	exp, err := filter.ParseTagExpression("fo* & !bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listScenariosMatchingTags([]*gauge.Specification{buildTestSpecification()}, exp, func(res []string) {
+		verifyUniqueness(res, []string{"spec1.spec:3 scenario1"}, t)
+	})
+}
@@ -0,0 +1,112 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/plugin/install"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pluginCmd = &cobra.Command{
+		Use:   "plugin <command>",
+		Short: "Manage the plugins of the project",
+		Long:  `Manage the plugins of the project.`,
+		Example: `  gauge plugin mirror /mnt/gauge-mirror
+  gauge plugin doctor
+  gauge plugin du`,
+		DisableAutoGenTag: true,
+	}
+	pluginMirrorCmd = &cobra.Command{
+		Use:   "mirror [flags] <dir>",
+		Short: "Download the plugins of the project into a local mirror",
+		Long: `Download the language runner and plugins in the project's 'manifest.json' file into a directory,
+which can be used with 'gauge install --from-dir' or listed in the 'plugin_repositories' configuration.`,
+		Example: `  gauge plugin mirror /mnt/gauge-mirror`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				exit(fmt.Errorf("missing argument <dir>"), cmd.UsageString())
+			}
+			if err := install.Mirror(args[0], machineReadable); err != nil {
+				logger.Fatalf(true, err.Error())
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	pluginDoctorCmd = &cobra.Command{
+		Use:   "doctor [flags]",
+		Short: "Check the installed plugins and language runners",
+		Long: `Check that the installed plugins and language runners can be used with this version of Gauge,
+and print how to fix the problems found. In a project, the latest version of each plugin is started to check that
+it connects. Exits with a non zero code if a problem is found.`,
+		Example: `  gauge plugin doctor
+  gauge plugin doctor --skip-connection`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.SetProjectRoot([]string{}); err != nil {
+				logger.Debugf(true, "Not in a Gauge project. %s", err.Error())
+			}
+			diagnoses, err := plugin.Diagnose(!skipConnection)
+			if err != nil {
+				logger.Fatalf(true, "Failed to check the plugins. %s", err.Error())
+			}
+			if printDiagnoses(diagnoses) > 0 {
+				os.Exit(1)
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+	pluginDuCmd = &cobra.Command{
+		Use:     "du",
+		Short:   "Show the disk usage of the installed plugins",
+		Long:    `Show the disk space used by each installed version of the plugins and language runners.`,
+		Example: `  gauge plugin du`,
+		Run: func(cmd *cobra.Command, args []string) {
+			install.PrintDiskUsage()
+		},
+		DisableAutoGenTag: true,
+	}
+	skipConnection bool
+)
+
+func init() {
+	GaugeCmd.AddCommand(pluginCmd)
+	pluginCmd.AddCommand(pluginMirrorCmd)
+	pluginCmd.AddCommand(pluginDoctorCmd)
+	pluginCmd.AddCommand(pluginDuCmd)
+	pluginDoctorCmd.Flags().BoolVarP(&skipConnection, "skip-connection", "", false, "Do not start the plugins to check that they connect")
+}
+
+// printDiagnoses prints the problems found with each plugin along with their fixes, and returns the number of problems.
+func printDiagnoses(diagnoses []plugin.Diagnosis) int {
+	problems := 0
+	for _, d := range diagnoses {
+		name := d.ID
+		if d.Version != "" {
+			name = fmt.Sprintf("%s (%s)", d.ID, d.Version)
+		}
+		if len(d.Problems) == 0 {
+			logger.Infof(true, "%s: ok", name)
+			continue
+		}
+		for _, p := range d.Problems {
+			logger.Errorf(true, "%s: %s\n  Fix: %s", name, p.Description, p.Fix)
+		}
+		problems += len(d.Problems)
+	}
+	if problems > 0 {
+		logger.Infof(true, "\n%d problem(s) found.", problems)
+	} else {
+		logger.Infof(true, "\nNo problems found.")
+	}
+	return problems
+}
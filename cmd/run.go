@@ -17,6 +17,7 @@ import (
 	"github.com/getgauge/gauge/execution"
 	"github.com/getgauge/gauge/execution/rerun"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
 	"github.com/getgauge/gauge/plugin/install"
 	"github.com/getgauge/gauge/util"
 	"github.com/spf13/cobra"
@@ -120,7 +121,7 @@ func init() {
 	f.BoolVarP(&verbose, verboseName, "v", verboseDefault, "Enable step level reporting on console, default being scenario level")
 	f.BoolVarP(&simpleConsole, simpleConsoleName, "", simpleConsoleDefault, "Removes colouring and simplifies the console output")
 	f.StringVarP(&environment, environmentName, "e", environmentDefault, "Specifies the environment to use")
-	f.StringVarP(&tags, tagsName, "t", tagsDefault, "Executes the specs and scenarios tagged with given tags")
+	f.StringVarP(&tags, tagsName, "t", tagsDefault, "Executes the specs and scenarios matching the tag expression. The words and, or and not are keywords, so tags named so have to be quoted (\"not\") or escaped (\\not)")
 	f.StringVarP(&rows, rowsName, "r", rowsDefault, "Executes the specs and scenarios only for the selected rows. It can be specified by range as 2-4 or as list 2,4")
 	f.BoolVarP(&parallel, parallelName, "p", parallelDefault, "Execute specs in parallel")
 	f.IntVarP(&streams, streamsName, "n", streamsDefault, "Specify number of parallel execution streams")
@@ -238,6 +239,9 @@ func execute(cmd *cobra.Command, args []string) {
 		rerun.WritePrevArgs(os.Args)
 	}
 	installMissingPlugins(installPlugins, false)
+	if err := manifest.RecordProject(); err != nil {
+		logger.Debugf(true, "Failed to record the project in %s. %s", manifest.ProjectsFile, err.Error())
+	}
 	exitCode := execution.ExecuteSpecs(specs)
 	if failSafe && exitCode != execution.ParseFailed {
 		exitCode = 0
@@ -0,0 +1,75 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/util"
+	"github.com/spf13/cobra"
+)
+
+// addPluginSubcommands adds the subcommands of the installed plugins with command scope. A subcommand with the name of
+// a command of Gauge is left out.
+func addPluginSubcommands(c *cobra.Command, subcommands []plugin.Subcommand) {
+	for _, s := range subcommands {
+		if hasSubcommand(c, s.Name) {
+			continue
+		}
+		c.AddCommand(pluginSubcommand(s))
+	}
+}
+
+// isGaugeCommand tells if the args run a command of Gauge, in which case the installed plugins are not looked up for
+// their subcommands. Help and the list of commands need them.
+func isGaugeCommand(c *cobra.Command, args []string) bool {
+	if len(args) == 0 || args[0] == "help" {
+		return false
+	}
+	return hasSubcommand(c, args[0])
+}
+
+func hasSubcommand(c *cobra.Command, name string) bool {
+	for _, sc := range c.Commands() {
+		if sc.Name() == name || sc.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help"
+}
+
+func pluginSubcommand(s plugin.Subcommand) *cobra.Command {
+	short := s.Description
+	if short == "" {
+		short = fmt.Sprintf("Run the %s plugin", s.PluginID)
+	}
+	return &cobra.Command{
+		Use:                fmt.Sprintf("%s [args]", s.Name),
+		Short:              short,
+		Long:               fmt.Sprintf("%s. Provided by the %s plugin, which gets all the args.", strings.TrimSuffix(short, "."), s.PluginID),
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.SetProjectRoot([]string{}); err != nil {
+				exit(err, "")
+			}
+			loadEnvAndReinitLogger(cmd)
+			code, err := plugin.RunSubcommand(s, args, util.GetSpecDirs())
+			if err != nil {
+				logger.Fatalf(true, err.Error())
+			}
+			if code != 0 {
+				os.Exit(code)
+			}
+		},
+		DisableAutoGenTag: true,
+	}
+}
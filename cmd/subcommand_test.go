@@ -0,0 +1,55 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/getgauge/gauge/plugin"
+	"github.com/spf13/cobra"
+)
+
+func TestPluginSubcommandsDoNotReplaceGaugeCommands(t *testing.T) {
+	root := &cobra.Command{Use: "gauge"}
+	root.AddCommand(&cobra.Command{Use: "run", Run: func(cmd *cobra.Command, args []string) {}})
+
+	addPluginSubcommands(root, []plugin.Subcommand{
+		{Name: "run", PluginID: "runner"},
+		{Name: "help", PluginID: "helper"},
+		{Name: "lint", PluginID: "lint", Description: "Lint the specs of the project."},
+	})
+
+	lint, args, err := root.Find([]string{"lint", "--fix", "specs/"})
+	if err != nil {
+		t.Fatalf("expected lint command to be added, got %s", err.Error())
+	}
+	if lint.Short != "Lint the specs of the project." || lint.Long != "Lint the specs of the project. Provided by the lint plugin, which gets all the args." {
+		t.Errorf("unexpected help for lint: %s, %s", lint.Short, lint.Long)
+	}
+	if len(args) != 2 || args[0] != "--fix" {
+		t.Errorf("expected all args to be passed to the plugin, got %v", args)
+	}
+	if len(root.Commands()) != 2 {
+		t.Errorf("expected run not to be replaced and help not to be added, got %d commands", len(root.Commands()))
+	}
+}
+
+func TestPluginSubcommandsAreLookedUpOnlyForArgsWhichAreNotGaugeCommands(t *testing.T) {
+	root := &cobra.Command{Use: "gauge"}
+	root.AddCommand(&cobra.Command{Use: "run", Aliases: []string{"r"}, Run: func(cmd *cobra.Command, args []string) {}})
+
+	for _, args := range [][]string{{"run", "specs"}, {"r"}} {
+		if !isGaugeCommand(root, args) {
+			t.Errorf("expected %v to run a Gauge command", args)
+		}
+	}
+	for _, args := range [][]string{{}, {"help"}, {"lint", "--fix"}, {"--help"}} {
+		if isGaugeCommand(root, args) {
+			t.Errorf("expected plugin subcommands to be looked up for %v", args)
+		}
+	}
+}
@@ -13,12 +13,27 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	unused bool
+	force  bool
+)
+
 var uninstallCmd = &cobra.Command{
-	Use:     "uninstall [flags] <plugin>",
-	Short:   "Uninstalls a plugin",
-	Long:    `Uninstalls a plugin.`,
-	Example: `  gauge uninstall java`,
+	Use:   "uninstall [flags] <plugin>",
+	Short: "Uninstalls a plugin",
+	Long: `Uninstalls a plugin.
+
+With --unused, uninstalls the versions of all plugins which are neither used by a known project nor the newest
+version compatible with this version of Gauge. Projects are known once they have run or installed their plugins.
+The versions are only listed, unless --force is set.`,
+	Example: `  gauge uninstall java
+  gauge uninstall --unused
+  gauge uninstall --unused --force`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if unused {
+			install.UninstallUnused(force)
+			return
+		}
 		if len(args) < 1 {
 			exit(fmt.Errorf("Missing argument <plugin name>."), cmd.UsageString())
 		}
@@ -30,4 +45,6 @@ var uninstallCmd = &cobra.Command{
 func init() {
 	GaugeCmd.AddCommand(uninstallCmd)
 	uninstallCmd.Flags().StringVarP(&pVersion, "version", "v", "", "Version of plugin to be uninstalled")
+	uninstallCmd.Flags().BoolVarP(&unused, "unused", "", false, "Uninstalls the versions of plugins not used by known projects, except the newest compatible one")
+	uninstallCmd.Flags().BoolVarP(&force, "force", "", false, "Uninstalls the versions listed by --unused")
 }
@@ -44,4 +44,5 @@ func init() {
 	GaugeCmd.AddCommand(updateCmd)
 	updateCmd.Flags().BoolVarP(&all, "all", "a", false, "Updates all the installed Gauge plugins")
 	updateCmd.Flags().BoolVarP(&check, "check", "c", false, "Checks for Gauge and plugins updates")
+	updateCmd.Flags().BoolVarP(&allowUnverified, "allow-unverified", "", false, "Installs plugins whose checksum or signature cannot be verified")
 }
@@ -0,0 +1,142 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package conceptExtractor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/formatter"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+)
+
+// LinesEdit replaces the zero based lines from Start to End, excluding End, by Lines.
+type LinesEdit struct {
+	Start, End int
+	Lines      []string
+}
+
+// InlineConcept returns the edit which replaces the concept invoked by the step at the line with the steps of the
+// concept, passing the arguments of the invocation to them. The concept is looked up by the step value with search.
+func InlineConcept(content, fileName string, lineNo int, search func(stepValue string) *gauge.Concept) (LinesEdit, error) {
+	step := stepAt(content, fileName, lineNo)
+	if step == nil {
+		return LinesEdit{}, fmt.Errorf("no step found at line %d of %s", lineNo, fileName)
+	}
+	concept := search(step.Value)
+	if concept == nil {
+		return LinesEdit{}, fmt.Errorf("step at line %d of %s is not a concept", lineNo, fileName)
+	}
+	params := make(map[string]*gauge.StepArg)
+	for i, arg := range concept.ConceptStep.Args {
+		if i < len(step.Args) {
+			params[arg.Value] = step.Args[i]
+		}
+	}
+	var steps []string
+	for _, conceptStep := range concept.ConceptStep.ConceptSteps {
+		inlined, err := withArgs(conceptStep, params)
+		if err != nil {
+			return LinesEdit{}, err
+		}
+		steps = append(steps, strings.TrimSuffix(formatter.FormatStep(inlined), "\n"))
+	}
+	start, end := stepLines(content, fileName, step)
+	return LinesEdit{Start: start, End: end, Lines: steps}, nil
+}
+
+func stepAt(content, fileName string, lineNo int) *gauge.Step {
+	if util.IsConcept(fileName) {
+		concepts, _ := new(parser.ConceptParser).Parse(content, fileName)
+		for _, concept := range concepts {
+			for _, step := range concept.ConceptSteps {
+				if step.LineNo == lineNo {
+					return step
+				}
+			}
+		}
+		return nil
+	}
+	spec, _ := new(parser.SpecParser).ParseSpecText(content, fileName)
+	for _, step := range spec.Steps() {
+		if step.LineNo == lineNo {
+			return step
+		}
+	}
+	return nil
+}
+
+// withArgs returns a copy of the step of a concept with the params of the concept replaced by the given arguments.
+func withArgs(step *gauge.Step, params map[string]*gauge.StepArg) (*gauge.Step, error) {
+	inlined := &gauge.Step{Value: step.Value, Suffix: step.Suffix}
+	for _, arg := range step.Args {
+		switch arg.ArgType {
+		case gauge.Dynamic:
+			if p, ok := params[arg.Value]; ok {
+				arg = p
+			}
+		case gauge.TableArg:
+			table, err := tableWithArgs(&arg.Table, params)
+			if err != nil {
+				return nil, err
+			}
+			arg = &gauge.StepArg{Name: arg.Name, ArgType: gauge.TableArg, Table: *table}
+		}
+		inlined.Args = append(inlined.Args, arg)
+	}
+	return inlined, nil
+}
+
+func tableWithArgs(table *gauge.Table, params map[string]*gauge.StepArg) (*gauge.Table, error) {
+	inlined := &gauge.Table{LineNo: table.LineNo}
+	inlined.AddHeaders(table.Headers)
+	if len(table.Columns) == 0 {
+		return inlined, nil
+	}
+	for i := range table.Columns[0] {
+		var row []gauge.TableCell
+		for _, column := range table.Columns {
+			cell := column[i]
+			if p, ok := params[cell.Value]; ok && cell.CellType == gauge.Dynamic {
+				switch p.ArgType {
+				case gauge.Static:
+					cell = gauge.TableCell{Value: p.Value, CellType: gauge.Static}
+				case gauge.TableArg:
+					return nil, fmt.Errorf("cannot pass the table <%s> to a table cell", cell.Value)
+				default:
+					cell = gauge.TableCell{Value: p.Name, CellType: gauge.Dynamic}
+				}
+			}
+			row = append(row, cell)
+		}
+		inlined.AddRowValues(row)
+	}
+	return inlined, nil
+}
+
+// stepLines returns the zero based range of lines of the step, including the lines of its inline table.
+func stepLines(content, fileName string, step *gauge.Step) (int, int) {
+	start, end := step.LineNo-1, step.LineNo
+	if step.LineSpanEnd > end {
+		end = step.LineSpanEnd
+	}
+	tokens, _ := new(parser.SpecParser).GenerateTokens(content, fileName)
+	inStep := false
+	for _, t := range tokens {
+		switch {
+		case t.Kind == gauge.StepKind:
+			inStep = t.LineNo == step.LineNo
+		case inStep && (t.Kind == gauge.TableHeader || t.Kind == gauge.TableRow):
+			end = t.LineNo
+		default:
+			inStep = false
+		}
+	}
+	return start, end
+}
@@ -0,0 +1,55 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package conceptExtractor
+
+import (
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	. "gopkg.in/check.v1"
+)
+
+func conceptDictionaryOf(c *C, content string) *gauge.ConceptDictionary {
+	concepts, res := new(parser.ConceptParser).Parse(content, "login.cpt")
+	c.Assert(res.ParseErrors, HasLen, 0)
+	dictionary := gauge.NewConceptDictionary()
+	errs, err := parser.AddConcept(concepts, "login.cpt", dictionary)
+	c.Assert(err, IsNil)
+	c.Assert(errs, HasLen, 0)
+	return dictionary
+}
+
+// applyEdits applies the edits, which are sorted by the lines they replace, to the content.
+func applyEdits(content string, edits ...LinesEdit) string {
+	lines := strings.Split(content, "\n")
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		lines = append(append(append([]string{}, lines[:e.Start]...), e.Lines...), lines[e.End:]...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *MySuite) TestInlineConceptPassesArgumentsToItsSteps(c *C) {
+	dictionary := conceptDictionaryOf(c, "# login as <user> with <password>\n* enter user <user>\n* enter password <password>\n   |field   |value     |\n   |--------|----------|\n   |password|<password>|\n* submit\n")
+	spec := "# Spec\n\n|user|\n|----|\n|john|\n\n## Login\n* login as <user> with \"secret\"\n* open dashboard\n"
+
+	edit, err := InlineConcept(spec, "login.spec", 8, dictionary.Search)
+
+	c.Assert(err, IsNil)
+	c.Assert(edit.Start, Equals, 7)
+	c.Assert(edit.End, Equals, 8)
+	c.Assert(applyEdits(spec, edit), Equals, "# Spec\n\n|user|\n|----|\n|john|\n\n## Login\n* enter user <user>\n* enter password \"secret\"\n\n   |field   |value |\n   |--------|------|\n   |password|secret|\n* submit\n* open dashboard\n")
+}
+
+func (s *MySuite) TestInlineConceptFailsForStepWhichIsNotAConcept(c *C) {
+	dictionary := conceptDictionaryOf(c, "# login as <user>\n* enter user <user>\n")
+
+	_, err := InlineConcept("# Spec\n## Login\n* open dashboard\n", "login.spec", 3, dictionary.Search)
+
+	c.Assert(err, ErrorMatches, "step at line 3 of login.spec is not a concept")
+}
@@ -0,0 +1,146 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package conceptExtractor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/gauge/formatter"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+)
+
+// ParameterizeSteps returns the edits which replace the static params of the steps from the start line to the end line
+// by dynamic params. A column of the data table which has the value of the param in all its rows is reused,
+// otherwise a column with the value in all rows is added, creating the data table if the spec has none.
+// The edits are sorted by the lines they replace.
+func ParameterizeSteps(content, fileName string, startLineNo, endLineNo int) ([]LinesEdit, error) {
+	spec, res := new(parser.SpecParser).ParseSpecText(content, fileName)
+	if !res.Ok {
+		return nil, fmt.Errorf("unable to parameterize steps, %s has parse errors", fileName)
+	}
+	if spec.DataTable.IsExternal {
+		return nil, fmt.Errorf("unable to parameterize steps of a spec with an external data table")
+	}
+	table := &gauge.Table{}
+	table.AddHeaders(nil)
+	rows := 1
+	if spec.DataTable.IsInitialized() {
+		table = spec.DataTable.Table
+		rows = len(table.Rows())
+	}
+	var edits []LinesEdit
+	columns := make(map[string]string)
+	for _, step := range spec.Steps() {
+		if step.LineNo < startLineNo || step.LineNo > endLineNo {
+			continue
+		}
+		parameterized := &gauge.Step{Value: step.Value, Suffix: step.Suffix}
+		changed := false
+		for _, arg := range step.Args {
+			if arg.ArgType == gauge.Static {
+				column, ok := columns[arg.Value]
+				if !ok {
+					column = columnFor(table, arg.Value, rows)
+					columns[arg.Value] = column
+				}
+				arg = &gauge.StepArg{Name: column, Value: column, ArgType: gauge.Dynamic}
+				changed = true
+			}
+			parameterized.Args = append(parameterized.Args, arg)
+		}
+		if changed {
+			start, end := stepLines(content, fileName, step)
+			edits = append(edits, LinesEdit{Start: start, End: end, Lines: []string{strings.TrimSuffix(formatter.FormatStep(parameterized), "\n")}})
+		}
+	}
+	if len(edits) == 0 {
+		return nil, fmt.Errorf("no static params found in the steps from line %d to %d of %s", startLineNo, endLineNo, fileName)
+	}
+	formatted := strings.Split(strings.Trim(formatter.FormatTable(table), "\n"), "\n")
+	if spec.DataTable.IsInitialized() {
+		start, end := tableLines(content, fileName, spec.DataTable.Table.LineNo)
+		edits = append(edits, LinesEdit{Start: start, End: end, Lines: formatted})
+	} else {
+		line := dataTableLine(content, fileName)
+		if line > 0 && strings.TrimSpace(util.GetLinesFromText(content)[line-1]) != "" {
+			formatted = append([]string{""}, formatted...)
+		}
+		edits = append(edits, LinesEdit{Start: line, End: line, Lines: append(formatted, "")})
+	}
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Start < edits[j].Start || edits[i].Start == edits[j].Start && edits[i].End < edits[j].End
+	})
+	return edits, nil
+}
+
+// columnFor returns the column of the table which has the value in all its rows, adding one if there is none.
+func columnFor(table *gauge.Table, value string, rows int) string {
+	for i, header := range table.Headers {
+		matches := len(table.Columns[i]) > 0
+		for _, cell := range table.Columns[i] {
+			matches = matches && cell.CellType == gauge.Static && cell.Value == value
+		}
+		if matches {
+			return header
+		}
+	}
+	column := ""
+	for i := 0; column == "" || containsHeader(table, column); i++ {
+		column = fmt.Sprintf("arg%d", i)
+	}
+	cells := make([]gauge.TableCell, rows)
+	for i := range cells {
+		cells[i] = gauge.GetTableCell(value)
+	}
+	columns := append(table.Columns, cells)
+	headers := append(append([]string{}, table.Headers...), column)
+	*table = *gauge.NewTable(headers, columns, table.LineNo)
+	return column
+}
+
+func containsHeader(table *gauge.Table, name string) bool {
+	for _, header := range table.Headers {
+		if header == name {
+			return true
+		}
+	}
+	return false
+}
+
+// tableLines returns the zero based range of lines of the table with the header at the given line.
+func tableLines(content, fileName string, headerLineNo int) (int, int) {
+	tokens, _ := new(parser.SpecParser).GenerateTokens(content, fileName)
+	end := headerLineNo
+	inTable := false
+	for _, t := range tokens {
+		switch {
+		case t.Kind == gauge.TableHeader:
+			inTable = t.LineNo == headerLineNo
+		case inTable && t.Kind == gauge.TableRow:
+			end = t.LineNo
+		default:
+			inTable = false
+		}
+	}
+	return headerLineNo - 1, end
+}
+
+// dataTableLine returns the zero based line before which the data table of a spec goes, which is the line of the
+// first step or scenario.
+func dataTableLine(content, fileName string) int {
+	tokens, _ := new(parser.SpecParser).GenerateTokens(content, fileName)
+	for _, t := range tokens {
+		if t.Kind == gauge.StepKind || t.Kind == gauge.ScenarioKind {
+			return t.LineNo - 1
+		}
+	}
+	return util.GetLineCount(content)
+}
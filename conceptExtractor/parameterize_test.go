@@ -0,0 +1,36 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package conceptExtractor
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestParameterizeStepsCreatesDataTable(c *C) {
+	spec := "# Spec\n\n## Login\n* login as \"john\" with \"secret\"\n* greet \"john\"\n* logout\n"
+
+	edits, err := ParameterizeSteps(spec, "login.spec", 4, 5)
+
+	c.Assert(err, IsNil)
+	c.Assert(edits, HasLen, 3)
+	c.Assert(applyEdits(spec, edits...), Equals, "# Spec\n\n   |arg0|arg1  |\n   |----|------|\n   |john|secret|\n\n## Login\n* login as <arg0> with <arg1>\n* greet <arg0>\n* logout\n")
+}
+
+func (s *MySuite) TestParameterizeStepsReusesColumnsOfDataTable(c *C) {
+	spec := "# Spec\n\n|user|\n|----|\n|john|\n|john|\n\n## Login\n* login as \"john\" with \"secret\"\n"
+
+	edits, err := ParameterizeSteps(spec, "login.spec", 9, 9)
+
+	c.Assert(err, IsNil)
+	c.Assert(applyEdits(spec, edits...), Equals, "# Spec\n\n   |user|arg0  |\n   |----|------|\n   |john|secret|\n   |john|secret|\n\n## Login\n* login as <user> with <arg0>\n")
+}
+
+func (s *MySuite) TestParameterizeStepsFailsWithoutStaticParams(c *C) {
+	_, err := ParameterizeSteps("# Spec\n## Login\n* logout\n", "login.spec", 3, 3)
+
+	c.Assert(err, ErrorMatches, "no static params found in the steps from line 3 to 3 of login.spec")
+}
@@ -25,6 +25,9 @@ const (
 	ideRequestTimeout       = "ide_request_timeout"
 	checkUpdates            = "check_updates"
 	allowInsecureDownload   = "allow_insecure_download"
+	trustedPluginKeys       = "trusted_plugin_keys"
+	requirePluginChecksums  = "require_plugin_checksums"
+	pluginRepositories      = "plugin_repositories"
 
 	defaultRunnerConnectionTimeout = time.Second * 25
 	defaultPluginConnectionTimeout = time.Second * 10
@@ -91,6 +94,27 @@ func AllowInsecureDownload() bool {
 	return convertToBool(allow, allowInsecureDownload, false)
 }
 
+// TrustedPluginKeys returns the base64 encoded ed25519 public keys trusted to sign plugin archives
+func TrustedPluginKeys() []string {
+	return splitList(getFromConfig(trustedPluginKeys))
+}
+
+// RequirePluginChecksums determines if plugin archives without a checksum in their install description are refused
+func RequirePluginChecksums() bool {
+	require := getFromConfig(requirePluginChecksums)
+	return convertToBool(require, requirePluginChecksums, false)
+}
+
+// PluginRepositories returns the repositories to locate plugins in, in the order they are tried. They are HTTP base URLs
+// or local directories, followed by the gauge repository URL.
+func PluginRepositories() []string {
+	repositories := splitList(getFromConfig(pluginRepositories))
+	if url := GaugeRepositoryUrl(); url != "" {
+		repositories = append(repositories, url)
+	}
+	return repositories
+}
+
 // GaugeRepositoryUrl fetches the repository URL to locate plugins
 func GaugeRepositoryUrl() string {
 	return getFromConfig(gaugeRepositoryURL)
@@ -126,6 +150,16 @@ func convertToTime(value string, defaultValue time.Duration, name string) time.D
 	return time.Millisecond * time.Duration(intValue)
 }
 
+func splitList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 func convertToBool(value, property string, defaultValue bool) bool {
 	boolValue, err := strconv.ParseBool(strings.TrimSpace(value))
 	if err != nil {
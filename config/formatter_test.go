@@ -22,8 +22,11 @@ func TestJSONFormatter(t *testing.T) {
 		"ide_request_timeout           	30000                              ",
 		"plugin_connection_timeout     	10000                              ",
 		"plugin_kill_timeout           	4000                               ",
+		"plugin_repositories           	                                   ",
+		"require_plugin_checksums      	false                              ",
 		"runner_connection_timeout     	30000                              ",
 		"runner_request_timeout        	30000                              ",
+		"trusted_plugin_keys           	                                   ",
 	}
 	p := defaults()
 	var properties []Property
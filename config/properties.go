@@ -97,6 +97,9 @@ func defaults() *properties {
 		runnerRequestTimeout:    NewProperty(runnerRequestTimeout, "30000", "Timeout in milliseconds for requests from the language runner."),
 		ideRequestTimeout:       NewProperty(ideRequestTimeout, "30000", "Timeout in milliseconds for requests from runner when invoked for ide."),
 		checkUpdates:            NewProperty(checkUpdates, "true", "Allow Gauge and its plugin updates to be notified."),
+		pluginRepositories:      NewProperty(pluginRepositories, "", "Comma separated plugin repositories, HTTP base URLs or local directories, tried in order before gauge_repository_url."),
+		trustedPluginKeys:       NewProperty(trustedPluginKeys, "", "Comma separated base64 encoded ed25519 public keys trusted to sign plugins. Unsigned plugins are refused once set, unless --allow-unverified is used."),
+		requirePluginChecksums:  NewProperty(requirePluginChecksums, "false", "Refuse to install plugins which have no checksum, unless --allow-unverified is used."),
 	}}
 }
 
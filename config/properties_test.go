@@ -123,11 +123,20 @@ plugin_connection_timeout = 10000
 # Timeout in milliseconds for a plugin to stop after a kill message has been sent.
 plugin_kill_timeout = 4000
 
+# Comma separated plugin repositories, HTTP base URLs or local directories, tried in order before gauge_repository_url.
+plugin_repositories = 
+
+# Refuse to install plugins which have no checksum, unless --allow-unverified is used.
+require_plugin_checksums = false
+
 # Timeout in milliseconds for making a connection to the language runner.
 runner_connection_timeout = 30000
 
 # Timeout in milliseconds for requests from the language runner.
 runner_request_timeout = 30000
+
+# Comma separated base64 encoded ed25519 public keys trusted to sign plugins. Unsigned plugins are refused once set, unless --allow-unverified is used.
+trusted_plugin_keys = 
 `
 
 func TestPropertiesString(t *testing.T) {
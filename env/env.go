@@ -14,6 +14,7 @@ import (
 	"strconv"
 
 	"strings"
+	"time"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
@@ -42,9 +43,21 @@ const (
 	allowScenarioDatatable         = "allow_scenario_datatable"
 	allowFilteredParallelExecution = "allow_filtered_parallel_execution"
 	enableMultithreading           = "enable_multithreading"
+	enableParseCache               = "enable_parse_cache"
 	// GaugeScreenshotsDir holds the location of screenshots dir
 	GaugeScreenshotsDir     = "gauge_screenshots_dir"
 	gaugeSpecFileExtensions = "gauge_spec_file_extensions"
+	gaugeSpecLanguage       = "gauge_spec_language"
+	// Toggles for the warnings reported by the language server in addition to parse and validation errors
+	lspWarnUnusedConcepts     = "lsp_warn_unused_concepts"
+	lspWarnDuplicateScenarios = "lsp_warn_duplicate_scenarios"
+	lspWarnUnusedTableColumns = "lsp_warn_unused_table_columns"
+	lspWarnSimilarSteps       = "lsp_warn_similar_steps"
+	lspAllowedTags            = "lsp_allowed_tags"
+	// Webhook to which summaries of the execution are posted
+	eventWebhookURL     = "gauge_event_webhook_url"
+	eventWebhookTimeout = "gauge_event_webhook_timeout"
+	eventWebhookRetries = "gauge_event_webhook_retries"
 )
 
 var envVars map[string]string
@@ -112,9 +125,11 @@ func loadDefaultEnvVars() {
 	addEnvVar(allowMultilineStep, "false")
 	addEnvVar(allowScenarioDatatable, "false")
 	addEnvVar(allowFilteredParallelExecution, "false")
+	addEnvVar(enableParseCache, "false")
 	defaultScreenshotDir := filepath.Join(config.ProjectRoot, common.DotGauge, "screenshots")
 	addEnvVar(GaugeScreenshotsDir, defaultScreenshotDir)
 	addEnvVar(gaugeSpecFileExtensions, ".spec, .md")
+	addEnvVar(gaugeSpecLanguage, "en")
 	err := os.MkdirAll(defaultScreenshotDir, 0750)
 	if err != nil {
 		logger.Warningf(true, "Could not create screenshot dir at %s", err.Error())
@@ -268,6 +283,20 @@ func convertToBool(property string, defaultValue bool) bool {
 	return boolValue
 }
 
+func convertToInt(property string, defaultValue int) int {
+	v := os.Getenv(property)
+	if strings.TrimSpace(v) == "" {
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || intValue < 0 {
+		logger.Warningf(true, "Incorrect value for %s in property file. Cannot convert %s to a positive number.", property, v)
+		logger.Warningf(true, "Using default value %v for property %s.", defaultValue, property)
+		return defaultValue
+	}
+	return intValue
+}
+
 // AllowFilteredParallelExecution - feature toggle for filtered parallel execution
 var AllowFilteredParallelExecution = func() bool {
 	return convertToBool(allowFilteredParallelExecution, false)
@@ -294,6 +323,12 @@ var EnableMultiThreadedExecution = func() bool {
 	return convertToBool(enableMultithreading, false)
 }
 
+// EnableParseCache determines if the specs and concepts of unchanged files
+// should be reused from .gauge/cache
+var EnableParseCache = func() bool {
+	return convertToBool(enableParseCache, false)
+}
+
 var GaugeSpecFileExtensions = func() []string {
 	e := os.Getenv(gaugeSpecFileExtensions)
 	if e == "" {
@@ -309,3 +344,55 @@ var GaugeSpecFileExtensions = func() []string {
 	}
 	return allowedExts
 }
+
+// SpecLanguage returns the language of the spec keywords and messages, e.g. `de`.
+var SpecLanguage = func() string {
+	return strings.TrimSpace(os.Getenv(gaugeSpecLanguage))
+}
+
+// WarnUnusedConcepts determines if the language server warns about concepts not used by any spec or concept
+var WarnUnusedConcepts = func() bool {
+	return convertToBool(lspWarnUnusedConcepts, true)
+}
+
+// WarnDuplicateScenarios determines if the language server warns about scenarios with the same heading in a spec
+var WarnDuplicateScenarios = func() bool {
+	return convertToBool(lspWarnDuplicateScenarios, true)
+}
+
+// WarnUnusedTableColumns determines if the language server warns about data table columns not used by any step
+var WarnUnusedTableColumns = func() bool {
+	return convertToBool(lspWarnUnusedTableColumns, true)
+}
+
+// WarnSimilarSteps determines if the language server warns about steps differing from another step only by whitespace or case
+var WarnSimilarSteps = func() bool {
+	return convertToBool(lspWarnSimilarSteps, true)
+}
+
+// AllowedTags returns the tags the language server accepts in specs and scenarios.
+// Tags are not checked when the list is empty.
+var AllowedTags = func() []string {
+	var tags []string
+	for _, tag := range strings.Split(os.Getenv(lspAllowedTags), ",") {
+		if t := strings.TrimSpace(tag); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// EventWebhookURL returns the URL to which summaries of the execution are posted. Nothing is posted when it is empty.
+var EventWebhookURL = func() string {
+	return strings.TrimSpace(os.Getenv(eventWebhookURL))
+}
+
+// EventWebhookTimeout returns how long a post to the event webhook may take. The property is set in seconds.
+var EventWebhookTimeout = func() time.Duration {
+	return time.Duration(convertToInt(eventWebhookTimeout, 5)) * time.Second
+}
+
+// EventWebhookRetries returns the number of times a failed post to the event webhook is retried.
+var EventWebhookRetries = func() int {
+	return convertToInt(eventWebhookRetries, 3)
+}
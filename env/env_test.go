@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
@@ -379,3 +380,20 @@ func (s *MySuite) TestLoadEnvWithAcyclicProperties(c *C) {
 	c.Assert(os.Getenv("e"), Equals, "foo")
 	c.Assert(os.Getenv("f"), Equals, "foo")
 }
+
+func (s *MySuite) TestEventWebhookDefaults(c *C) {
+	os.Clearenv()
+
+	c.Assert(EventWebhookURL(), Equals, "")
+	c.Assert(EventWebhookTimeout(), Equals, 5*time.Second)
+	c.Assert(EventWebhookRetries(), Equals, 3)
+}
+
+func (s *MySuite) TestEventWebhookWithInvalidRetriesUsesDefault(c *C) {
+	os.Clearenv()
+	os.Setenv("gauge_event_webhook_timeout", "2")
+	os.Setenv("gauge_event_webhook_retries", "-1")
+
+	c.Assert(EventWebhookTimeout(), Equals, 2*time.Second)
+	c.Assert(EventWebhookRetries(), Equals, 3)
+}
@@ -0,0 +1,74 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package execution
+
+import (
+	"sync"
+
+	"github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/gauge"
+)
+
+// PausePoint describes a step, or a concept invocation, which is about to be executed.
+type PausePoint struct {
+	Step *gauge.Step
+	// Parameters are the parameters of the step with the values they resolve to for the current data table row.
+	Parameters []*gauge_messages.Parameter
+	// Concepts are the concept invocations the step is nested in, outermost first.
+	Concepts []*gauge.Step
+	Scenario *gauge.Scenario
+	SpecFile string
+}
+
+var debugger struct {
+	sync.RWMutex
+	beforeStep func(p *PausePoint)
+}
+
+// AttachDebugger makes the execution call beforeStep before every step and concept invocation of a scenario.
+// The execution waits till it returns, which lets a debugger pause there.
+// Specs are executed serially while a debugger is attached.
+func AttachDebugger(beforeStep func(p *PausePoint)) {
+	debugger.Lock()
+	defer debugger.Unlock()
+	debugger.beforeStep = beforeStep
+}
+
+// DetachDebugger stops the execution from pausing before steps.
+func DetachDebugger() {
+	AttachDebugger(nil)
+}
+
+// DebuggerAttached tells if a debugger is attached to the execution.
+func DebuggerAttached() bool {
+	debugger.RLock()
+	defer debugger.RUnlock()
+	return debugger.beforeStep != nil
+}
+
+// BeforeStep passes the pause point to the attached debugger, if any, and waits till it returns.
+func BeforeStep(p *PausePoint) {
+	debugger.RLock()
+	beforeStep := debugger.beforeStep
+	debugger.RUnlock()
+	if beforeStep != nil {
+		beforeStep(p)
+	}
+}
+
+func (e *scenarioExecutor) pause(step *gauge.Step, fragments []*gauge_messages.Fragment) {
+	if !DebuggerAttached() {
+		return
+	}
+	BeforeStep(&PausePoint{
+		Step:       step,
+		Parameters: getParameters(fragments),
+		Concepts:   append([]*gauge.Step{}, e.concepts...),
+		Scenario:   e.scenario,
+		SpecFile:   e.currentExecutionInfo.GetCurrentSpec().GetFileName(),
+	})
+}
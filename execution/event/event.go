@@ -68,11 +68,27 @@ func InitRegistry() {
 // Register registers the given channel to the given list of topics. Any updates for the given topics
 // will be sent on this channel
 func Register(ch chan ExecutionEvent, topics ...Topic) {
+	if subscriberRegistry == nil {
+		InitRegistry()
+	}
 	for _, t := range topics {
 		subscriberRegistry[t] = append(subscriberRegistry[t], ch)
 	}
 }
 
+// Unregister removes the given channel from all the topics it is registered to.
+func Unregister(ch chan ExecutionEvent) {
+	for t, subscribers := range subscriberRegistry {
+		remaining := make([]chan ExecutionEvent, 0, len(subscribers))
+		for _, c := range subscribers {
+			if c != ch {
+				remaining = append(remaining, c)
+			}
+		}
+		subscriberRegistry[t] = remaining
+	}
+}
+
 // Notify notifies all the subscribers of the event about its occurrence
 func Notify(e ExecutionEvent) {
 	for _, c := range subscriberRegistry[e.Topic] {
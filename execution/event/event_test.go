@@ -48,6 +48,20 @@ func (s *MySuite) TestRegisterForMultipleTopics(c *C) {
 	c.Assert(subscriberRegistry[SpecEnd][0], Equals, ch)
 }
 
+func (s *MySuite) TestUnregisterRemovesOnlyTheGivenChannel(c *C) {
+	InitRegistry()
+	ch1 := make(chan ExecutionEvent)
+	ch2 := make(chan ExecutionEvent)
+	Register(ch1, StepStart, StepEnd)
+	Register(ch2, StepEnd)
+
+	Unregister(ch1)
+
+	c.Assert(len(subscriberRegistry[StepStart]), Equals, 0)
+	c.Assert(len(subscriberRegistry[StepEnd]), Equals, 1)
+	c.Assert(subscriberRegistry[StepEnd][0], Equals, ch2)
+}
+
 func (s *MySuite) TestMultipleSubscribersRegisteringForMultipleEvent(c *C) {
 	InitRegistry()
 
@@ -40,15 +40,19 @@ import (
 	"path/filepath"
 
 	"github.com/getgauge/common"
+	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/rerun"
 	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/execution/webhook"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/plugin/install"
 	"github.com/getgauge/gauge/reporter"
+	"github.com/getgauge/gauge/runner"
 	"github.com/getgauge/gauge/validation"
 )
 
@@ -80,6 +84,7 @@ var ExecutionArgs []*gauge.ExecutionArg
 
 type suiteExecutor interface {
 	run() *result.SuiteResult
+	pluginStatuses() []plugin.Status
 }
 
 type executor interface {
@@ -125,16 +130,31 @@ var ExecuteSpecs = func(specDirs []string) int {
 	event.InitRegistry()
 	wg := &sync.WaitGroup{}
 	reporter.ListenExecutionEvents(wg)
-	rerun.ListenFailedScenarios(wg, specDirs)
+	rerun.ListenFailedScenarios(specDirs)
+	webhook.ListenExecutionEvents()
 	if env.SaveExecutionResult() {
 		ListenSuiteEndAndSaveResult(wg)
 	}
 	defer wg.Wait()
-	ei := newExecutionInfo(res.SpecCollection, res.Runner, nil, res.ErrMap, InParallel, 0)
+	ei := newExecutionInfo(res.SpecCollection, res.Runner, nil, res.ErrMap, inParallel(), 0)
 
 	e := ei.getExecutor()
 	logger.Debug(true, "Run started")
-	return printExecutionResult(e.run(), res.ParseOk)
+	suiteResult := e.run()
+	return printExecutionResult(suiteResult, e.pluginStatuses(), res.ParseOk)
+}
+
+// ExecuteWithRunner executes the specs with an already started runner, which is kept alive for later executions.
+// It is meant for long running clients like the language server, so plugins are not started and the result is not saved.
+func ExecuteWithRunner(specs []*gauge.Specification, r runner.Runner, errMap *gauge.BuildErrors) *result.SuiteResult {
+	ei := newExecutionInfo(gauge.NewSpecCollection(specs, false), r, &plugin.GaugePlugins{}, errMap, false, 0)
+	e := newSimpleExecution(ei, true, false)
+	e.startTime = time.Now()
+	event.Notify(event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, &gauge_messages.ExecutionInfo{}))
+	e.execute()
+	e.suiteResult = mergeDataTableSpecResults(e.suiteResult)
+	event.Notify(event.NewExecutionEvent(event.SuiteEnd, nil, e.suiteResult, 0, &gauge_messages.ExecutionInfo{}))
+	return e.suiteResult
 }
 
 func writeExecutionResult(content string) {
@@ -164,7 +184,7 @@ func ReadLastExecutionResult() (interface{}, error) {
 	return meta, nil
 }
 
-func printExecutionResult(suiteResult *result.SuiteResult, isParsingOk bool) int {
+func printExecutionResult(suiteResult *result.SuiteResult, pluginStatuses []plugin.Status, isParsingOk bool) int {
 	nSkippedSpecs := suiteResult.SpecsSkippedCount
 	var nExecutedSpecs int
 	if len(suiteResult.SpecResults) != 0 {
@@ -195,6 +215,9 @@ func printExecutionResult(suiteResult *result.SuiteResult, isParsingOk bool) int
 	s := statusJSON(nExecutedSpecs, nPassedSpecs, nFailedSpecs, nSkippedSpecs, nExecutedScenarios, nPassedScenarios, nFailedScenarios, nSkippedScenarios)
 	logger.Infof(true, "Specifications:\t%d executed\t%d passed\t%d failed\t%d skipped", nExecutedSpecs, nPassedSpecs, nFailedSpecs, nSkippedSpecs)
 	logger.Infof(true, "Scenarios:\t%d executed\t%d passed\t%d failed\t%d skipped", nExecutedScenarios, nPassedScenarios, nFailedScenarios, nSkippedScenarios)
+	for _, s := range pluginStatuses {
+		logger.Infof(true, "Plugin %s:\t%s", s.ID, s)
+	}
 	logger.Infof(true, "\nTotal time taken: %s", time.Millisecond*time.Duration(suiteResult.ExecutionTime))
 	writeExecutionResult(s)
 
@@ -207,6 +230,16 @@ func printExecutionResult(suiteResult *result.SuiteResult, isParsingOk bool) int
 	return Success
 }
 
+// inParallel tells if specs should be executed in parallel. A debugger follows a single flow of execution,
+// so specs are executed serially while one is attached.
+func inParallel() bool {
+	if InParallel && DebuggerAttached() {
+		logger.Infof(true, "Executing specs serially as a debugger is attached.")
+		return false
+	}
+	return InParallel
+}
+
 func validateFlags() error {
 	if MaxRetriesCount < 1 {
 		return fmt.Errorf("invalid input(%s) to --max-retries-count flag", strconv.Itoa(MaxRetriesCount))
@@ -63,6 +63,17 @@ func (s *MySuite) TestValidateFlagsWithInvalidStrategy(c *C) {
 	c.Assert(err.Error(), Equals, "invalid input(sdf) to --strategy flag")
 }
 
+func (s *MySuite) TestSpecsAreExecutedSeriallyWhenDebuggerIsAttached(c *C) {
+	InParallel = true
+	defer func() { InParallel = false }()
+	c.Assert(inParallel(), Equals, true)
+
+	AttachDebugger(func(p *PausePoint) {})
+	defer DetachDebugger()
+
+	c.Assert(inParallel(), Equals, false)
+}
+
 func (s *MySuite) TestValidateFlagsWithInvalidStream(c *C) {
 	InParallel = true
 	NumberOfExecutionStreams = -1
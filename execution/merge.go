@@ -66,7 +66,7 @@ func mergeResults(results []*result.SpecResult) *result.SpecResult {
 		IsTableDriven:    hasTableDrivenSpec(results),
 		PreHookMessages:  results[0].ProtoSpec.PreHookMessages,
 		PostHookMessages: results[len(results)-1].ProtoSpec.PostHookMessages,
-	}}
+	}, Metadata: results[0].Metadata}
 	var scnResults []*m.ProtoItem
 	table := &m.ProtoTable{}
 	dataTableScnResults := make(map[string][]*m.ProtoTableDrivenScenario)
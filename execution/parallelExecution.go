@@ -99,6 +99,13 @@ func (e *parallelExecution) start() {
 	e.pluginHandler = plugin.StartPlugins(e.manifest)
 }
 
+func (e *parallelExecution) pluginStatuses() []plugin.Status {
+	if e.pluginHandler == nil {
+		return nil
+	}
+	return e.pluginHandler.Statuses()
+}
+
 func (e *parallelExecution) startRunnersForRemainingStreams() {
 	totalStreams := e.numberOfStreams()
 	rChan := make(chan runner.Runner, totalStreams-1)
@@ -289,7 +296,7 @@ func (e *parallelExecution) finish() {
 			SuiteResult: gauge.ConvertToProtoSuiteResult(e.suiteResult),
 		},
 	}
-	e.pluginHandler.NotifyPlugins(message)
+	e.pluginHandler.NotifyPlugins(message, nil)
 	e.pluginHandler.GracefullyKillPlugins()
 }
 
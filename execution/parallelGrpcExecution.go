@@ -57,7 +57,7 @@ func (e *parallelExecution) notifyBeforeSuite() {
 			CurrentExecutionInfo: &gauge_messages.ExecutionInfo{},
 			Stream:               1},
 	}
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 	res := e.runners[0].ExecuteAndGetStatus(m)
 	e.suiteResult.PreHookMessages = res.Message
 	e.suiteResult.PreHookScreenshotFiles = res.ScreenshotFiles
@@ -66,7 +66,7 @@ func (e *parallelExecution) notifyBeforeSuite() {
 		result.AddPreHook(e.suiteResult, res)
 	}
 	m.ExecutionStartingRequest.SuiteResult = gauge.ConvertToProtoSuiteResult(e.suiteResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 }
 
 func (e *parallelExecution) notifyAfterSuite() {
@@ -76,7 +76,7 @@ func (e *parallelExecution) notifyAfterSuite() {
 			Stream:               1,
 		},
 	}
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 	res := e.runners[0].ExecuteAndGetStatus(m)
 	e.suiteResult.PostHookMessages = res.Message
 	e.suiteResult.PostHookScreenshotFiles = res.ScreenshotFiles
@@ -85,5 +85,5 @@ func (e *parallelExecution) notifyAfterSuite() {
 		result.AddPostHook(e.suiteResult, res)
 	}
 	m.ExecutionEndingRequest.SuiteResult = gauge.ConvertToProtoSuiteResult(e.suiteResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 }
@@ -13,21 +13,20 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"sync"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/config"
-	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
-	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/util"
 )
 
 const (
 	failedFile         = "failures.json"
 	lastRunCmdFileName = "lastRunCmd.json"
+	listenerName       = "rerun"
 )
 
 var failedMeta *failedMetadata
@@ -71,37 +70,35 @@ func (m *failedMetadata) addFailedItem(itemName string, item string) {
 	m.failedItemsMap[itemName][item] = true
 }
 
-// ListenFailedScenarios listens to execution events and writes the failed scenarios to JSON file
-func ListenFailedScenarios(wg *sync.WaitGroup, specDirs []string) {
-	ch := make(chan event.ExecutionEvent)
-	event.Register(ch, event.ScenarioEnd)
-	event.Register(ch, event.SpecEnd)
-	event.Register(ch, event.SuiteEnd)
-	wg.Add(1)
-
-	go func() {
-		for {
-			e := <-ch
-			switch e.Topic {
-			case event.ScenarioEnd:
-				prepareScenarioFailedMetadata(e.Result.(*result.ScenarioResult), e.Item.(*gauge.Scenario), e.ExecutionInfo)
-			case event.SpecEnd:
-				addFailedMetadata(e.Result, specDirs, addSpecFailedMetadata)
-			case event.SuiteEnd:
-				addFailedMetadata(e.Result, specDirs, addSuiteFailedMetadata)
-				failedMeta.aggregateFailedItems()
-				writeFailedMeta(getJSON(failedMeta))
-				wg.Done()
-			}
+// ListenFailedScenarios registers a listener for the execution messages which writes the failed scenarios to JSON file
+// at the end of the suite.
+func ListenFailedScenarios(specDirs []string) {
+	plugin.RegisterListener(listenerName, 0, failedScenariosListener(specDirs))
+}
+
+func failedScenariosListener(specDirs []string) plugin.ListenerFunc {
+	return func(m *gauge_messages.Message) error {
+		switch m.GetMessageType() {
+		case gauge_messages.Message_ScenarioExecutionEnding:
+			r := m.GetScenarioExecutionEndingRequest()
+			prepareScenarioFailedMetadata(r.GetScenarioResult().GetProtoItem().GetScenario(), r.GetCurrentExecutionInfo())
+		case gauge_messages.Message_SpecExecutionEnding:
+			addFailedMetadata(&result.SpecResult{ProtoSpec: m.GetSpecExecutionEndingRequest().GetSpecResult().GetProtoSpec()}, specDirs, addSpecFailedMetadata)
+		case gauge_messages.Message_SuiteExecutionResult:
+			r := m.GetSuiteExecutionResult().GetSuiteResult()
+			addFailedMetadata(&result.SuiteResult{PreSuite: r.GetPreHookFailure(), PostSuite: r.GetPostHookFailure()}, specDirs, addSuiteFailedMetadata)
+			failedMeta.aggregateFailedItems()
+			writeFailedMeta(getJSON(failedMeta))
 		}
-	}()
+		return nil
+	}
 }
 
-func prepareScenarioFailedMetadata(res *result.ScenarioResult, sce *gauge.Scenario, executionInfo *gauge_messages.ExecutionInfo) {
-	if res.GetFailed() {
+func prepareScenarioFailedMetadata(scenario *gauge_messages.ProtoScenario, executionInfo *gauge_messages.ExecutionInfo) {
+	if scenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_FAILED {
 		specPath := executionInfo.GetCurrentSpec().GetFileName()
 		failedScenario := util.RelPathToProjectRoot(specPath)
-		failedMeta.addFailedItem(specPath, fmt.Sprintf("%s:%v", failedScenario, sce.Span.Start))
+		failedMeta.addFailedItem(specPath, fmt.Sprintf("%s:%v", failedScenario, scenario.GetSpan().GetStart()))
 	}
 }
 
@@ -16,7 +16,6 @@ import (
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/execution/result"
-	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/util"
 
 	"sort"
@@ -54,15 +53,40 @@ func (s *MySuite) TestIfFailedFileIsCreated(c *C) {
 func (s *MySuite) TestGetScenarioFailedMetadata(c *C) {
 	spec1Rel := filepath.Join("specs", "example1.spec")
 	spec1Abs := filepath.Join(config.ProjectRoot, spec1Rel)
-	sce := &gauge.Scenario{Span: &gauge.Span{Start: 2}}
-	sr1 := &result.ScenarioResult{ProtoScenario: &gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED}}
+	sce := &gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED, Span: &gauge_messages.Span{Start: 2}}
 
-	prepareScenarioFailedMetadata(sr1, sce, &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: spec1Abs}})
+	prepareScenarioFailedMetadata(sce, &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: spec1Abs}})
 
 	c.Assert(len(failedMeta.failedItemsMap[spec1Abs]), Equals, 1)
 	c.Assert(failedMeta.failedItemsMap[spec1Abs][spec1Rel+":2"], Equals, true)
 }
 
+func (s *MySuite) TestPassedScenarioIsNotAddedToFailedMetadata(c *C) {
+	spec1Abs := filepath.Join(config.ProjectRoot, "specs", "example1.spec")
+	sce := &gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_PASSED, Span: &gauge_messages.Span{Start: 2}}
+
+	prepareScenarioFailedMetadata(sce, &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: spec1Abs}})
+
+	c.Assert(len(failedMeta.failedItemsMap), Equals, 0)
+}
+
+func (s *MySuite) TestFailedScenariosListenerWritesFailedScenariosAtSuiteEnd(c *C) {
+	spec1Rel := filepath.Join("specs", "example1.spec")
+	spec1Abs := filepath.Join(config.ProjectRoot, spec1Rel)
+	defer os.RemoveAll(filepath.Join(config.ProjectRoot, common.DotGauge))
+	l := failedScenariosListener([]string{})
+	info := &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: spec1Abs}}
+	scenario := &gauge_messages.ProtoScenario{ExecutionStatus: gauge_messages.ExecutionStatus_FAILED, Span: &gauge_messages.Span{Start: 5}}
+
+	c.Assert(l.Notify(&gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionEnding,
+		ScenarioExecutionEndingRequest: &gauge_messages.ScenarioExecutionEndingRequest{CurrentExecutionInfo: info,
+			ScenarioResult: &gauge_messages.ProtoScenarioResult{ProtoItem: &gauge_messages.ProtoItem{Scenario: scenario}}}}), IsNil)
+	c.Assert(l.Notify(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: &gauge_messages.ProtoSuiteResult{}}}), IsNil)
+
+	c.Assert(readLastFailedState().FailedItems, DeepEquals, []string{spec1Rel + ":5"})
+}
+
 func (s *MySuite) TestAddSpecPreHookFailedMetadata(c *C) {
 	spec1Rel := filepath.Join("specs", "example1.spec")
 	spec1Abs := filepath.Join(config.ProjectRoot, spec1Rel)
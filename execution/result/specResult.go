@@ -21,6 +21,9 @@ type SpecResult struct {
 	Skipped              bool
 	ScenarioSkippedCount int
 	Errors               []*gauge_messages.Error
+	// Metadata holds the front matter entries of the spec. gauge-proto has no field for it, so it is not a part of
+	// the ProtoSpec sent to plugins.
+	Metadata map[string]string
 }
 
 // SetFailure sets the result to failed
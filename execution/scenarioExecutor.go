@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"errors"
+	"os"
 
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/execution/event"
@@ -29,6 +30,8 @@ type scenarioExecutor struct {
 	stream               int
 	contexts             []*gauge.Step
 	teardowns            []*gauge.Step
+	scenario             *gauge.Scenario
+	concepts             []*gauge.Step
 }
 
 func newScenarioExecutor(r runner.Runner, ph plugin.Handler, ei *gauge_messages.ExecutionInfo, errMap *gauge.BuildErrors, contexts []*gauge.Step, teardowns []*gauge.Step, stream int) *scenarioExecutor {
@@ -46,22 +49,27 @@ func newScenarioExecutor(r runner.Runner, ph plugin.Handler, ei *gauge_messages.
 func (e *scenarioExecutor) execute(i gauge.Item, r result.Result) {
 	scenario := i.(*gauge.Scenario)
 	scenarioResult := r.(*result.ScenarioResult)
+	e.scenario = scenario
 	scenarioResult.ProtoScenario.ExecutionStatus = gauge_messages.ExecutionStatus_PASSED
 	scenarioResult.ProtoScenario.Skipped = false
 	if(e.runner.Info().Killed){
-		e.errMap.ScenarioErrs[scenario] = append([]error{errors.New("skipped Reason: Runner is not alive")}, e.errMap.ScenarioErrs[scenario]...)
-		setSkipInfoInResult(scenarioResult, scenario, e.errMap)
+		setSkipInfoInResult(scenarioResult, append([]error{errors.New("skipped Reason: Runner is not alive")}, e.errMap.ScenarioErrs[scenario]...))
 		return
 	}
 	if scenario.SpecDataTableRow.IsInitialized() && !shouldExecuteForRow(scenario.SpecDataTableRowIndex) {
-		e.errMap.ScenarioErrs[scenario] = append([]error{errors.New("skipped Reason: Doesn't satisfy --table-rows flag condition")}, e.errMap.ScenarioErrs[scenario]...)
-		setSkipInfoInResult(scenarioResult, scenario, e.errMap)
+		setSkipInfoInResult(scenarioResult, append([]error{errors.New("skipped Reason: Doesn't satisfy --table-rows flag condition")}, e.errMap.ScenarioErrs[scenario]...))
 		return
 	}
-	if _, ok := e.errMap.ScenarioErrs[scenario]; ok {
-		setSkipInfoInResult(scenarioResult, scenario, e.errMap)
+	// The errMap is shared by the streams of a parallel execution, so the skip reasons are only added to the result.
+	skipErrs, skipped := e.errMap.ScenarioErrs[scenario]
+	if reason, ok := scenario.SkipReason(os.Getenv); ok {
+		skipErrs, skipped = append([]error{fmt.Errorf("skipped Reason: %s", reason)}, skipErrs...), true
+	}
+	if skipped {
+		setSkipInfoInResult(scenarioResult, skipErrs)
 		event.Notify(event.NewExecutionEvent(event.ScenarioStart, scenario, scenarioResult, e.stream, e.currentExecutionInfo))
 		event.Notify(event.NewExecutionEvent(event.ScenarioEnd, scenario, scenarioResult, e.stream, e.currentExecutionInfo))
+		e.notifyListenersOfSkippedScenario(scenarioResult)
 		return
 	}
 	event.Notify(event.NewExecutionEvent(event.ScenarioStart, scenario, scenarioResult, e.stream, e.currentExecutionInfo))
@@ -70,6 +78,7 @@ func (e *scenarioExecutor) execute(i gauge.Item, r result.Result) {
 	res := e.initScenarioDataStore()
 	if res.GetFailed() {
 		e.handleScenarioDataStoreFailure(scenarioResult, scenario, fmt.Errorf("Failed to initialize scenario datastore. Error: %s", res.GetErrorMessage()))
+		e.notifyListenersOfSkippedScenario(scenarioResult)
 		return
 	}
 	e.notifyBeforeScenarioHook(scenarioResult)
@@ -86,8 +95,8 @@ func (e *scenarioExecutor) execute(i gauge.Item, r result.Result) {
 		e.executeSteps(e.teardowns, scenarioResult.ProtoScenario.GetTearDownSteps(), scenarioResult)
 	}
 
-	e.notifyAfterScenarioHook(scenarioResult)
 	scenarioResult.UpdateExecutionTime()
+	e.notifyAfterScenarioHook(scenarioResult)
 }
 
 func (e *scenarioExecutor) initScenarioDataStore() *gauge_messages.ProtoExecutionResult {
@@ -100,15 +109,14 @@ func (e *scenarioExecutor) handleScenarioDataStoreFailure(scenarioResult *result
 	logger.Errorf(true, err.Error())
 	validationError := validation.NewStepValidationError(&gauge.Step{LineNo: scenario.Heading.LineNo, LineText: scenario.Heading.Value},
 		err.Error(), e.currentExecutionInfo.CurrentSpec.GetFileName(), nil, "")
-	e.errMap.ScenarioErrs[scenario] = []error{validationError}
-	setSkipInfoInResult(scenarioResult, scenario, e.errMap)
+	setSkipInfoInResult(scenarioResult, []error{validationError})
 }
 
-func setSkipInfoInResult(scenarioResult *result.ScenarioResult, scenario *gauge.Scenario, errMap *gauge.BuildErrors) {
+func setSkipInfoInResult(scenarioResult *result.ScenarioResult, skipErrs []error) {
 	scenarioResult.ProtoScenario.ExecutionStatus = gauge_messages.ExecutionStatus_SKIPPED
 	scenarioResult.ProtoScenario.Skipped = true
 	var errs []string
-	for _, err := range errMap.ScenarioErrs[scenario] {
+	for _, err := range skipErrs {
 		errs = append(errs, err.Error())
 	}
 	scenarioResult.ProtoScenario.SkipErrors = errs
@@ -117,7 +125,7 @@ func setSkipInfoInResult(scenarioResult *result.ScenarioResult, scenario *gauge.
 func (e *scenarioExecutor) notifyBeforeScenarioHook(scenarioResult *result.ScenarioResult) {
 	message := &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionStarting,
 		ScenarioExecutionStartingRequest: &gauge_messages.ScenarioExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo, Stream: int32(e.stream)}}
-	e.pluginHandler.NotifyPlugins(message)
+	e.pluginHandler.NotifyPlugins(message, e.scenario)
 	res := executeHook(message, scenarioResult, e.runner)
 	scenarioResult.ProtoScenario.PreHookMessages = res.Message
 	scenarioResult.ProtoScenario.PreHookScreenshotFiles = res.ScreenshotFiles
@@ -127,7 +135,7 @@ func (e *scenarioExecutor) notifyBeforeScenarioHook(scenarioResult *result.Scena
 		handleHookFailure(scenarioResult, res, result.AddPreHook)
 	}
 	message.ScenarioExecutionStartingRequest.ScenarioResult = gauge.ConvertToProtoScenarioResult(scenarioResult)
-	e.pluginHandler.NotifyPlugins(message)
+	e.pluginHandler.NotifyPlugins(message, e.scenario)
 }
 
 func (e *scenarioExecutor) notifyAfterScenarioHook(scenarioResult *result.ScenarioResult) {
@@ -142,7 +150,17 @@ func (e *scenarioExecutor) notifyAfterScenarioHook(scenarioResult *result.Scenar
 		handleHookFailure(scenarioResult, res, result.AddPostHook)
 	}
 	message.ScenarioExecutionEndingRequest.ScenarioResult = gauge.ConvertToProtoScenarioResult(scenarioResult)
-	e.pluginHandler.NotifyPlugins(message)
+	e.pluginHandler.NotifyPlugins(message, e.scenario)
+}
+
+// notifyListenersOfSkippedScenario tells the listeners about a scenario which is skipped, which the plugins are not told
+// about.
+func (e *scenarioExecutor) notifyListenersOfSkippedScenario(scenarioResult *result.ScenarioResult) {
+	e.pluginHandler.NotifyListeners(&gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionStarting,
+		ScenarioExecutionStartingRequest: &gauge_messages.ScenarioExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo, Stream: int32(e.stream)}}, e.scenario)
+	e.pluginHandler.NotifyListeners(&gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionEnding,
+		ScenarioExecutionEndingRequest: &gauge_messages.ScenarioExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo,
+			ScenarioResult: gauge.ConvertToProtoScenarioResult(scenarioResult), Stream: int32(e.stream)}}, e.scenario)
 }
 
 func (e *scenarioExecutor) executeSteps(steps []*gauge.Step, protoItems []*gauge_messages.ProtoItem, scenarioResult *result.ScenarioResult) bool {
@@ -166,11 +184,13 @@ func (e *scenarioExecutor) executeStep(step *gauge.Step, protoItem *gauge_messag
 	var failed, recoverable bool
 	if protoItem.GetItemType() == gauge_messages.ProtoItem_Concept {
 		protoConcept := protoItem.GetConcept()
+		e.pause(step, protoConcept.GetConceptStep().GetFragments())
 		res := e.executeConcept(step, protoConcept, scenarioResult)
 		failed = res.GetFailed()
 		recoverable = res.GetRecoverable()
 
 	} else if protoItem.GetItemType() == gauge_messages.ProtoItem_Step {
+		e.pause(step, protoItem.GetStep().GetFragments())
 		se := &stepExecutor{runner: e.runner, pluginHandler: e.pluginHandler, currentExecutionInfo: e.currentExecutionInfo, stream: e.stream}
 		res := se.executeStep(step, protoItem.GetStep())
 		protoItem.GetStep().StepExecutionResult = res.ProtoStepExecResult()
@@ -184,6 +204,8 @@ func (e *scenarioExecutor) executeConcept(item *gauge.Step, protoConcept *gauge_
 	cptResult := result.NewConceptResult(protoConcept)
 	event.Notify(event.NewExecutionEvent(event.ConceptStart, item, nil, e.stream, e.currentExecutionInfo))
 	defer event.Notify(event.NewExecutionEvent(event.ConceptEnd, nil, cptResult, e.stream, e.currentExecutionInfo))
+	e.concepts = append(e.concepts, item)
+	defer func() { e.concepts = e.concepts[:len(e.concepts)-1] }()
 
 	var conceptStepIndex int
 	for _, protoStep := range protoConcept.Steps {
@@ -146,3 +146,70 @@ func TestNotifyAfterScenarioShouldAddAfterScenarioHookScreenshots(t *testing.T)
 		}
 	}
 }
+
+func TestExecuteShouldSkipScenarioWithSkipDirectiveWithoutCallingRunner(t *testing.T) {
+	r := &mockRunner{}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		t.Errorf("Expected skipped scenario not to reach the runner, got %s", m.MessageType)
+		return &gauge_messages.ProtoExecutionResult{}
+	}
+	ei := &gauge_messages.ExecutionInfo{}
+	errMap := gauge.NewBuildErrors()
+	sce := newScenarioExecutor(r, h, ei, errMap, nil, nil, 0)
+	scenario := &gauge.Scenario{
+		Heading:        &gauge.Heading{Value: "A scenario"},
+		Span:           &gauge.Span{Start: 2, End: 10},
+		SkipDirectives: []*gauge.SkipDirective{{LineNo: 3, Reason: "waiting for #123"}},
+	}
+	scenarioResult := result.NewScenarioResult(gauge.NewProtoScenario(scenario))
+
+	sce.execute(scenario, scenarioResult)
+
+	if scenarioResult.ProtoScenario.ExecutionStatus != gauge_messages.ExecutionStatus_SKIPPED {
+		t.Errorf("Expected scenario to be skipped, got : %s", scenarioResult.ProtoScenario.ExecutionStatus)
+	}
+	want := "skipped Reason: waiting for #123"
+	if errs := scenarioResult.ProtoScenario.SkipErrors; len(errs) != 1 || errs[0] != want {
+		t.Errorf("Expected skip errors [%s], got : %v", want, errs)
+	}
+	if len(errMap.ScenarioErrs) != 0 {
+		t.Errorf("Expected the skip reason not to be added to the errMap shared by the streams, got : %v", errMap.ScenarioErrs)
+	}
+}
+
+func TestExecuteStepsPausesBeforeConceptsAndTheirSteps(t *testing.T) {
+	r := &mockRunner{ExecuteAndGetStatusFunc: func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		return &gauge_messages.ProtoExecutionResult{}
+	}}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	ei := &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{FileName: "login.spec"}}
+	sce := newScenarioExecutor(r, h, ei, nil, nil, nil, 0)
+	step := &gauge.Step{Value: "enter user {}", LineNo: 2}
+	concept := &gauge.Step{Value: "login as {}", LineNo: 5, IsConcept: true, ConceptSteps: []*gauge.Step{step}}
+	user := &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_Dynamic, Name: "user", Value: "john"}
+	protoItems := []*gauge_messages.ProtoItem{{
+		ItemType: gauge_messages.ProtoItem_Concept,
+		Concept: &gauge_messages.ProtoConcept{
+			ConceptStep: &gauge_messages.ProtoStep{Fragments: []*gauge_messages.Fragment{{FragmentType: gauge_messages.Fragment_Parameter, Parameter: user}}},
+			Steps: []*gauge_messages.ProtoItem{{ItemType: gauge_messages.ProtoItem_Step, Step: &gauge_messages.ProtoStep{
+				StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{}},
+			}}},
+		},
+	}}
+	var pausePoints []*PausePoint
+	AttachDebugger(func(p *PausePoint) { pausePoints = append(pausePoints, p) })
+	defer DetachDebugger()
+
+	sce.executeSteps([]*gauge.Step{concept}, protoItems, result.NewScenarioResult(&gauge_messages.ProtoScenario{}))
+
+	if len(pausePoints) != 2 {
+		t.Fatalf("Expected to pause before the concept and its step, got: %d pauses", len(pausePoints))
+	}
+	if pausePoints[0].Step != concept || len(pausePoints[0].Concepts) != 0 || pausePoints[0].Parameters[0] != user || pausePoints[0].SpecFile != "login.spec" {
+		t.Errorf("Wrong pause before concept, got: %+v", pausePoints[0])
+	}
+	if pausePoints[1].Step != step || len(pausePoints[1].Concepts) != 1 || pausePoints[1].Concepts[0] != concept {
+		t.Errorf("Wrong pause before step of concept, got: %+v", pausePoints[1])
+	}
+}
@@ -113,6 +113,13 @@ func (e *simpleExecution) finish() {
 	e.stopAllPlugins()
 }
 
+func (e *simpleExecution) pluginStatuses() []plugin.Status {
+	if e.pluginHandler == nil {
+		return nil
+	}
+	return e.pluginHandler.Statuses()
+}
+
 func (e *simpleExecution) stopAllPlugins() {
 	e.notifyExecutionStop()
 	if err := e.runner.Kill(); err != nil {
@@ -173,7 +180,7 @@ func (e *simpleExecution) notifyBeforeSuite() {
 		handleHookFailure(e.suiteResult, res, result.AddPreHook)
 	}
 	m.ExecutionStartingRequest.SuiteResult = gauge.ConvertToProtoSuiteResult(e.suiteResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 }
 
 func (e *simpleExecution) notifyAfterSuite() {
@@ -187,7 +194,7 @@ func (e *simpleExecution) notifyAfterSuite() {
 		handleHookFailure(e.suiteResult, res, result.AddPostHook)
 	}
 	m.ExecutionEndingRequest.SuiteResult = gauge.ConvertToProtoSuiteResult(e.suiteResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 }
 
 func (e *simpleExecution) initSuiteDataStore() *(gauge_messages.ProtoExecutionResult) {
@@ -197,20 +204,20 @@ func (e *simpleExecution) initSuiteDataStore() *(gauge_messages.ProtoExecutionRe
 }
 
 func (e *simpleExecution) executeHook(m *gauge_messages.Message) *(gauge_messages.ProtoExecutionResult) {
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 	return e.runner.ExecuteAndGetStatus(m)
 }
 
 func (e *simpleExecution) notifyExecutionResult() {
 	m := &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
 		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: gauge.ConvertToProtoSuiteResult(e.suiteResult)}}
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 }
 
 func (e *simpleExecution) notifyExecutionStop() {
 	m := &gauge_messages.Message{MessageType: gauge_messages.Message_KillProcessRequest,
 		KillProcessRequest: &gauge_messages.KillProcessRequest{}}
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, nil)
 	e.pluginHandler.GracefullyKillPlugins()
 }
 
@@ -42,7 +42,7 @@ func newSpecExecutor(s *gauge.Specification, r runner.Runner, ph plugin.Handler,
 			Name:     s.Heading.Value,
 			FileName: s.FileName,
 			IsFailed: false,
-			Tags:     getTagValue(s.Tags)},
+			Tags:     filter.SpecTags(s)},
 		ProjectName:              filepath.Base(config.ProjectRoot),
 		NumberOfExecutionStreams: int32(NumberOfExecutionStreams),
 		RunnerId:                 int32(stream),
@@ -85,11 +85,13 @@ func (e *specExecutor) execute(executeBefore, execute, executeAfter bool) *resul
 		event.Notify(event.NewExecutionEvent(event.SpecStart, e.specification, e.specResult, e.stream, e.currentExecutionInfo))
 		if _, ok := e.errMap.SpecErrs[e.specification]; !ok {
 			if res := e.initSpecDataStore(); res.GetFailed() {
+				e.notifyListenersOfSpecStart()
 				e.skipSpecForError(fmt.Errorf("Failed to initialize spec datastore. Error: %s", res.GetErrorMessage()))
 			} else {
 				e.notifyBeforeSpecHook()
 			}
 		} else {
+			e.notifyListenersOfSpecStart()
 			e.specResult.SetSkipped(true)
 			e.specResult.Errors = e.convertErrors(e.errMap.SpecErrs[e.specification])
 		}
@@ -128,6 +130,10 @@ func (e *specExecutor) execute(executeBefore, execute, executeAfter bool) *resul
 	if executeAfter {
 		if _, ok := e.errMap.SpecErrs[e.specification]; !ok {
 			e.notifyAfterSpecHook()
+		} else {
+			e.pluginHandler.NotifyListeners(&gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionEnding,
+				SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo,
+					SpecResult: gauge.ConvertToProtoSpecResult(e.specResult), Stream: int32(e.stream)}}, e.specification)
 		}
 		event.Notify(event.NewExecutionEvent(event.SpecEnd, e.specification, e.specResult, e.stream, e.currentExecutionInfo))
 	}
@@ -173,7 +179,7 @@ func (e *specExecutor) initSpecDataStore() *gauge_messages.ProtoExecutionResult
 func (e *specExecutor) notifyBeforeSpecHook() {
 	m := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionStarting,
 		SpecExecutionStartingRequest: &gauge_messages.SpecExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo, Stream: int32(e.stream)}}
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, e.specification)
 	res := executeHook(m, e.specResult, e.runner)
 	e.specResult.ProtoSpec.PreHookMessages = res.Message
 	e.specResult.ProtoSpec.PreHookScreenshotFiles = res.ScreenshotFiles
@@ -183,7 +189,14 @@ func (e *specExecutor) notifyBeforeSpecHook() {
 		handleHookFailure(e.specResult, res, result.AddPreHook)
 	}
 	m.SpecExecutionStartingRequest.SpecResult = gauge.ConvertToProtoSpecResult(e.specResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, e.specification)
+}
+
+// notifyListenersOfSpecStart tells the listeners about the start of a spec which is skipped, which the plugins are not
+// told about.
+func (e *specExecutor) notifyListenersOfSpecStart() {
+	e.pluginHandler.NotifyListeners(&gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionStarting,
+		SpecExecutionStartingRequest: &gauge_messages.SpecExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo, Stream: int32(e.stream)}}, e.specification)
 }
 
 func (e *specExecutor) notifyAfterSpecHook() {
@@ -199,7 +212,7 @@ func (e *specExecutor) notifyAfterSpecHook() {
 		handleHookFailure(e.specResult, res, result.AddPostHook)
 	}
 	m.SpecExecutionEndingRequest.SpecResult = gauge.ConvertToProtoSpecResult(e.specResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, e.specification)
 }
 
 func (e *specExecutor) skipSpecForError(err error) {
@@ -285,13 +298,7 @@ func (e *specExecutor) executeScenario(scenario *gauge.Scenario) (*result.Scenar
 	shouldRetry := RetryOnlyTags == ""
 
 	if !shouldRetry {
-		spec := e.specification
-		tagValues := make([]string, 0)
-		if spec.Tags != nil {
-			tagValues = spec.Tags.Values()
-		}
-
-		specFilter := filter.NewScenarioFilterBasedOnTags(tagValues, RetryOnlyTags)
+		specFilter := filter.NewScenarioFilterBasedOnSpecTags(e.specification, RetryOnlyTags)
 
 		shouldRetry = !(specFilter.Filter(scenario))
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/validation"
 	. "gopkg.in/check.v1"
 )
@@ -141,7 +142,7 @@ func (s *MySuite) TestSpecIsSkippedIfDataRangeIsInvalid(c *C) {
 	r := &mockRunner{}
 	spec := anySpec()
 	errMap.SpecErrs[spec] = []error{validation.NewSpecValidationError("Table row number out of range", spec.FileName)}
-	se := newSpecExecutor(spec, r, nil, errMap, 0)
+	se := newSpecExecutor(spec, r, &mockPluginHandler{}, errMap, 0)
 
 	specResult := se.execute(true, false, false)
 	c.Assert(specResult.Skipped, Equals, true)
@@ -170,7 +171,7 @@ func (s *MySuite) TestDataTableRowsAreSkippedForUnimplemetedStep(c *C) {
 	r := &mockRunner{}
 	errMap.SpecErrs[spec] = []error{validation.NewSpecValidationError("Step implementation not found", spec.FileName)}
 	errMap.ScenarioErrs[spec.Scenarios[0]] = []error{validation.NewSpecValidationError("Step implementation not found", spec.FileName)}
-	se := newSpecExecutor(spec, r, nil, errMap, 0)
+	se := newSpecExecutor(spec, r, &mockPluginHandler{}, errMap, 0)
 
 	specResult := se.execute(true, true, true)
 	c.Assert(specResult.ProtoSpec.GetIsTableDriven(), Equals, true)
@@ -270,14 +271,21 @@ type mockPluginHandler struct {
 	GracefullyKillPluginsfunc func()
 }
 
-func (h *mockPluginHandler) NotifyPlugins(m *gauge_messages.Message) {
+func (h *mockPluginHandler) NotifyPlugins(m *gauge_messages.Message, item gauge.Item) {
 	h.NotifyPluginsfunc(m)
 }
 
+func (h *mockPluginHandler) NotifyListeners(m *gauge_messages.Message, item gauge.Item) {
+}
+
 func (h *mockPluginHandler) GracefullyKillPlugins() {
 	h.GracefullyKillPluginsfunc()
 }
 
+func (h *mockPluginHandler) Statuses() []plugin.Status {
+	return nil
+}
+
 func (h *mockPluginHandler) ExtendTimeout(id string) {
 
 }
@@ -370,7 +378,7 @@ func TestExecuteSkipsWhenSpecDatastoreInitFails(t *testing.T) {
 	r.ExecuteAndGetStatusFunc = func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
 		return &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "datastore init error"}
 	}
-	se := newSpecExecutor(exampleSpecWithScenarios, r, nil, errs, 0)
+	se := newSpecExecutor(exampleSpecWithScenarios, r, &mockPluginHandler{}, errs, 0)
 	res := se.execute(true, false, false)
 
 	if !res.Skipped {
@@ -723,3 +731,13 @@ func TestExecuteShouldMarkSpecAsSkippedWhenAllScenariosSkipped(t *testing.T) {
 		t.Error("Expect SpecResult.Skipped = true, got false")
 	}
 }
+
+func (s *MySuite) TestSpecInfoTagsDoNotIncludeFrontMatterEntries(c *C) {
+	spec := anySpec()
+	spec.Tags = &gauge.Tags{RawValues: [][]string{{"smoke"}}}
+	spec.Metadata = map[string]string{"owner": "payments", "jira": "PAY-1"}
+
+	se := newSpecExecutor(spec, &mockRunner{}, nil, nil, 0)
+
+	c.Assert(se.currentExecutionInfo.CurrentSpec.Tags, DeepEquals, []string{"smoke"})
+}
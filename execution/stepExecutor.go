@@ -36,7 +36,7 @@ func (e *stepExecutor) executeStep(step *gauge.Step, protoStep *gauge_messages.P
 	}
 	event.Notify(event.NewExecutionEvent(event.StepStart, step, nil, e.stream, e.currentExecutionInfo))
 
-	e.notifyBeforeStepHook(stepResult)
+	e.notifyBeforeStepHook(step, stepResult)
 	if !stepResult.GetFailed() {
 		executeStepMessage := &gauge_messages.Message{MessageType: gauge_messages.Message_ExecuteStep, ExecuteStepRequest: stepRequest}
 		stepExecutionStatus := e.runner.ExecuteAndGetStatus(executeStepMessage)
@@ -50,7 +50,7 @@ func (e *stepExecutor) executeStep(step *gauge.Step, protoStep *gauge_messages.P
 		}
 		stepResult.SetProtoExecResult(stepExecutionStatus)
 	}
-	e.notifyAfterStepHook(stepResult)
+	e.notifyAfterStepHook(step, stepResult)
 
 	event.Notify(event.NewExecutionEvent(event.StepEnd, *step, stepResult, e.stream, e.currentExecutionInfo))
 	defer e.currentExecutionInfo.CurrentStep.Reset()
@@ -63,12 +63,12 @@ func (e *stepExecutor) createStepRequest(protoStep *gauge_messages.ProtoStep) *g
 	return stepRequest
 }
 
-func (e *stepExecutor) notifyBeforeStepHook(stepResult *result.StepResult) {
+func (e *stepExecutor) notifyBeforeStepHook(step *gauge.Step, stepResult *result.StepResult) {
 	m := &gauge_messages.Message{
 		MessageType:                  gauge_messages.Message_StepExecutionStarting,
 		StepExecutionStartingRequest: &gauge_messages.StepExecutionStartingRequest{CurrentExecutionInfo: e.currentExecutionInfo, Stream: int32(e.stream)},
 	}
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, step)
 	res := executeHook(m, stepResult, e.runner)
 	stepResult.ProtoStep.PreHookMessages = res.Message
 	stepResult.ProtoStep.PreHookScreenshotFiles = res.ScreenshotFiles
@@ -78,10 +78,10 @@ func (e *stepExecutor) notifyBeforeStepHook(stepResult *result.StepResult) {
 		handleHookFailure(stepResult, res, result.AddPreHook)
 	}
 	m.StepExecutionStartingRequest.StepResult = gauge.ConvertToProtoStepResult(stepResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, step)
 }
 
-func (e *stepExecutor) notifyAfterStepHook(stepResult *result.StepResult) {
+func (e *stepExecutor) notifyAfterStepHook(step *gauge.Step, stepResult *result.StepResult) {
 	m := &gauge_messages.Message{
 		MessageType:                gauge_messages.Message_StepExecutionEnding,
 		StepExecutionEndingRequest: &gauge_messages.StepExecutionEndingRequest{CurrentExecutionInfo: e.currentExecutionInfo, Stream: int32(e.stream)},
@@ -96,5 +96,5 @@ func (e *stepExecutor) notifyAfterStepHook(stepResult *result.StepResult) {
 		handleHookFailure(stepResult, res, result.AddPostHook)
 	}
 	m.StepExecutionEndingRequest.StepResult = gauge.ConvertToProtoStepResult(stepResult)
-	e.pluginHandler.NotifyPlugins(m)
+	e.pluginHandler.NotifyPlugins(m, step)
 }
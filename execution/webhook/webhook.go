@@ -0,0 +1,275 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	listenerName     = "webhook"
+	listenerPriority = 10
+	queueSize        = 1024
+	retryBackoff     = 500 * time.Millisecond
+	drainTimeout     = 30 * time.Second
+)
+
+const (
+	suiteStart     = "suiteStart"
+	specEnd        = "specEnd"
+	scenarioFailed = "scenarioFailed"
+	suiteEnd       = "suiteEnd"
+)
+
+// ListenExecutionEvents registers a listener which posts summaries of the execution to the webhook URL set in the env,
+// at suite start, spec end, scenario failure and suite end. Nothing is registered if no URL is set.
+func ListenExecutionEvents() {
+	plugin.UnregisterListener(listenerName)
+	url := env.EventWebhookURL()
+	if url == "" {
+		return
+	}
+	plugin.RegisterListener(listenerName, listenerPriority, newSink(url, env.EventWebhookTimeout(), env.EventWebhookRetries(), retryBackoff))
+}
+
+// sink posts the events in the order of the execution from a queue, so that a slow webhook does not hold up the
+// execution. Events are dropped when the queue is full. The queue is drained at the end of the suite, for at most
+// the drain timeout, after which the events left are dropped.
+type sink struct {
+	url     string
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+	drain   time.Duration
+	queue   chan []byte
+	done    chan struct{}
+	stop    chan struct{}
+	closed  bool
+}
+
+func newSink(url string, timeout time.Duration, retries int, backoff time.Duration) *sink {
+	s := &sink{url: url, timeout: timeout, retries: retries, backoff: backoff, drain: drainTimeout,
+		queue: make(chan []byte, queueSize), done: make(chan struct{}), stop: make(chan struct{})}
+	go s.post()
+	return s
+}
+
+// post posts the queued events. Once an event fails after all its retries, the webhook is taken to be down and the
+// events after it are posted only once.
+func (s *sink) post() {
+	defer close(s.done)
+	retries := s.retries
+	for body := range s.queue {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+		if err := util.PostJSON(s.url, body, s.timeout, retries, s.backoff); err != nil {
+			logger.Warningf(true, "Failed to post execution event to %s. %s", s.url, err.Error())
+			retries = 0
+		}
+	}
+}
+
+// Notify queues the summary of the message, if it is one of the events posted to the webhook.
+func (s *sink) Notify(m *gauge_messages.Message) error {
+	if s.closed {
+		return nil
+	}
+	if e := eventFor(m); e != nil {
+		body, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		select {
+		case s.queue <- body:
+		default:
+			logger.Warningf(true, "Dropped execution event for %s as too many events are waiting to be posted.", s.url)
+		}
+	}
+	if m.GetMessageType() == gauge_messages.Message_SuiteExecutionResult {
+		s.closed = true
+		close(s.queue)
+		select {
+		case <-s.done:
+		case <-time.After(s.drain):
+			close(s.stop)
+			logger.Warningf(true, "Gave up posting execution events to %s after %s.", s.url, s.drain)
+		}
+	}
+	return nil
+}
+
+type counts struct {
+	Total   int `json:"total"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// event is the JSON posted to the webhook. The text is a one line summary, which chat services like Slack show as is.
+type event struct {
+	Event         string   `json:"event"`
+	Text          string   `json:"text"`
+	Timestamp     string   `json:"timestamp"`
+	Project       string   `json:"project,omitempty"`
+	Environment   string   `json:"environment,omitempty"`
+	Tags          string   `json:"tags,omitempty"`
+	Spec          string   `json:"spec,omitempty"`
+	Scenario      string   `json:"scenario,omitempty"`
+	File          string   `json:"file,omitempty"`
+	Line          int      `json:"line,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Errors        []string `json:"errors,omitempty"`
+	Specs         *counts  `json:"specs,omitempty"`
+	Scenarios     *counts  `json:"scenarios,omitempty"`
+	ExecutionTime int64    `json:"executionTime,omitempty"`
+}
+
+func eventFor(m *gauge_messages.Message) *event {
+	switch m.GetMessageType() {
+	case gauge_messages.Message_ExecutionStarting:
+		// The message is sent before and after the before suite hooks, only the latter has the suite result.
+		if r := m.GetExecutionStartingRequest().GetSuiteResult(); r != nil {
+			return suiteStartEvent(r)
+		}
+	case gauge_messages.Message_SpecExecutionEnding:
+		return specEndEvent(m.GetSpecExecutionEndingRequest().GetSpecResult())
+	case gauge_messages.Message_ScenarioExecutionEnding:
+		r := m.GetScenarioExecutionEndingRequest()
+		scenario := r.GetScenarioResult().GetProtoItem().GetScenario()
+		if scenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_FAILED {
+			return scenarioFailedEvent(scenario, r.GetCurrentExecutionInfo().GetCurrentSpec())
+		}
+	case gauge_messages.Message_SuiteExecutionResult:
+		return suiteEndEvent(m.GetSuiteExecutionResult().GetSuiteResult())
+	}
+	return nil
+}
+
+func newEvent(name string) *event {
+	return &event{Event: name, Timestamp: time.Now().Format(time.RFC3339)}
+}
+
+func suiteStartEvent(r *gauge_messages.ProtoSuiteResult) *event {
+	e := newEvent(suiteStart)
+	e.Project, e.Environment, e.Tags = r.GetProjectName(), r.GetEnvironment(), r.GetTags()
+	e.Text = fmt.Sprintf("Execution of %s started in %s environment", e.Project, e.Environment)
+	if hookFailed(r.GetPreHookFailure()) {
+		e.Status = "failed"
+		e.Errors = []string{r.GetPreHookFailure().GetErrorMessage()}
+		e.Text += ", before suite hook failed"
+	}
+	return e
+}
+
+func specEndEvent(r *gauge_messages.ProtoSpecResult) *event {
+	e := newEvent(specEnd)
+	e.Spec = r.GetProtoSpec().GetSpecHeading()
+	e.File = util.RelPathToProjectRoot(r.GetProtoSpec().GetFileName())
+	e.Status = status(r.GetFailed(), r.GetSkipped())
+	e.Scenarios = &counts{Total: int(r.GetScenarioCount()), Failed: int(r.GetScenarioFailedCount()), Skipped: int(r.GetScenarioSkippedCount())}
+	e.ExecutionTime = r.GetExecutionTime()
+	for _, f := range append(r.GetProtoSpec().GetPreHookFailures(), r.GetProtoSpec().GetPostHookFailures()...) {
+		e.Errors = append(e.Errors, f.GetErrorMessage())
+	}
+	e.Text = fmt.Sprintf("Spec %s %s: %d of %d scenarios failed", e.Spec, e.Status, e.Scenarios.Failed, e.Scenarios.Total)
+	return e
+}
+
+func scenarioFailedEvent(s *gauge_messages.ProtoScenario, spec *gauge_messages.SpecInfo) *event {
+	e := newEvent(scenarioFailed)
+	e.Spec = spec.GetName()
+	e.Scenario = s.GetScenarioHeading()
+	e.File = util.RelPathToProjectRoot(spec.GetFileName())
+	e.Line = int(s.GetSpan().GetStart())
+	e.Status = "failed"
+	e.ExecutionTime = s.GetExecutionTime()
+	e.Errors = scenarioErrors(s)
+	e.Text = fmt.Sprintf("Scenario %s failed at %s:%d", e.Scenario, e.File, e.Line)
+	if len(e.Errors) > 0 {
+		e.Text = fmt.Sprintf("%s. %s", e.Text, e.Errors[0])
+	}
+	return e
+}
+
+func suiteEndEvent(r *gauge_messages.ProtoSuiteResult) *event {
+	e := newEvent(suiteEnd)
+	e.Project, e.Environment, e.Tags = r.GetProjectName(), r.GetEnvironment(), r.GetTags()
+	e.Status = status(r.GetFailed(), false)
+	e.Specs = &counts{Total: len(r.GetSpecResults()), Failed: int(r.GetSpecsFailedCount()), Skipped: int(r.GetSpecsSkippedCount())}
+	e.Scenarios = &counts{}
+	for _, spec := range r.GetSpecResults() {
+		e.Scenarios.Total += int(spec.GetScenarioCount())
+		e.Scenarios.Failed += int(spec.GetScenarioFailedCount())
+		e.Scenarios.Skipped += int(spec.GetScenarioSkippedCount())
+	}
+	e.ExecutionTime = r.GetExecutionTime()
+	for _, f := range []*gauge_messages.ProtoHookFailure{r.GetPreHookFailure(), r.GetPostHookFailure()} {
+		if hookFailed(f) {
+			e.Errors = append(e.Errors, f.GetErrorMessage())
+		}
+	}
+	e.Text = fmt.Sprintf("Execution of %s %s: %d of %d specs and %d of %d scenarios failed", e.Project, e.Status,
+		e.Specs.Failed, e.Specs.Total, e.Scenarios.Failed, e.Scenarios.Total)
+	return e
+}
+
+func status(failed, skipped bool) string {
+	if failed {
+		return "failed"
+	}
+	if skipped {
+		return "skipped"
+	}
+	return "passed"
+}
+
+func hookFailed(f *gauge_messages.ProtoHookFailure) bool {
+	return f != nil && f.GetErrorMessage() != ""
+}
+
+// scenarioErrors returns the error messages of the failed hooks and steps of the scenario, including the steps of
+// its concepts.
+func scenarioErrors(s *gauge_messages.ProtoScenario) []string {
+	var errs []string
+	if hookFailed(s.GetPreHookFailure()) {
+		errs = append(errs, s.GetPreHookFailure().GetErrorMessage())
+	}
+	var items []*gauge_messages.ProtoItem
+	items = append(items, s.GetContexts()...)
+	items = append(items, s.GetScenarioItems()...)
+	items = append(items, s.GetTearDownSteps()...)
+	errs = append(errs, itemErrors(items)...)
+	if hookFailed(s.GetPostHookFailure()) {
+		errs = append(errs, s.GetPostHookFailure().GetErrorMessage())
+	}
+	return errs
+}
+
+func itemErrors(items []*gauge_messages.ProtoItem) []string {
+	var errs []string
+	for _, item := range items {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Step:
+			if r := item.GetStep().GetStepExecutionResult().GetExecutionResult(); r.GetFailed() {
+				errs = append(errs, r.GetErrorMessage())
+			}
+		case gauge_messages.ProtoItem_Concept:
+			errs = append(errs, itemErrors(item.GetConcept().GetSteps())...)
+		}
+	}
+	return errs
+}
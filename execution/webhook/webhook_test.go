@@ -0,0 +1,175 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+// webhookServer is a stand-in for a webhook, which records the events posted to it. It fails the first request to
+// check that it is retried.
+type webhookServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	attempts int
+	events   []event
+}
+
+func newWebhookServer(c *C) *webhookServer {
+	s := &webhookServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.attempts++
+		if s.attempts == 1 {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+			return
+		}
+		c.Check(r.Header.Get("Content-Type"), Equals, "application/json")
+		b, _ := ioutil.ReadAll(r.Body)
+		var e event
+		c.Check(json.Unmarshal(b, &e), IsNil)
+		s.events = append(s.events, e)
+	}))
+	return s
+}
+
+func (s *MySuite) SetUpTest(c *C) {
+	config.ProjectRoot, _ = filepath.Abs("_testdata")
+}
+
+func (s *MySuite) TestSinkPostsExecutionEventsInOrder(c *C) {
+	server := newWebhookServer(c)
+	defer server.Close()
+	specFile := filepath.Join(config.ProjectRoot, "specs", "login.spec")
+	info := &gauge_messages.ExecutionInfo{CurrentSpec: &gauge_messages.SpecInfo{Name: "Login", FileName: specFile}}
+	failed := &gauge_messages.ProtoScenario{ScenarioHeading: "Wrong password", ExecutionStatus: gauge_messages.ExecutionStatus_FAILED,
+		Span: &gauge_messages.Span{Start: 7}, ScenarioItems: []*gauge_messages.ProtoItem{
+			{ItemType: gauge_messages.ProtoItem_Concept, Concept: &gauge_messages.ProtoConcept{Steps: []*gauge_messages.ProtoItem{
+				{ItemType: gauge_messages.ProtoItem_Step, Step: &gauge_messages.ProtoStep{StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{
+					ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "expected error message"}}}},
+			}}},
+		}}
+	passed := &gauge_messages.ProtoScenario{ScenarioHeading: "Right password", ExecutionStatus: gauge_messages.ExecutionStatus_PASSED}
+	specResult := &gauge_messages.ProtoSpecResult{ProtoSpec: &gauge_messages.ProtoSpec{SpecHeading: "Login", FileName: specFile},
+		Failed: true, ScenarioCount: 2, ScenarioFailedCount: 1}
+	suite := &gauge_messages.ProtoSuiteResult{ProjectName: "shop", Environment: "ci"}
+	sink := newSink(server.URL, time.Second, 2, time.Millisecond)
+
+	messages := []*gauge_messages.Message{
+		{MessageType: gauge_messages.Message_ExecutionStarting, ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{}},
+		{MessageType: gauge_messages.Message_ExecutionStarting, ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{SuiteResult: suite}},
+		{MessageType: gauge_messages.Message_ScenarioExecutionEnding, ScenarioExecutionEndingRequest: &gauge_messages.ScenarioExecutionEndingRequest{
+			CurrentExecutionInfo: info, ScenarioResult: &gauge_messages.ProtoScenarioResult{ProtoItem: &gauge_messages.ProtoItem{Scenario: failed}}}},
+		{MessageType: gauge_messages.Message_ScenarioExecutionEnding, ScenarioExecutionEndingRequest: &gauge_messages.ScenarioExecutionEndingRequest{
+			CurrentExecutionInfo: info, ScenarioResult: &gauge_messages.ProtoScenarioResult{ProtoItem: &gauge_messages.ProtoItem{Scenario: passed}}}},
+		{MessageType: gauge_messages.Message_SpecExecutionEnding, SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{SpecResult: specResult}},
+		{MessageType: gauge_messages.Message_SuiteExecutionResult, SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{
+			SuiteResult: &gauge_messages.ProtoSuiteResult{ProjectName: "shop", Environment: "ci", Failed: true, SpecsFailedCount: 1,
+				SpecResults: []*gauge_messages.ProtoSpecResult{specResult}}}},
+		{MessageType: gauge_messages.Message_KillProcessRequest, KillProcessRequest: &gauge_messages.KillProcessRequest{}},
+	}
+	for _, m := range messages {
+		c.Assert(sink.Notify(m), IsNil)
+	}
+
+	c.Assert(server.events, HasLen, 4)
+	c.Assert(server.events[0].Event, Equals, suiteStart)
+	c.Assert(server.events[0].Text, Equals, "Execution of shop started in ci environment")
+	c.Assert(server.events[1].Event, Equals, scenarioFailed)
+	c.Assert(server.events[1].File, Equals, filepath.Join("specs", "login.spec"))
+	c.Assert(server.events[1].Line, Equals, 7)
+	c.Assert(server.events[1].Errors, DeepEquals, []string{"expected error message"})
+	c.Assert(server.events[1].Text, Equals, "Scenario Wrong password failed at "+filepath.Join("specs", "login.spec")+":7. expected error message")
+	c.Assert(server.events[2].Event, Equals, specEnd)
+	c.Assert(server.events[2].Status, Equals, "failed")
+	c.Assert(*server.events[2].Scenarios, Equals, counts{Total: 2, Failed: 1})
+	c.Assert(server.events[3].Event, Equals, suiteEnd)
+	c.Assert(*server.events[3].Specs, Equals, counts{Total: 1, Failed: 1})
+	c.Assert(server.events[3].Text, Equals, "Execution of shop failed: 1 of 1 specs and 1 of 2 scenarios failed")
+}
+
+func (s *MySuite) TestSinkLogsFailedPostsWithoutFailingExecution(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	sink := newSink(server.URL, time.Second, 1, time.Millisecond)
+
+	c.Assert(sink.Notify(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: &gauge_messages.ProtoSuiteResult{}}}), IsNil)
+	c.Assert(sink.closed, Equals, true)
+}
+
+func (s *MySuite) TestSinkStopsRetryingOnceAnEventExhaustsItsRetries(c *C) {
+	var mutex sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		attempts++
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	sink := newSink(server.URL, time.Second, 2, time.Millisecond)
+	spec := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionEnding,
+		SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{SpecResult: &gauge_messages.ProtoSpecResult{}}}
+
+	c.Assert(sink.Notify(spec), IsNil)
+	c.Assert(sink.Notify(spec), IsNil)
+	c.Assert(sink.Notify(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: &gauge_messages.ProtoSuiteResult{}}}), IsNil)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	c.Assert(attempts, Equals, 5)
+}
+
+func (s *MySuite) TestSinkGivesUpDrainingAfterTheDrainTimeout(c *C) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+	sink := newSink(server.URL, time.Minute, 0, time.Millisecond)
+	sink.drain = 50 * time.Millisecond
+
+	start := time.Now()
+	c.Assert(sink.Notify(&gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: &gauge_messages.ProtoSuiteResult{}}}), IsNil)
+
+	c.Assert(time.Since(start) < 10*time.Second, Equals, true)
+}
+
+func (s *MySuite) TestSinkDropsEventsWhenTheQueueIsFull(c *C) {
+	sink := &sink{url: "http://localhost", queue: make(chan []byte, 1)}
+	spec := &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionEnding,
+		SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{SpecResult: &gauge_messages.ProtoSpecResult{}}}
+
+	c.Assert(sink.Notify(spec), IsNil)
+	c.Assert(sink.Notify(spec), IsNil)
+
+	c.Assert(sink.queue, HasLen, 1)
+}
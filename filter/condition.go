@@ -0,0 +1,88 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+const envPropertyPrefix = "env."
+
+// Condition is a condition on env properties, like the one of a `@skip-if` directive. It is written like a tag
+// expression, whose operands are env properties instead of tags. `env.NAME` is true if the property is set to a non
+// empty value, and `env.NAME == "value"` or `env.NAME != 'value'` compares it with a quoted value.
+type Condition struct {
+	raw  string
+	root tagExpNode
+}
+
+// envProperties looks up the env properties which are the operands of a condition.
+type envProperties func(string) string
+
+func (getenv envProperties) has(name string) bool {
+	return getenv(name) != ""
+}
+
+func (getenv envProperties) value(name string) string {
+	return getenv(name)
+}
+
+// ParseCondition parses a condition, which ends at the end of the text or at a ',' outside of parentheses. The text
+// from that ',' on is returned along with the condition. The returned error is a *TagExpressionError pointing at the
+// offending position.
+func ParseCondition(exp string) (*Condition, string, error) {
+	tokens, err := tokenizeExpression(exp, true)
+	if err != nil {
+		return nil, "", err
+	}
+	p := &tagExpParser{exp: exp, tokens: tokens, conditions: true}
+	if t := p.peek(); t.kind == endToken || t.kind == commaToken {
+		return nil, "", p.errorf(t, "empty condition")
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, "", err
+	}
+	t := p.peek()
+	if t.kind != endToken && t.kind != commaToken {
+		return nil, "", p.errorf(t, "unexpected %s", t)
+	}
+	runes := []rune(exp)
+	end := t.pos - 1
+	return &Condition{raw: strings.TrimSpace(string(runes[:end])), root: root}, string(runes[end:]), nil
+}
+
+// Evaluate reports whether the condition holds for the env properties, which are looked up with getenv.
+func (c *Condition) Evaluate(getenv func(string) string) bool {
+	return c.root.eval(envProperties(getenv))
+}
+
+func (c *Condition) String() string {
+	return c.raw
+}
+
+func (p *tagExpParser) parseComparison(t tagExpToken) (tagExpNode, error) {
+	name := strings.TrimPrefix(t.value, envPropertyPrefix)
+	if !strings.HasPrefix(t.value, envPropertyPrefix) || name == "" || strings.IndexFunc(name, isNotEnvPropertyChar) >= 0 {
+		return nil, p.errorf(t, "expected an env property like env.NAME, found '%s'", t.value)
+	}
+	op := p.peek()
+	if op.kind != equalsToken && op.kind != notEqualsToken {
+		return &tagNode{name: name}, nil
+	}
+	p.next()
+	v := p.next()
+	if v.kind != stringToken {
+		return nil, p.errorf(v, "expected a quoted value after '%s', found %s", op.value, v)
+	}
+	return &comparisonNode{name: name, value: v.value, negate: op.kind == notEqualsToken}, nil
+}
+
+func isNotEnvPropertyChar(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '-'
+}
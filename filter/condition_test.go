@@ -0,0 +1,54 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package filter
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func getenvFrom(props map[string]string) func(string) string {
+	return func(name string) string { return props[name] }
+}
+
+func (s *MySuite) TestConditionWithOperatorsOfTagExpressions(c *C) {
+	cond, rest, err := ParseCondition(`env.BROWSER == "safari" and not (env.CI | env.gauge_env != 'dev')`)
+
+	c.Assert(err, IsNil)
+	c.Assert(rest, Equals, "")
+	c.Assert(cond.Evaluate(getenvFrom(map[string]string{"BROWSER": "safari", "gauge_env": "dev"})), Equals, true)
+	c.Assert(cond.Evaluate(getenvFrom(map[string]string{"BROWSER": "safari", "gauge_env": "ci"})), Equals, false)
+	c.Assert(cond.Evaluate(getenvFrom(map[string]string{"BROWSER": "safari", "gauge_env": "dev", "CI": "true"})), Equals, false)
+}
+
+func (s *MySuite) TestConditionEndsAtCommaOutsideParentheses(c *C) {
+	cond, rest, err := ParseCondition(`env.OS == "a,b" && !env.CI , "flaky on CI"`)
+
+	c.Assert(err, IsNil)
+	c.Assert(cond.String(), Equals, `env.OS == "a,b" && !env.CI`)
+	c.Assert(rest, Equals, `, "flaky on CI"`)
+	c.Assert(cond.Evaluate(getenvFrom(map[string]string{"OS": "a,b"})), Equals, true)
+}
+
+func (s *MySuite) TestInvalidConditions(c *C) {
+	invalid := map[string]int{
+		``:                        1,
+		`BROWSER == "safari"`:     1,
+		`env.BROWSER == safari`:   16,
+		`env.BROWSER = "safari"`:  13,
+		`env.BROWSER == "safari`:  16,
+		`(env.CI, "reason")`:      8,
+		`env.CI env.OS`:           1,
+		`env.CI && "yes"`:         11,
+		`env.CI & (env.OS == "a"`: 24,
+		`env.CI == "yes" env.OS`:  17,
+	}
+	for text, position := range invalid {
+		_, _, err := ParseCondition(text)
+		c.Assert(err, NotNil, Commentf("expected %s to be invalid", text))
+		c.Assert(err.(*TagExpressionError).Position, Equals, position, Commentf(text))
+	}
+}
@@ -7,16 +7,8 @@
 package filter
 
 import (
-	"errors"
-	"go/constant"
-	"go/token"
-	"go/types"
-	"regexp"
-	"strconv"
 	"strings"
 
-	"fmt"
-
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
 )
@@ -26,7 +18,9 @@ type scenarioFilterBasedOnSpan struct {
 }
 type ScenarioFilterBasedOnTags struct {
 	specTags      []string
+	metadata      map[string]string
 	tagExpression string
+	expression    *TagExpression
 }
 
 type scenarioFilterBasedOnName struct {
@@ -47,7 +41,12 @@ func (filter *scenarioFilterBasedOnSpan) Filter(item gauge.Item) bool {
 }
 
 func NewScenarioFilterBasedOnTags(specTags []string, tagExp string) *ScenarioFilterBasedOnTags {
-	return &ScenarioFilterBasedOnTags{specTags, tagExp}
+	return &ScenarioFilterBasedOnTags{specTags: specTags, tagExpression: tagExp}
+}
+
+// NewScenarioFilterBasedOnSpecTags filters the scenarios of the spec on its tags and its front matter metadata.
+func NewScenarioFilterBasedOnSpecTags(spec *gauge.Specification, tagExp string) *ScenarioFilterBasedOnTags {
+	return &ScenarioFilterBasedOnTags{specTags: SpecTags(spec), metadata: spec.Metadata, tagExpression: tagExp}
 }
 
 func (filter *ScenarioFilterBasedOnTags) Filter(item gauge.Item) bool {
@@ -66,151 +65,71 @@ func (filter *scenarioFilterBasedOnName) Filter(item gauge.Item) bool {
 	return !item.(*gauge.Scenario).HasAnyHeading(filter.scenariosName)
 }
 
-func sanitize(tag string) string {
-	if _, err := strconv.ParseBool(tag); err == nil {
-		return fmt.Sprintf("{%s}", tag)
-	}
-	return tag
-}
-
 func (filter *ScenarioFilterBasedOnTags) filterTags(stags []string) bool {
-	tagsMap := make(map[string]bool)
-	for _, tag := range stags {
-		tag = sanitize(tag)
-		tagsMap[strings.Replace(tag, " ", "", -1)] = true
-	}
-	filter.replaceSpecialChar()
-	value, _ := filter.formatAndEvaluateExpression(tagsMap, filter.isTagPresent)
-	return value
-}
-
-func (filter *ScenarioFilterBasedOnTags) replaceSpecialChar() {
-	filter.tagExpression = strings.Replace(strings.Replace(strings.Replace(strings.Replace(filter.tagExpression, " ", "", -1), ",", "&", -1), "&&", "&", -1), "||", "|", -1)
-}
-
-func (filter *ScenarioFilterBasedOnTags) formatAndEvaluateExpression(tagsMap map[string]bool, isTagQualified func(tagsMap map[string]bool, tagName string) bool) (bool, error) {
-	tagExpressionParts, tags := filter.parseTagExpression()
-	for _, tag := range tags {
-		for i, txp := range tagExpressionParts {
-			if strings.TrimSpace(txp) == strings.TrimSpace(tag) {
-				tagExpressionParts[i] = strconv.FormatBool(isTagQualified(tagsMap, strings.TrimSpace(tag)))
-			}
+	if filter.expression == nil {
+		exp, err := ParseTagExpression(filter.tagExpression)
+		if err != nil {
+			return false
 		}
+		filter.expression = exp
 	}
-	return filter.evaluateExp(filter.handleNegation(strings.Join(tagExpressionParts, "")))
+	return filter.expression.EvaluateWithMetadata(stags, filter.metadata)
 }
 
-func (filter *ScenarioFilterBasedOnTags) handleNegation(tagExpression string) string {
-	tagExpression = strings.Replace(strings.Replace(tagExpression, "!true", "false", -1), "!false", "true", -1)
-	for strings.Contains(tagExpression, "!(") {
-		tagExpression = filter.evaluateBrackets(tagExpression)
+// SpecTags returns the tags of the spec. The front matter metadata is not a part of the tags.
+func SpecTags(spec *gauge.Specification) []string {
+	tagValues := make([]string, 0)
+	if spec.Tags != nil {
+		tagValues = append(tagValues, spec.Tags.Values()...)
 	}
-	return tagExpression
+	return tagValues
 }
 
-func (filter *ScenarioFilterBasedOnTags) evaluateBrackets(tagExpression string) string {
-	if strings.Contains(tagExpression, "!(") {
-		innerText := filter.resolveBracketExpression(tagExpression)
-		return strings.Replace(tagExpression, "!("+innerText+")", filter.evaluateBrackets(innerText), -1)
-	}
-	value, _ := filter.evaluateExp(tagExpression)
-	return strconv.FormatBool(!value)
-}
-
-func (filter *ScenarioFilterBasedOnTags) resolveBracketExpression(tagExpression string) string {
-	indexOfOpenBracket := strings.Index(tagExpression, "!(") + 1
-	bracketStack := make([]string, 0)
-	i := indexOfOpenBracket
-	for ; i < len(tagExpression); i++ {
-		if tagExpression[i] == '(' {
-			bracketStack = append(bracketStack, "(")
-		} else if tagExpression[i] == ')' {
-			bracketStack = bracketStack[:len(bracketStack)-1]
+func filterSpecsByTags(specs []*gauge.Specification, tagExpression string) ([]*gauge.Specification, []*gauge.Specification) {
+	warnKeywordTags(specs, tagExpression)
+	filteredSpecs := make([]*gauge.Specification, 0)
+	otherSpecs := make([]*gauge.Specification, 0)
+	for _, spec := range specs {
+		specWithFilteredItems, specWithOtherItems := spec.Filter(NewScenarioFilterBasedOnSpecTags(spec, tagExpression))
+		if len(specWithFilteredItems.Scenarios) != 0 {
+			filteredSpecs = append(filteredSpecs, specWithFilteredItems)
 		}
-		if len(bracketStack) == 0 {
-			break
+		if len(specWithOtherItems.Scenarios) != 0 {
+			otherSpecs = append(otherSpecs, specWithOtherItems)
 		}
 	}
-	return tagExpression[indexOfOpenBracket+1 : i]
+	return filteredSpecs, otherSpecs
 }
 
-func (filter *ScenarioFilterBasedOnTags) evaluateExp(tagExpression string) (bool, error) {
-	tre := regexp.MustCompile("true")
-	fre := regexp.MustCompile("false")
-
-	s := fre.ReplaceAllString(tre.ReplaceAllString(tagExpression, "1"), "0")
-
-	val, err := types.Eval(token.NewFileSet(), nil, 0, s)
+// warnKeywordTags warns about the tags of the specs and scenarios named like a keyword which the tag expression
+// uses, as the expression does not refer to those tags.
+func warnKeywordTags(specs []*gauge.Specification, tagExpression string) {
+	exp, err := ParseTagExpression(tagExpression)
 	if err != nil {
-		return false, errors.New("Invalid Expression.\n" + err.Error())
-	}
-	res, _ := constant.Uint64Val(val.Value)
-
-	var final bool
-	if res == 1 {
-		final = true
-	} else {
-		final = false
+		return
 	}
-
-	return final, nil
-}
-
-func (filter *ScenarioFilterBasedOnTags) isTagPresent(tagsMap map[string]bool, tagName string) bool {
-	_, ok := tagsMap[tagName]
-	return ok
-}
-
-func (filter *ScenarioFilterBasedOnTags) parseTagExpression() (tagExpressionParts []string, tags []string) {
-	isValidOperator := func(r rune) bool { return r == '&' || r == '|' || r == '(' || r == ')' || r == '!' }
-	var word string
-	var wordValue = func() string {
-		return sanitize(strings.TrimSpace(word))
-	}
-	for _, c := range filter.tagExpression {
-		c1, _ := strconv.Unquote(strconv.QuoteRuneToASCII(c))
-		if isValidOperator(c) {
-			if word != "" {
-				tagExpressionParts = append(tagExpressionParts, wordValue())
-				tags = append(tags, wordValue())
+	warned := make(map[string]bool)
+	warn := func(tags *gauge.Tags) {
+		if tags == nil {
+			return
+		}
+		for _, tag := range tags.Values() {
+			if exp.UsesKeyword(tag) && !warned[tag] {
+				warned[tag] = true
+				logger.Warningf(true, "Tag '%s' is a keyword in tag expressions. Quote it as \"%s\" or escape it as \\%s to filter by the tag.", tag, tag, tag)
 			}
-			tagExpressionParts = append(tagExpressionParts, c1)
-			word = ""
-		} else {
-			word += c1
 		}
 	}
-	if word != "" {
-		tagExpressionParts = append(tagExpressionParts, wordValue())
-		tags = append(tags, wordValue())
-	}
-	return
-}
-
-func filterSpecsByTags(specs []*gauge.Specification, tagExpression string) ([]*gauge.Specification, []*gauge.Specification) {
-	filteredSpecs := make([]*gauge.Specification, 0)
-	otherSpecs := make([]*gauge.Specification, 0)
 	for _, spec := range specs {
-		tagValues := make([]string, 0)
-		if spec.Tags != nil {
-			tagValues = spec.Tags.Values()
-		}
-		specWithFilteredItems, specWithOtherItems := spec.Filter(NewScenarioFilterBasedOnTags(tagValues, tagExpression))
-		if len(specWithFilteredItems.Scenarios) != 0 {
-			filteredSpecs = append(filteredSpecs, specWithFilteredItems)
-		}
-		if len(specWithOtherItems.Scenarios) != 0 {
-			otherSpecs = append(otherSpecs, specWithOtherItems)
+		warn(spec.Tags)
+		for _, scenario := range spec.Scenarios {
+			warn(scenario.Tags)
 		}
 	}
-	return filteredSpecs, otherSpecs
 }
 
 func validateTagExpression(tagExpression string) {
-	filter := &ScenarioFilterBasedOnTags{tagExpression: tagExpression}
-	filter.replaceSpecialChar()
-	_, err := filter.formatAndEvaluateExpression(make(map[string]bool), func(a map[string]bool, b string) bool { return true })
-	if err != nil {
+	if _, err := ParseTagExpression(tagExpression); err != nil {
 		logger.Fatalf(true, err.Error())
 	}
 }
@@ -49,9 +49,16 @@ func (s *MySuite) TestToEvaluateTagExpressionConsistingLogicalNotOperator(c *C)
 	c.Assert(filter.filterTags([]string{"tag2", "tag3"}), Equals, true)
 }
 
+// With tag2 and tag4, tag5 is false, so !(tag5) is true, (tag6 | true) is true and !(...) is false. Then (tag 1 |
+// false) is false, its negation is true, (true & tag2) is true and the whole expression is false. The evaluator which
+// the parser replaced resolved negations by string replacement and got this case wrong, returning true.
 func (s *MySuite) TestToEvaluateTagExpressionConsistingManyLogicalNotOperator(c *C) {
 	filter := &ScenarioFilterBasedOnTags{tagExpression: "!(!(tag 1 | !(tag6 | !(tag5))) & tag2)"}
 	value := filter.filterTags([]string{"tag2", "tag4"})
+	c.Assert(value, Equals, false)
+
+	filter = &ScenarioFilterBasedOnTags{tagExpression: "!(!(tag 1 | !(tag6 | !(tag5))) & tag2)"}
+	value = filter.filterTags([]string{"tag2", "tag5"})
 	c.Assert(value, Equals, true)
 }
 
@@ -92,18 +99,17 @@ func (s *MySuite) TestToEvaluateTagExpressionWhenTagIsSubsetOfTrueOrFalse(c *C)
 	c.Assert(filter.filterTags([]string{"a", "b"}), Equals, true)
 }
 
-func (s *MySuite) TestParseTagExpression(c *C) {
-	filter := &ScenarioFilterBasedOnTags{tagExpression: "b || c | b & b && a"}
-	txps, tags := filter.parseTagExpression()
+func (s *MySuite) TestTokenizeTagExpression(c *C) {
+	tokens, err := tokenizeTagExpression("b || c | b & b && a")
+	c.Assert(err, IsNil)
 
-	expectedTxps := []string{"b", "|", "|", "c", "|", "b", "&", "b", "&", "&", "a"}
-	expectedTags := []string{"b", "c", "b", "b", "a"}
+	expectedValues := []string{"b", "||", "c", "|", "b", "&", "b", "&&", "a", ""}
+	expectedKinds := []tagTokenKind{tagToken, orToken, tagToken, orToken, tagToken, andToken, tagToken, andToken, tagToken, endToken}
 
-	for i, t := range txps {
-		c.Assert(expectedTxps[i], Equals, t)
-	}
-	for i, t := range tags {
-		c.Assert(expectedTags[i], Equals, t)
+	c.Assert(len(tokens), Equals, len(expectedValues))
+	for i, t := range tokens {
+		c.Assert(t.value, Equals, expectedValues[i])
+		c.Assert(t.kind, Equals, expectedKinds[i])
 	}
 }
 
@@ -805,3 +811,59 @@ func (s *MySuite) TestScenarioTagFilterShouldNotRemoveNonScenarioKindItems(c *C)
 	c.Assert(len(specWithOtherItems), Equals, 1)
 	c.Assert(len(specWithOtherItems[0].Items), Equals, 4)
 }
+
+func (s *MySuite) TestToFilterScenariosBySpecMetadata(c *C) {
+	scenario1 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "First Scenario"},
+		Span:    &gauge.Span{Start: 1, End: 3},
+		Tags:    &gauge.Tags{RawValues: [][]string{[]string{"slow"}}},
+	}
+	scenario2 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Second Scenario"},
+		Span:    &gauge.Span{Start: 4, End: 6},
+	}
+	payments := &gauge.Specification{
+		Items:     []gauge.Item{scenario1, scenario2},
+		Scenarios: []*gauge.Scenario{scenario1, scenario2},
+		Metadata:  map[string]string{"owner": "payments"},
+	}
+	scenario3 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Third Scenario"},
+		Span:    &gauge.Span{Start: 4, End: 6},
+	}
+	billing := &gauge.Specification{
+		Items:     []gauge.Item{scenario3},
+		Scenarios: []*gauge.Scenario{scenario3},
+		Metadata:  map[string]string{"owner": "billing"},
+	}
+
+	filteredSpecs, _ := filterSpecsByTags([]*gauge.Specification{payments, billing}, "owner=payments & !slow")
+
+	c.Assert(len(filteredSpecs), Equals, 1)
+	c.Assert(filteredSpecs[0].Scenarios, DeepEquals, []*gauge.Scenario{scenario2})
+}
+
+func (s *MySuite) TestSpecTagsDoesNotIncludeMetadataEntries(c *C) {
+	spec := &gauge.Specification{
+		Tags:     &gauge.Tags{RawValues: [][]string{[]string{"smoke"}}},
+		Metadata: map[string]string{"owner": "payments", "component": "checkout"},
+	}
+
+	c.Assert(SpecTags(spec), DeepEquals, []string{"smoke"})
+}
+
+func (s *MySuite) TestMetadataKeysAreNotMatchedAsTags(c *C) {
+	scenario := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "First Scenario"},
+		Span:    &gauge.Span{Start: 1, End: 3},
+	}
+	spec := &gauge.Specification{
+		Items:     []gauge.Item{scenario},
+		Scenarios: []*gauge.Scenario{scenario},
+		Metadata:  map[string]string{"owner": "payments"},
+	}
+
+	filteredSpecs, _ := filterSpecsByTags([]*gauge.Specification{spec}, "owner | payments")
+
+	c.Assert(len(filteredSpecs), Equals, 0)
+}
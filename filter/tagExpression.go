@@ -0,0 +1,466 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tagTokenKind int
+
+const (
+	tagToken tagTokenKind = iota
+	andToken
+	orToken
+	notToken
+	leftParenToken
+	rightParenToken
+	equalsToken
+	notEqualsToken
+	stringToken
+	commaToken
+	endToken
+)
+
+type tagExpToken struct {
+	kind  tagTokenKind
+	value string
+	pos   int
+}
+
+func (t tagExpToken) String() string {
+	switch t.kind {
+	case endToken:
+		return "end of expression"
+	case tagToken:
+		return fmt.Sprintf("tag '%s'", t.value)
+	case stringToken:
+		return fmt.Sprintf("string \"%s\"", t.value)
+	}
+	return fmt.Sprintf("'%s'", t.value)
+}
+
+// TagExpressionError describes an invalid tag expression along with the position (1-based, in characters)
+// at which the problem was found.
+type TagExpressionError struct {
+	Expression string
+	Position   int
+	Message    string
+}
+
+func (e *TagExpressionError) Error() string {
+	return fmt.Sprintf("Invalid tag expression: %s at position %d\n  %s\n  %s^", e.Message, e.Position, e.Expression, strings.Repeat(" ", e.Position-1))
+}
+
+var tagExpKeywords = map[string]tagTokenKind{
+	"and": andToken,
+	"or":  orToken,
+	"not": notToken,
+}
+
+func isTagExpOperator(r rune, conditions bool) bool {
+	if conditions && (r == '=' || r == '\'') {
+		return true
+	}
+	return r == '&' || r == '|' || r == ',' || r == '!' || r == '(' || r == ')' || r == '"'
+}
+
+// tokenizeTagExpression splits a tag expression into tokens. Consecutive words which are not operators
+// or keywords make up a single tag, so `foo bar & baz` refers to the tags `foo bar` and `baz`. A backslash
+// escapes the next character, so `\not` and `a\&b` are tags, as are quoted tags like `"not"`.
+func tokenizeTagExpression(exp string) ([]tagExpToken, error) {
+	return tokenizeExpression(exp, false)
+}
+
+// tokenizeExpression tokenizes a tag expression, or a condition if conditions is set. Conditions also have the
+// comparisons '==' and '!=', single or double quoted strings and ',' which ends the condition.
+func tokenizeExpression(exp string, conditions bool) ([]tagExpToken, error) {
+	runes := []rune(exp)
+	var tokens []tagExpToken
+	var words []string
+	wordsPos := 0
+	flushWords := func() {
+		if len(words) > 0 {
+			tokens = append(tokens, tagExpToken{kind: tagToken, value: strings.Join(words, " "), pos: wordsPos})
+			words = nil
+		}
+	}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || (conditions && r == '\''):
+			flushWords()
+			var value []rune
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				}
+				value = append(value, runes[end])
+				end++
+			}
+			if conditions {
+				if end == len(runes) {
+					return nil, &TagExpressionError{Expression: exp, Position: i + 1, Message: "unterminated string"}
+				}
+				tokens = append(tokens, tagExpToken{kind: stringToken, value: string(value), pos: i + 1})
+				i = end + 1
+				continue
+			}
+			if end == len(runes) {
+				return nil, &TagExpressionError{Expression: exp, Position: i + 1, Message: "unterminated quoted tag"}
+			}
+			if len(value) == 0 {
+				return nil, &TagExpressionError{Expression: exp, Position: i + 1, Message: "empty quoted tag"}
+			}
+			tokens = append(tokens, tagExpToken{kind: tagToken, value: string(value), pos: i + 1})
+			i = end + 1
+		case conditions && (r == '=' || r == '!') && i+1 < len(runes) && runes[i+1] == '=':
+			flushWords()
+			kind := equalsToken
+			if r == '!' {
+				kind = notEqualsToken
+			}
+			tokens = append(tokens, tagExpToken{kind: kind, value: string(runes[i : i+2]), pos: i + 1})
+			i += 2
+		case conditions && r == '=':
+			return nil, &TagExpressionError{Expression: exp, Position: i + 1, Message: "expected '==' or '!='"}
+		case conditions && r == ',':
+			flushWords()
+			tokens = append(tokens, tagExpToken{kind: commaToken, value: ",", pos: i + 1})
+			i++
+		case r == '&' || r == '|':
+			flushWords()
+			kind := andToken
+			if r == '|' {
+				kind = orToken
+			}
+			value := string(r)
+			if i+1 < len(runes) && runes[i+1] == r {
+				value += string(r)
+			}
+			tokens = append(tokens, tagExpToken{kind: kind, value: value, pos: i + 1})
+			i += len(value)
+		case r == ',':
+			flushWords()
+			tokens = append(tokens, tagExpToken{kind: andToken, value: ",", pos: i + 1})
+			i++
+		case r == '!':
+			flushWords()
+			tokens = append(tokens, tagExpToken{kind: notToken, value: "!", pos: i + 1})
+			i++
+		case r == '(' || r == ')':
+			flushWords()
+			kind := leftParenToken
+			if r == ')' {
+				kind = rightParenToken
+			}
+			tokens = append(tokens, tagExpToken{kind: kind, value: string(r), pos: i + 1})
+			i++
+		default:
+			start := i
+			var word []rune
+			escaped := false
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !isTagExpOperator(runes[i], conditions) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					escaped = true
+				}
+				word = append(word, runes[i])
+				i++
+			}
+			if kind, ok := tagExpKeywords[strings.ToLower(string(word))]; ok && !escaped {
+				flushWords()
+				tokens = append(tokens, tagExpToken{kind: kind, value: string(word), pos: start + 1})
+				continue
+			}
+			if len(words) == 0 {
+				wordsPos = start + 1
+			}
+			words = append(words, string(word))
+		}
+	}
+	flushWords()
+	return append(tokens, tagExpToken{kind: endToken, pos: len(runes) + 1}), nil
+}
+
+// operands are what the leaves of an expression are evaluated against: the tags of a scenario for tag expressions,
+// or the env properties for conditions.
+type operands interface {
+	has(name string) bool
+	value(name string) string
+}
+
+// tagSet holds normalized tags. A name with a '*' is a wildcard, and is present if any of the tags matches it.
+type tagSet map[string]bool
+
+func (tags tagSet) has(name string) bool {
+	if !strings.Contains(name, "*") {
+		return tags[name]
+	}
+	for tag := range tags {
+		if matchesWildcard(name, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tags tagSet) value(name string) string {
+	return ""
+}
+
+// taggedItem holds the tags of a scenario along with the front matter metadata of its spec. A `key=value` name is
+// present if it is a tag, or if the metadata has the entry.
+type taggedItem struct {
+	tags     tagSet
+	metadata tagSet
+}
+
+func (item taggedItem) has(name string) bool {
+	return item.tags.has(name) || (strings.Contains(name, "=") && item.metadata.has(name))
+}
+
+func (item taggedItem) value(name string) string {
+	return ""
+}
+
+type tagExpNode interface {
+	eval(o operands) bool
+}
+
+type tagNode struct {
+	name string
+}
+
+type notNode struct {
+	operand tagExpNode
+}
+
+type andNode struct {
+	left, right tagExpNode
+}
+
+type orNode struct {
+	left, right tagExpNode
+}
+
+type comparisonNode struct {
+	name, value string
+	negate      bool
+}
+
+func (n *tagNode) eval(o operands) bool {
+	return o.has(n.name)
+}
+
+func (n *notNode) eval(o operands) bool {
+	return !n.operand.eval(o)
+}
+
+func (n *andNode) eval(o operands) bool {
+	return n.left.eval(o) && n.right.eval(o)
+}
+
+func (n *orNode) eval(o operands) bool {
+	return n.left.eval(o) || n.right.eval(o)
+}
+
+func (n *comparisonNode) eval(o operands) bool {
+	return (o.value(n.name) == n.value) != n.negate
+}
+
+// matchesWildcard reports whether the tag matches the pattern, where each '*' in the pattern matches
+// any sequence of characters.
+func matchesWildcard(pattern, tag string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(tag, parts[0]) {
+		return false
+	}
+	tag = tag[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(tag, part)
+		}
+		idx := strings.Index(tag, part)
+		if idx < 0 {
+			return false
+		}
+		tag = tag[idx+len(part):]
+	}
+	return tag == ""
+}
+
+// TagExpression is a parsed tag expression which can be evaluated against the tags of a scenario. The words
+// and, or and not (in any case) are keywords, so tags with those names have to be quoted or escaped.
+//
+// Grammar:
+//
+//	expression := term { ('|' | '||' | 'or') term }
+//	term       := factor { ('&' | '&&' | ',' | 'and') factor }
+//	factor     := ('!' | 'not') factor | '(' expression ')' | tag
+type TagExpression struct {
+	raw      string
+	root     tagExpNode
+	keywords map[string]bool
+}
+
+type tagExpParser struct {
+	exp        string
+	tokens     []tagExpToken
+	pos        int
+	conditions bool
+}
+
+// ParseTagExpression parses the given tag expression. The returned error is a *TagExpressionError
+// pointing at the offending position.
+func ParseTagExpression(exp string) (*TagExpression, error) {
+	tokens, err := tokenizeTagExpression(exp)
+	if err != nil {
+		return nil, err
+	}
+	p := &tagExpParser{exp: exp, tokens: tokens}
+	if p.peek().kind == endToken {
+		return nil, p.errorf(p.peek(), "empty expression")
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != endToken {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	keywords := make(map[string]bool)
+	for _, t := range tokens {
+		if _, ok := tagExpKeywords[strings.ToLower(t.value)]; ok && t.kind != tagToken {
+			keywords[strings.ToLower(t.value)] = true
+		}
+	}
+	return &TagExpression{raw: exp, root: root, keywords: keywords}, nil
+}
+
+func (e *TagExpression) String() string {
+	return e.raw
+}
+
+// Evaluate reports whether the given tags satisfy the expression.
+func (e *TagExpression) Evaluate(tags []string) bool {
+	return e.EvaluateWithMetadata(tags, nil)
+}
+
+// EvaluateWithMetadata reports whether the given tags and spec metadata satisfy the expression. A `key=value` term
+// like `owner=payments` matches a metadata entry, with '*' as a wildcard in the value.
+func (e *TagExpression) EvaluateWithMetadata(tags []string, metadata map[string]string) bool {
+	tagsMap := make(tagSet, len(tags))
+	for _, tag := range tags {
+		tagsMap[normalizeTag(tag)] = true
+	}
+	metadataMap := make(tagSet, len(metadata))
+	for key, value := range metadata {
+		metadataMap[normalizeTag(key+"="+value)] = true
+	}
+	return e.root.eval(taggedItem{tags: tagsMap, metadata: metadataMap})
+}
+
+// UsesKeyword reports whether the tag is a word which the expression uses as a keyword, and so cannot refer to
+// the tag.
+func (e *TagExpression) UsesKeyword(tag string) bool {
+	return e.keywords[strings.ToLower(tag)]
+}
+
+func normalizeTag(tag string) string {
+	return strings.Join(strings.Fields(tag), "")
+}
+
+func (p *tagExpParser) peek() tagExpToken {
+	return p.tokens[p.pos]
+}
+
+func (p *tagExpParser) next() tagExpToken {
+	t := p.tokens[p.pos]
+	if t.kind != endToken {
+		p.pos++
+	}
+	return t
+}
+
+func (p *tagExpParser) errorf(t tagExpToken, format string, args ...interface{}) error {
+	return &TagExpressionError{Expression: p.exp, Position: t.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *tagExpParser) parseOr() (tagExpNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == orToken {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *tagExpParser) parseAnd() (tagExpNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == andToken {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *tagExpParser) parseNot() (tagExpNode, error) {
+	if p.peek().kind == notToken {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *tagExpParser) parsePrimary() (tagExpNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tagToken:
+		if p.conditions {
+			return p.parseComparison(t)
+		}
+		return &tagNode{name: normalizeTag(t.value)}, nil
+	case leftParenToken:
+		exp, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != rightParenToken {
+			return nil, p.errorf(closing, "expected ')' to close '(' at position %d, found %s", t.pos, closing)
+		}
+		p.next()
+		return exp, nil
+	}
+	if p.conditions {
+		return nil, p.errorf(t, "expected an env property, '!' or '(', found %s", t)
+	}
+	return nil, p.errorf(t, "expected a tag, '!' or '(', found %s", t)
+}
@@ -0,0 +1,122 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package filter
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestTagExpressionWithSpecialCharactersInTags(c *C) {
+	exp, err := ParseTagExpression("browser:chrome & feature-1.2 & !wip")
+	c.Assert(err, IsNil)
+	c.Assert(exp.Evaluate([]string{"browser:chrome", "feature-1.2"}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"browser:chrome", "feature-1.2", "wip"}), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionWithUnicodeTags(c *C) {
+	exp, err := ParseTagExpression("テスト | überprüfung")
+	c.Assert(err, IsNil)
+	c.Assert(exp.Evaluate([]string{"überprüfung"}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"other"}), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionWithKeywords(c *C) {
+	exp, err := ParseTagExpression("smoke and not (slow or flaky)")
+	c.Assert(err, IsNil)
+	c.Assert(exp.Evaluate([]string{"smoke"}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"smoke", "flaky"}), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionWithQuotedTags(c *C) {
+	exp, err := ParseTagExpression(`"and" & "a|b"`)
+	c.Assert(err, IsNil)
+	c.Assert(exp.Evaluate([]string{"and", "a|b"}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"and"}), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionWithEscapedTags(c *C) {
+	exp, err := ParseTagExpression(`\not & a\&b & "say \"hi\""`)
+	c.Assert(err, IsNil)
+	c.Assert(exp.Evaluate([]string{"not", "a&b", `say "hi"`}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"a&b", `say "hi"`}), Equals, false)
+	c.Assert(exp.UsesKeyword("not"), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionUsesKeyword(c *C) {
+	exp, err := ParseTagExpression("smoke AND not wip")
+	c.Assert(err, IsNil)
+	c.Assert(exp.UsesKeyword("and"), Equals, true)
+	c.Assert(exp.UsesKeyword("Not"), Equals, true)
+	c.Assert(exp.UsesKeyword("or"), Equals, false)
+	c.Assert(exp.UsesKeyword("smoke"), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionWithWildcard(c *C) {
+	exp, err := ParseTagExpression("smoke* & !*slow")
+	c.Assert(err, IsNil)
+	c.Assert(exp.Evaluate([]string{"smoke-login"}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"smoke"}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"smoke-login", "very-slow"}), Equals, false)
+	c.Assert(exp.Evaluate([]string{"regression"}), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionWithMetadata(c *C) {
+	exp, err := ParseTagExpression("owner=pay* & !slow")
+	c.Assert(err, IsNil)
+	c.Assert(exp.EvaluateWithMetadata([]string{"smoke"}, map[string]string{"owner": "payments"}), Equals, true)
+	c.Assert(exp.EvaluateWithMetadata([]string{"slow"}, map[string]string{"owner": "payments"}), Equals, false)
+	c.Assert(exp.EvaluateWithMetadata([]string{"smoke"}, map[string]string{"owner": "billing"}), Equals, false)
+	c.Assert(exp.Evaluate([]string{"smoke"}), Equals, false)
+}
+
+func (s *MySuite) TestMatchesWildcard(c *C) {
+	c.Assert(matchesWildcard("a*b*c", "axxbyyc"), Equals, true)
+	c.Assert(matchesWildcard("a*b*c", "axxbyy"), Equals, false)
+	c.Assert(matchesWildcard("*", "anything"), Equals, true)
+	c.Assert(matchesWildcard("ab*ba", "aba"), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionAndBindsTighterThanOr(c *C) {
+	exp, err := ParseTagExpression("a | b & c")
+	c.Assert(err, IsNil)
+	c.Assert(exp.Evaluate([]string{"a"}), Equals, true)
+	c.Assert(exp.Evaluate([]string{"b"}), Equals, false)
+}
+
+func (s *MySuite) TestTagExpressionErrorForUnbalancedBracket(c *C) {
+	_, err := ParseTagExpression("(tag1 & tag2")
+	c.Assert(err, NotNil)
+	tagErr, ok := err.(*TagExpressionError)
+	c.Assert(ok, Equals, true)
+	c.Assert(tagErr.Position, Equals, 13)
+	c.Assert(err.Error(), Equals, "Invalid tag expression: expected ')' to close '(' at position 1, found end of expression at position 13\n  (tag1 & tag2\n              ^")
+}
+
+func (s *MySuite) TestTagExpressionErrorForMissingOperand(c *C) {
+	_, err := ParseTagExpression("tag1 & | tag2")
+	c.Assert(err, NotNil)
+	c.Assert(err.(*TagExpressionError).Position, Equals, 8)
+	c.Assert(err.(*TagExpressionError).Message, Equals, "expected a tag, '!' or '(', found '|'")
+}
+
+func (s *MySuite) TestTagExpressionErrorForUnexpectedClosingBracket(c *C) {
+	_, err := ParseTagExpression("tag1 ) tag2")
+	c.Assert(err, NotNil)
+	c.Assert(err.(*TagExpressionError).Position, Equals, 6)
+}
+
+func (s *MySuite) TestTagExpressionErrorForUnterminatedQuote(c *C) {
+	_, err := ParseTagExpression(`tag1 & "tag2`)
+	c.Assert(err, NotNil)
+	c.Assert(err.(*TagExpressionError).Message, Equals, "unterminated quoted tag")
+	c.Assert(err.(*TagExpressionError).Position, Equals, 8)
+}
+
+func (s *MySuite) TestTagExpressionErrorForEmptyExpression(c *C) {
+	_, err := ParseTagExpression("   ")
+	c.Assert(err, NotNil)
+}
@@ -56,6 +56,10 @@ func (formatter *formatter) TearDown(t *gauge.TearDown) {
 	formatter.buffer.WriteString(t.Value + "\n")
 }
 
+func (formatter *formatter) FrontMatter(f *gauge.FrontMatter) {
+	formatter.buffer.WriteString(strings.Join(f.Lines, "\n") + "\n")
+}
+
 func (formatter *formatter) Scenario(scenario *gauge.Scenario) {
 }
 
@@ -12,10 +12,12 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/util"
@@ -181,8 +183,9 @@ func FormatTags(tags *gauge.Tags) string {
 	if tags == nil || len(tags.RawValues) == 0 {
 		return ""
 	}
+	prefix := i18n.CurrentKeywords().Tags + ": "
 	var b bytes.Buffer
-	b.WriteString("tags: ")
+	b.WriteString(prefix)
 	for i, tag := range tags.RawValues {
 		for j, tagString := range tag {
 			b.WriteString(tagString)
@@ -192,7 +195,7 @@ func FormatTags(tags *gauge.Tags) string {
 		}
 		b.WriteString("\n")
 		if i != len(tags.RawValues)-1 {
-			b.WriteString("      ")
+			b.WriteString(strings.Repeat(" ", utf8.RuneCountInString(prefix)))
 		}
 	}
 	return b.String()
@@ -203,7 +206,8 @@ func formatExternalDataTable(dataTable *gauge.DataTable) string {
 		return ""
 	}
 	var b bytes.Buffer
-	b.WriteString(dataTable.Value)
+	b.WriteString(i18n.CurrentKeywords().Table + ": ")
+	b.WriteString(strings.TrimSpace(strings.TrimPrefix(dataTable.Value, "table:")))
 	b.WriteString("\n")
 	return b.String()
 }
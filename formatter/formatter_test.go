@@ -495,3 +495,20 @@ func (s *MySuite) TestFormatShouldNotAddExtraNewLinesBeforeDataTable(c *C) {
    |Rhythm|0          |
 `)
 }
+
+func (s *MySuite) TestFormatSpecificationShouldPreserveFrontMatter(c *C) {
+	specText := `---
+owner: payments
+priority: high
+---
+# Spec Heading
+## Scenario Heading
+* Example step
+`
+	spec, result := new(parser.SpecParser).ParseSpecText(specText, "")
+	c.Assert(result.Ok, Equals, true)
+
+	formatted := FormatSpecification(spec)
+
+	c.Assert(formatted, Equals, specText)
+}
@@ -17,3 +17,9 @@ type ItemProcessor interface {
 	TearDown(*TearDown)
 	Comment(*Comment)
 }
+
+// FrontMatterProcessor is implemented by item processors which also process the front matter of a spec. It is
+// separate from ItemProcessor so that existing processors don't have to change.
+type FrontMatterProcessor interface {
+	FrontMatter(*FrontMatter)
+}
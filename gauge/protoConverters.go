@@ -7,6 +7,7 @@
 package gauge
 
 import (
+	"strings"
 	"time"
 
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
@@ -28,6 +29,9 @@ func ConvertToProtoItem(item Item) *gauge_messages.ProtoItem {
 	case TearDownKind:
 		teardown := item.(*TearDown)
 		return convertToProtoCommentItem(&Comment{LineNo: teardown.LineNo, Value: teardown.Value})
+	case FrontMatterKind:
+		frontMatter := item.(*FrontMatter)
+		return convertToProtoCommentItem(&Comment{LineNo: frontMatter.LineNo, Value: strings.Join(frontMatter.Lines, "\n")})
 	}
 	return nil
 }
@@ -276,6 +280,7 @@ func NewSpecResult(specification *Specification) *result.SpecResult {
 	return &result.SpecResult{
 		ProtoSpec:           newProtoSpec(specification),
 		FailedDataTableRows: make([]int32, 0),
+		Metadata:            specification.Metadata,
 	}
 }
 
@@ -22,6 +22,7 @@ type Scenario struct {
 	ScenarioDataTableRow      Table
 	ScenarioDataTableRowIndex int
 	Span                      *Span
+	SkipDirectives            []*SkipDirective
 }
 
 // Span represents scope of Scenario based on line number
@@ -65,6 +66,20 @@ func (scenario *Scenario) AddComment(comment *Comment) {
 	scenario.AddItem(comment)
 }
 
+func (scenario *Scenario) AddSkipDirective(directive *SkipDirective) {
+	scenario.SkipDirectives = append(scenario.SkipDirectives, directive)
+}
+
+// SkipReason returns the reason of the first skip directive of the scenario which applies.
+func (scenario *Scenario) SkipReason(getenv func(string) string) (string, bool) {
+	for _, d := range scenario.SkipDirectives {
+		if reason, ok := d.ShouldSkip(getenv); ok {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
 func (scenario *Scenario) AddDataTable(table *Table) {
 	scenario.DataTable.Table = table
 	scenario.AddItem(&scenario.DataTable)
@@ -0,0 +1,38 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package gauge
+
+import "fmt"
+
+// SkipCondition is the condition of a `@skip-if(...)` directive. It is evaluated against the environment
+// properties, which are looked up with getenv.
+type SkipCondition interface {
+	Evaluate(getenv func(string) string) bool
+	String() string
+}
+
+// SkipDirective is a `@skip("reason")` or `@skip-if(condition)` line of a scenario. A directive without
+// a condition always skips the scenario.
+type SkipDirective struct {
+	LineNo    int
+	Reason    string
+	Condition SkipCondition
+}
+
+// ShouldSkip reports whether the directive skips the scenario and the reason for skipping it.
+func (d *SkipDirective) ShouldSkip(getenv func(string) string) (string, bool) {
+	if d.Condition != nil && !d.Condition.Evaluate(getenv) {
+		return "", false
+	}
+	if d.Reason != "" {
+		return d.Reason, true
+	}
+	if d.Condition != nil {
+		return fmt.Sprintf("%s is true", d.Condition.String()), true
+	}
+	return "Marked with @skip", true
+}
@@ -0,0 +1,839 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package gauge
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge-proto/go/gauge_messages"
+)
+
+// SpecSnapshot is the serialisable form of a parsed specification, see MarshalBinary.
+//
+// A specification is a graph rather than a tree: the items of a spec or scenario refer to the same scenarios, steps,
+// comments and tags as their fields, the steps of concepts point back to their parent and lookups share the args of
+// steps. A snapshot stores each node once, in a list per type, and every pointer as the index of the node it points
+// to. Indices start at 1, so that 0 is nil. Reading the snapshot back gives a graph with the same sharing and cycles.
+type SpecSnapshot struct {
+	Nodes snapshotNodes
+	Spec  specNode
+}
+
+// ConceptDictionarySnapshot is the serialisable form of a concept dictionary. See SpecSnapshot.
+type ConceptDictionarySnapshot struct {
+	Nodes           snapshotNodes
+	Concepts        map[string]conceptNode
+	ConstructionMap map[string][]int
+}
+
+type snapshotNodes struct {
+	Files        []string
+	Steps        []stepNode
+	Args         []argNode
+	Scenarios    []scenarioNode
+	Headings     []Heading
+	Comments     []Comment
+	Tags         []Tags
+	Tables       []tableNode
+	DataTables   []dataTableNode
+	TearDowns    []TearDown
+	FrontMatters []FrontMatter
+}
+
+type specNode struct {
+	Heading       int
+	Scenarios     []int
+	Comments      []int
+	DataTable     dataTableNode
+	Contexts      []int
+	FileName      string
+	Tags          int
+	Items         []itemNode
+	TearDownSteps []int
+	Metadata      map[string]string
+}
+
+type scenarioNode struct {
+	Heading                   int
+	Steps                     []int
+	Comments                  []int
+	Tags                      int
+	Items                     []itemNode
+	DataTable                 dataTableNode
+	SpecDataTableRow          tableNode
+	SpecDataTableRowIndex     int
+	ScenarioDataTableRow      tableNode
+	ScenarioDataTableRowIndex int
+	Span                      *Span
+	SkipDirectives            []*SkipDirective
+}
+
+// stepNode refers to the file of the step by its index in the files of the snapshot, as a spec holds many steps of a
+// few files.
+type stepNode struct {
+	LineNo         int
+	File           int
+	Value          string
+	LineText       string
+	Args           []int
+	IsConcept      bool
+	Lookup         *lookupNode
+	ConceptSteps   []int
+	Fragments      []*gauge_messages.Fragment
+	Parent         int
+	HasInlineTable bool
+	Items          []itemNode
+	PreComments    []int
+	Suffix         string
+	LineSpanEnd    int
+}
+
+type lookupNode struct {
+	ParamIndexMap map[string]int
+	Params        []paramNode
+}
+
+type paramNode struct {
+	Name string
+	Arg  int
+}
+
+type argNode struct {
+	Name    string
+	Value   string
+	ArgType ArgType
+	Table   *tableNode
+}
+
+type tableNode struct {
+	HeaderIndexMap map[string]int
+	Columns        [][]TableCell
+	Headers        []string
+	LineNo         int
+}
+
+type dataTableNode struct {
+	Table      int
+	Value      string
+	LineNo     int
+	IsExternal bool
+}
+
+type conceptNode struct {
+	ConceptStep int
+	FileName    string
+}
+
+// itemNode is an item of a spec, scenario or concept, referred to by the type and index of its node. The data table
+// of the spec or scenario itself is an item too, which is kept pointing to its field.
+type itemNode struct {
+	Type  string
+	Index int
+}
+
+const (
+	headingItem       = "heading"
+	commentItem       = "comment"
+	tagsItem          = "tags"
+	scenarioItem      = "scenario"
+	scenarioValueItem = "scenarioValue"
+	stepItem          = "step"
+	stepValueItem     = "stepValue"
+	tableItem         = "table"
+	dataTableItem     = "dataTable"
+	ownDataTableItem  = "ownDataTable"
+	tearDownItem      = "tearDown"
+	frontMatterItem   = "frontMatter"
+)
+
+// NewSpecSnapshot flattens the specification. Specs with skip conditions cannot be flattened, as the conditions are
+// expressions built by the parser.
+func NewSpecSnapshot(spec *Specification) (*SpecSnapshot, error) {
+	e := newSnapshotEncoder()
+	s := &SpecSnapshot{Spec: specNode{
+		Heading:       e.heading(spec.Heading),
+		Scenarios:     e.scenarioRefs(spec.Scenarios),
+		Comments:      e.commentRefs(spec.Comments),
+		DataTable:     e.dataTableNode(spec.DataTable),
+		Contexts:      e.stepRefs(spec.Contexts),
+		FileName:      spec.FileName,
+		Tags:          e.tagsRef(spec.Tags),
+		Items:         e.items(spec.Items, &spec.DataTable),
+		TearDownSteps: e.stepRefs(spec.TearDownSteps),
+		Metadata:      spec.Metadata,
+	}}
+	if e.err != nil {
+		return nil, e.err
+	}
+	s.Nodes = e.nodes
+	return s, nil
+}
+
+// Specification builds the specification back from the snapshot.
+func (s *SpecSnapshot) Specification() *Specification {
+	d := newSnapshotDecoder(&s.Nodes)
+	spec := &Specification{
+		Heading:       d.heading(s.Spec.Heading),
+		Scenarios:     d.scenarioList(s.Spec.Scenarios),
+		Comments:      d.commentList(s.Spec.Comments),
+		DataTable:     d.dataTable(s.Spec.DataTable),
+		Contexts:      d.stepList(s.Spec.Contexts),
+		FileName:      s.Spec.FileName,
+		Tags:          d.tag(s.Spec.Tags),
+		TearDownSteps: d.stepList(s.Spec.TearDownSteps),
+		Metadata:      s.Spec.Metadata,
+	}
+	spec.Items = d.items(s.Spec.Items, &spec.DataTable)
+	return spec
+}
+
+// NewConceptDictionarySnapshot flattens the concept dictionary.
+func NewConceptDictionarySnapshot(dict *ConceptDictionary) (*ConceptDictionarySnapshot, error) {
+	e := newSnapshotEncoder()
+	s := &ConceptDictionarySnapshot{Concepts: make(map[string]conceptNode), ConstructionMap: make(map[string][]int)}
+	for value, concept := range dict.ConceptsMap {
+		s.Concepts[value] = conceptNode{ConceptStep: e.step(concept.ConceptStep), FileName: concept.FileName}
+	}
+	for value, steps := range dict.constructionMap {
+		s.ConstructionMap[value] = e.stepRefs(steps)
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	s.Nodes = e.nodes
+	return s, nil
+}
+
+// ConceptDictionary builds the concept dictionary back from the snapshot.
+func (s *ConceptDictionarySnapshot) ConceptDictionary() *ConceptDictionary {
+	d := newSnapshotDecoder(&s.Nodes)
+	dict := NewConceptDictionary()
+	for value, c := range s.Concepts {
+		dict.ConceptsMap[value] = &Concept{ConceptStep: d.step(c.ConceptStep), FileName: c.FileName}
+	}
+	for value, refs := range s.ConstructionMap {
+		dict.constructionMap[value] = d.stepList(refs)
+	}
+	return dict
+}
+
+// snapshotEncoder adds each node it is given to the lists of nodes once, keeping the index of every pointer. A node
+// gets its index before its fields are encoded, so that cycles like the items of a concept end.
+type snapshotEncoder struct {
+	nodes        snapshotNodes
+	steps        map[*Step]int
+	args         map[*StepArg]int
+	scenarios    map[*Scenario]int
+	headings     map[*Heading]int
+	comments     map[*Comment]int
+	tags         map[*Tags]int
+	tables       map[*Table]int
+	dataTables   map[*DataTable]int
+	tearDowns    map[*TearDown]int
+	frontMatters map[*FrontMatter]int
+	files        map[string]int
+	err          error
+}
+
+func newSnapshotEncoder() *snapshotEncoder {
+	return &snapshotEncoder{
+		steps:        make(map[*Step]int),
+		args:         make(map[*StepArg]int),
+		scenarios:    make(map[*Scenario]int),
+		headings:     make(map[*Heading]int),
+		comments:     make(map[*Comment]int),
+		tags:         make(map[*Tags]int),
+		tables:       make(map[*Table]int),
+		dataTables:   make(map[*DataTable]int),
+		tearDowns:    make(map[*TearDown]int),
+		frontMatters: make(map[*FrontMatter]int),
+		files:        make(map[string]int),
+	}
+}
+
+func (e *snapshotEncoder) file(name string) int {
+	if name == "" {
+		return 0
+	}
+	if i, ok := e.files[name]; ok {
+		return i
+	}
+	e.nodes.Files = append(e.nodes.Files, name)
+	e.files[name] = len(e.nodes.Files)
+	return len(e.nodes.Files)
+}
+
+func (e *snapshotEncoder) step(step *Step) int {
+	if step == nil {
+		return 0
+	}
+	if i, ok := e.steps[step]; ok {
+		return i
+	}
+	e.nodes.Steps = append(e.nodes.Steps, stepNode{})
+	i := len(e.nodes.Steps)
+	e.steps[step] = i
+	n := stepNode{
+		LineNo:         step.LineNo,
+		File:           e.file(step.FileName),
+		Value:          step.Value,
+		LineText:       step.LineText,
+		Args:           e.argRefs(step.Args),
+		IsConcept:      step.IsConcept,
+		Lookup:         e.lookup(step.Lookup),
+		ConceptSteps:   e.stepRefs(step.ConceptSteps),
+		Fragments:      step.Fragments,
+		Parent:         e.step(step.Parent),
+		HasInlineTable: step.HasInlineTable,
+		Items:          e.items(step.Items, nil),
+		PreComments:    e.commentRefs(step.PreComments),
+		Suffix:         step.Suffix,
+		LineSpanEnd:    step.LineSpanEnd,
+	}
+	e.nodes.Steps[i-1] = n
+	return i
+}
+
+func (e *snapshotEncoder) stepRefs(steps []*Step) []int {
+	if steps == nil {
+		return nil
+	}
+	refs := make([]int, len(steps))
+	for i, s := range steps {
+		refs[i] = e.step(s)
+	}
+	return refs
+}
+
+func (e *snapshotEncoder) arg(arg *StepArg) int {
+	if arg == nil {
+		return 0
+	}
+	if i, ok := e.args[arg]; ok {
+		return i
+	}
+	n := argNode{Name: arg.Name, Value: arg.Value, ArgType: arg.ArgType}
+	if arg.Table.headerIndexMap != nil || arg.Table.Columns != nil || arg.Table.Headers != nil || arg.Table.LineNo != 0 {
+		t := e.tableNode(arg.Table)
+		n.Table = &t
+	}
+	e.nodes.Args = append(e.nodes.Args, n)
+	i := len(e.nodes.Args)
+	e.args[arg] = i
+	return i
+}
+
+func (e *snapshotEncoder) argRefs(args []*StepArg) []int {
+	if args == nil {
+		return nil
+	}
+	refs := make([]int, len(args))
+	for i, a := range args {
+		refs[i] = e.arg(a)
+	}
+	return refs
+}
+
+func (e *snapshotEncoder) lookup(lookup ArgLookup) *lookupNode {
+	if lookup.ParamIndexMap == nil && lookup.paramValue == nil {
+		return nil
+	}
+	n := &lookupNode{ParamIndexMap: lookup.ParamIndexMap}
+	if lookup.paramValue != nil {
+		n.Params = make([]paramNode, len(lookup.paramValue))
+		for i, p := range lookup.paramValue {
+			n.Params[i] = paramNode{Name: p.name, Arg: e.arg(p.stepArg)}
+		}
+	}
+	return n
+}
+
+func (e *snapshotEncoder) scenario(scenario *Scenario) int {
+	if scenario == nil {
+		return 0
+	}
+	if i, ok := e.scenarios[scenario]; ok {
+		return i
+	}
+	e.nodes.Scenarios = append(e.nodes.Scenarios, scenarioNode{})
+	i := len(e.nodes.Scenarios)
+	e.scenarios[scenario] = i
+	for _, d := range scenario.SkipDirectives {
+		if d.Condition != nil {
+			e.fail(fmt.Errorf("scenario %s has a skip condition", scenario.Heading.Value))
+		}
+	}
+	n := scenarioNode{
+		Heading:                   e.heading(scenario.Heading),
+		Steps:                     e.stepRefs(scenario.Steps),
+		Comments:                  e.commentRefs(scenario.Comments),
+		Tags:                      e.tagsRef(scenario.Tags),
+		Items:                     e.items(scenario.Items, &scenario.DataTable),
+		DataTable:                 e.dataTableNode(scenario.DataTable),
+		SpecDataTableRow:          e.tableNode(scenario.SpecDataTableRow),
+		SpecDataTableRowIndex:     scenario.SpecDataTableRowIndex,
+		ScenarioDataTableRow:      e.tableNode(scenario.ScenarioDataTableRow),
+		ScenarioDataTableRowIndex: scenario.ScenarioDataTableRowIndex,
+		Span:                      scenario.Span,
+		SkipDirectives:            scenario.SkipDirectives,
+	}
+	e.nodes.Scenarios[i-1] = n
+	return i
+}
+
+func (e *snapshotEncoder) scenarioRefs(scenarios []*Scenario) []int {
+	if scenarios == nil {
+		return nil
+	}
+	refs := make([]int, len(scenarios))
+	for i, s := range scenarios {
+		refs[i] = e.scenario(s)
+	}
+	return refs
+}
+
+func (e *snapshotEncoder) heading(heading *Heading) int {
+	if heading == nil {
+		return 0
+	}
+	if i, ok := e.headings[heading]; ok {
+		return i
+	}
+	e.nodes.Headings = append(e.nodes.Headings, *heading)
+	e.headings[heading] = len(e.nodes.Headings)
+	return len(e.nodes.Headings)
+}
+
+func (e *snapshotEncoder) comment(comment *Comment) int {
+	if comment == nil {
+		return 0
+	}
+	if i, ok := e.comments[comment]; ok {
+		return i
+	}
+	e.nodes.Comments = append(e.nodes.Comments, *comment)
+	e.comments[comment] = len(e.nodes.Comments)
+	return len(e.nodes.Comments)
+}
+
+func (e *snapshotEncoder) commentRefs(comments []*Comment) []int {
+	if comments == nil {
+		return nil
+	}
+	refs := make([]int, len(comments))
+	for i, c := range comments {
+		refs[i] = e.comment(c)
+	}
+	return refs
+}
+
+func (e *snapshotEncoder) tagsRef(tags *Tags) int {
+	if tags == nil {
+		return 0
+	}
+	if i, ok := e.tags[tags]; ok {
+		return i
+	}
+	e.nodes.Tags = append(e.nodes.Tags, *tags)
+	e.tags[tags] = len(e.nodes.Tags)
+	return len(e.nodes.Tags)
+}
+
+func (e *snapshotEncoder) table(table *Table) int {
+	if table == nil {
+		return 0
+	}
+	if i, ok := e.tables[table]; ok {
+		return i
+	}
+	e.nodes.Tables = append(e.nodes.Tables, e.tableNode(*table))
+	e.tables[table] = len(e.nodes.Tables)
+	return len(e.nodes.Tables)
+}
+
+func (e *snapshotEncoder) tableNode(table Table) tableNode {
+	return tableNode{HeaderIndexMap: table.headerIndexMap, Columns: table.Columns, Headers: table.Headers, LineNo: table.LineNo}
+}
+
+func (e *snapshotEncoder) dataTable(dataTable *DataTable) int {
+	if dataTable == nil {
+		return 0
+	}
+	if i, ok := e.dataTables[dataTable]; ok {
+		return i
+	}
+	e.nodes.DataTables = append(e.nodes.DataTables, e.dataTableNode(*dataTable))
+	e.dataTables[dataTable] = len(e.nodes.DataTables)
+	return len(e.nodes.DataTables)
+}
+
+func (e *snapshotEncoder) dataTableNode(dataTable DataTable) dataTableNode {
+	return dataTableNode{Table: e.table(dataTable.Table), Value: dataTable.Value, LineNo: dataTable.LineNo, IsExternal: dataTable.IsExternal}
+}
+
+func (e *snapshotEncoder) items(items []Item, own *DataTable) []itemNode {
+	if items == nil {
+		return nil
+	}
+	nodes := make([]itemNode, len(items))
+	for i, item := range items {
+		nodes[i] = e.item(item, own)
+	}
+	return nodes
+}
+
+func (e *snapshotEncoder) item(item Item, own *DataTable) itemNode {
+	switch v := item.(type) {
+	case *Heading:
+		return itemNode{Type: headingItem, Index: e.heading(v)}
+	case *Comment:
+		return itemNode{Type: commentItem, Index: e.comment(v)}
+	case *Tags:
+		return itemNode{Type: tagsItem, Index: e.tagsRef(v)}
+	case *Scenario:
+		return itemNode{Type: scenarioItem, Index: e.scenario(v)}
+	case Scenario:
+		return itemNode{Type: scenarioValueItem, Index: e.scenario(&v)}
+	case *Step:
+		return itemNode{Type: stepItem, Index: e.step(v)}
+	case Step:
+		return itemNode{Type: stepValueItem, Index: e.step(&v)}
+	case *Table:
+		return itemNode{Type: tableItem, Index: e.table(v)}
+	case *DataTable:
+		if v == own {
+			return itemNode{Type: ownDataTableItem}
+		}
+		return itemNode{Type: dataTableItem, Index: e.dataTable(v)}
+	case *TearDown:
+		if i, ok := e.tearDowns[v]; ok {
+			return itemNode{Type: tearDownItem, Index: i}
+		}
+		e.nodes.TearDowns = append(e.nodes.TearDowns, *v)
+		e.tearDowns[v] = len(e.nodes.TearDowns)
+		return itemNode{Type: tearDownItem, Index: len(e.nodes.TearDowns)}
+	case *FrontMatter:
+		if i, ok := e.frontMatters[v]; ok {
+			return itemNode{Type: frontMatterItem, Index: i}
+		}
+		e.nodes.FrontMatters = append(e.nodes.FrontMatters, *v)
+		e.frontMatters[v] = len(e.nodes.FrontMatters)
+		return itemNode{Type: frontMatterItem, Index: len(e.nodes.FrontMatters)}
+	}
+	e.fail(fmt.Errorf("unknown item %T", item))
+	return itemNode{}
+}
+
+func (e *snapshotEncoder) fail(err error) {
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// snapshotDecoder creates all the nodes of a snapshot up front, and fills in their fields the first time they are
+// referred to, so that every index gives the same pointer.
+type snapshotDecoder struct {
+	nodes        *snapshotNodes
+	steps        []*Step
+	args         []*StepArg
+	scenarios    []*Scenario
+	headings     []*Heading
+	comments     []*Comment
+	tags         []*Tags
+	tables       []*Table
+	dataTables   []*DataTable
+	tearDowns    []*TearDown
+	frontMatters []*FrontMatter
+	// stepsFilled, argsFilled and scenariosFilled tell which of the nodes with references to others are filled in.
+	stepsFilled     []bool
+	argsFilled      []bool
+	scenariosFilled []bool
+}
+
+// newSnapshotDecoder allocates the nodes of each type together, rather than one by one.
+func newSnapshotDecoder(nodes *snapshotNodes) *snapshotDecoder {
+	d := &snapshotDecoder{nodes: nodes, stepsFilled: make([]bool, len(nodes.Steps)), argsFilled: make([]bool, len(nodes.Args)), scenariosFilled: make([]bool, len(nodes.Scenarios))}
+	steps := make([]Step, len(nodes.Steps))
+	d.steps = make([]*Step, len(steps))
+	for i := range steps {
+		d.steps[i] = &steps[i]
+	}
+	args := make([]StepArg, len(nodes.Args))
+	d.args = make([]*StepArg, len(args))
+	for i := range args {
+		d.args[i] = &args[i]
+	}
+	scenarios := make([]Scenario, len(nodes.Scenarios))
+	d.scenarios = make([]*Scenario, len(scenarios))
+	for i := range scenarios {
+		d.scenarios[i] = &scenarios[i]
+	}
+	headings := append([]Heading(nil), nodes.Headings...)
+	d.headings = make([]*Heading, len(headings))
+	for i := range headings {
+		d.headings[i] = &headings[i]
+	}
+	comments := append([]Comment(nil), nodes.Comments...)
+	d.comments = make([]*Comment, len(comments))
+	for i := range comments {
+		d.comments[i] = &comments[i]
+	}
+	tags := append([]Tags(nil), nodes.Tags...)
+	d.tags = make([]*Tags, len(tags))
+	for i := range tags {
+		d.tags[i] = &tags[i]
+	}
+	tables := make([]Table, len(nodes.Tables))
+	d.tables = make([]*Table, len(tables))
+	for i := range tables {
+		tables[i] = d.tableValue(nodes.Tables[i])
+		d.tables[i] = &tables[i]
+	}
+	dataTables := make([]DataTable, len(nodes.DataTables))
+	d.dataTables = make([]*DataTable, len(dataTables))
+	for i := range dataTables {
+		dataTables[i] = d.dataTable(nodes.DataTables[i])
+		d.dataTables[i] = &dataTables[i]
+	}
+	tearDowns := append([]TearDown(nil), nodes.TearDowns...)
+	d.tearDowns = make([]*TearDown, len(tearDowns))
+	for i := range tearDowns {
+		d.tearDowns[i] = &tearDowns[i]
+	}
+	frontMatters := append([]FrontMatter(nil), nodes.FrontMatters...)
+	d.frontMatters = make([]*FrontMatter, len(frontMatters))
+	for i := range frontMatters {
+		d.frontMatters[i] = &frontMatters[i]
+	}
+	return d
+}
+
+func (d *snapshotDecoder) file(i int) string {
+	if !inRange(i, len(d.nodes.Files)) {
+		return ""
+	}
+	return d.nodes.Files[i-1]
+}
+
+func inRange(i, n int) bool {
+	return i > 0 && i <= n
+}
+
+func (d *snapshotDecoder) step(i int) *Step {
+	if !inRange(i, len(d.steps)) {
+		return nil
+	}
+	step := d.steps[i-1]
+	if d.stepsFilled[i-1] {
+		return step
+	}
+	d.stepsFilled[i-1] = true
+	n := d.nodes.Steps[i-1]
+	*step = Step{
+		LineNo:         n.LineNo,
+		FileName:       d.file(n.File),
+		Value:          n.Value,
+		LineText:       n.LineText,
+		Args:           d.argList(n.Args),
+		IsConcept:      n.IsConcept,
+		Lookup:         d.lookup(n.Lookup),
+		ConceptSteps:   d.stepList(n.ConceptSteps),
+		Fragments:      n.Fragments,
+		Parent:         d.step(n.Parent),
+		HasInlineTable: n.HasInlineTable,
+		PreComments:    d.commentList(n.PreComments),
+		Suffix:         n.Suffix,
+		LineSpanEnd:    n.LineSpanEnd,
+	}
+	step.Items = d.items(n.Items, nil)
+	return step
+}
+
+func (d *snapshotDecoder) stepList(refs []int) []*Step {
+	if refs == nil {
+		return nil
+	}
+	steps := make([]*Step, len(refs))
+	for i, r := range refs {
+		steps[i] = d.step(r)
+	}
+	return steps
+}
+
+func (d *snapshotDecoder) arg(i int) *StepArg {
+	if !inRange(i, len(d.args)) {
+		return nil
+	}
+	arg := d.args[i-1]
+	if !d.argsFilled[i-1] {
+		d.argsFilled[i-1] = true
+		n := d.nodes.Args[i-1]
+		*arg = StepArg{Name: n.Name, Value: n.Value, ArgType: n.ArgType}
+		if n.Table != nil {
+			arg.Table = d.tableValue(*n.Table)
+		}
+	}
+	return arg
+}
+
+func (d *snapshotDecoder) argList(refs []int) []*StepArg {
+	if refs == nil {
+		return nil
+	}
+	args := make([]*StepArg, len(refs))
+	for i, r := range refs {
+		args[i] = d.arg(r)
+	}
+	return args
+}
+
+func (d *snapshotDecoder) lookup(n *lookupNode) ArgLookup {
+	if n == nil {
+		return ArgLookup{}
+	}
+	lookup := ArgLookup{ParamIndexMap: n.ParamIndexMap}
+	if n.Params != nil {
+		lookup.paramValue = make([]paramNameValue, len(n.Params))
+		for i, p := range n.Params {
+			lookup.paramValue[i] = paramNameValue{name: p.Name, stepArg: d.arg(p.Arg)}
+		}
+	}
+	return lookup
+}
+
+func (d *snapshotDecoder) scenario(i int) *Scenario {
+	if !inRange(i, len(d.scenarios)) {
+		return nil
+	}
+	scenario := d.scenarios[i-1]
+	if d.scenariosFilled[i-1] {
+		return scenario
+	}
+	d.scenariosFilled[i-1] = true
+	n := d.nodes.Scenarios[i-1]
+	*scenario = Scenario{
+		Heading:                   d.heading(n.Heading),
+		Steps:                     d.stepList(n.Steps),
+		Comments:                  d.commentList(n.Comments),
+		Tags:                      d.tag(n.Tags),
+		DataTable:                 d.dataTable(n.DataTable),
+		SpecDataTableRow:          d.tableValue(n.SpecDataTableRow),
+		SpecDataTableRowIndex:     n.SpecDataTableRowIndex,
+		ScenarioDataTableRow:      d.tableValue(n.ScenarioDataTableRow),
+		ScenarioDataTableRowIndex: n.ScenarioDataTableRowIndex,
+		Span:                      n.Span,
+		SkipDirectives:            n.SkipDirectives,
+	}
+	scenario.Items = d.items(n.Items, &scenario.DataTable)
+	return scenario
+}
+
+func (d *snapshotDecoder) scenarioList(refs []int) []*Scenario {
+	if refs == nil {
+		return nil
+	}
+	scenarios := make([]*Scenario, len(refs))
+	for i, r := range refs {
+		scenarios[i] = d.scenario(r)
+	}
+	return scenarios
+}
+
+func (d *snapshotDecoder) heading(i int) *Heading {
+	if !inRange(i, len(d.headings)) {
+		return nil
+	}
+	return d.headings[i-1]
+}
+
+func (d *snapshotDecoder) commentList(refs []int) []*Comment {
+	if refs == nil {
+		return nil
+	}
+	comments := make([]*Comment, len(refs))
+	for i, r := range refs {
+		if inRange(r, len(d.comments)) {
+			comments[i] = d.comments[r-1]
+		}
+	}
+	return comments
+}
+
+func (d *snapshotDecoder) tag(i int) *Tags {
+	if !inRange(i, len(d.tags)) {
+		return nil
+	}
+	return d.tags[i-1]
+}
+
+func (d *snapshotDecoder) table(i int) *Table {
+	if !inRange(i, len(d.tables)) {
+		return nil
+	}
+	return d.tables[i-1]
+}
+
+func (d *snapshotDecoder) tableValue(n tableNode) Table {
+	return Table{headerIndexMap: n.HeaderIndexMap, Columns: n.Columns, Headers: n.Headers, LineNo: n.LineNo}
+}
+
+func (d *snapshotDecoder) dataTable(n dataTableNode) DataTable {
+	return DataTable{Table: d.table(n.Table), Value: n.Value, LineNo: n.LineNo, IsExternal: n.IsExternal}
+}
+
+func (d *snapshotDecoder) items(nodes []itemNode, own *DataTable) []Item {
+	if nodes == nil {
+		return nil
+	}
+	items := make([]Item, len(nodes))
+	for i, n := range nodes {
+		items[i] = d.item(n, own)
+	}
+	return items
+}
+
+func (d *snapshotDecoder) item(n itemNode, own *DataTable) Item {
+	switch n.Type {
+	case headingItem:
+		return d.heading(n.Index)
+	case commentItem:
+		if inRange(n.Index, len(d.comments)) {
+			return d.comments[n.Index-1]
+		}
+	case tagsItem:
+		return d.tag(n.Index)
+	case scenarioItem:
+		return d.scenario(n.Index)
+	case scenarioValueItem:
+		if s := d.scenario(n.Index); s != nil {
+			return *s
+		}
+	case stepItem:
+		return d.step(n.Index)
+	case stepValueItem:
+		if s := d.step(n.Index); s != nil {
+			return *s
+		}
+	case tableItem:
+		return d.table(n.Index)
+	case dataTableItem:
+		if inRange(n.Index, len(d.dataTables)) {
+			return d.dataTables[n.Index-1]
+		}
+	case ownDataTableItem:
+		return own
+	case tearDownItem:
+		if inRange(n.Index, len(d.tearDowns)) {
+			return d.tearDowns[n.Index-1]
+		}
+	case frontMatterItem:
+		if inRange(n.Index, len(d.frontMatters)) {
+			return d.frontMatters[n.Index-1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,690 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package gauge
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/getgauge/gauge-proto/go/gauge_messages"
+)
+
+// snapshotFormat is the version of the binary form of snapshots. It has to be bumped whenever the nodes change.
+const snapshotFormat = 1
+
+var errSnapshotTruncated = errors.New("snapshot is truncated")
+
+// MarshalBinary encodes the snapshot in a compact binary form. Reading it back takes a fraction of the time of
+// reflection based encodings like JSON or gob, which are slower than parsing the spec again.
+func (s *SpecSnapshot) MarshalBinary() ([]byte, error) {
+	w := newSnapshotWriter()
+	w.nodes(&s.Nodes)
+	w.specNode(&s.Spec)
+	return w.buf, nil
+}
+
+// UnmarshalBinary decodes a snapshot written by MarshalBinary.
+func (s *SpecSnapshot) UnmarshalBinary(data []byte) error {
+	r, err := newSnapshotReader(data)
+	if err != nil {
+		return err
+	}
+	r.nodes(&s.Nodes)
+	r.specNode(&s.Spec)
+	return r.finish()
+}
+
+// MarshalBinary encodes the snapshot in the binary form of SpecSnapshot.
+func (s *ConceptDictionarySnapshot) MarshalBinary() ([]byte, error) {
+	w := newSnapshotWriter()
+	w.nodes(&s.Nodes)
+	w.length(len(s.Concepts), s.Concepts == nil)
+	for value, c := range s.Concepts {
+		w.string(value)
+		w.int(c.ConceptStep)
+		w.string(c.FileName)
+	}
+	w.length(len(s.ConstructionMap), s.ConstructionMap == nil)
+	for value, refs := range s.ConstructionMap {
+		w.string(value)
+		w.ints(refs)
+	}
+	return w.buf, nil
+}
+
+// UnmarshalBinary decodes a snapshot written by MarshalBinary.
+func (s *ConceptDictionarySnapshot) UnmarshalBinary(data []byte) error {
+	r, err := newSnapshotReader(data)
+	if err != nil {
+		return err
+	}
+	r.nodes(&s.Nodes)
+	if n, ok := r.length(); ok {
+		s.Concepts = make(map[string]conceptNode, n)
+		for i := 0; i < n; i++ {
+			value := r.string()
+			s.Concepts[value] = conceptNode{ConceptStep: r.int(), FileName: r.string()}
+		}
+	}
+	if n, ok := r.length(); ok {
+		s.ConstructionMap = make(map[string][]int, n)
+		for i := 0; i < n; i++ {
+			value := r.string()
+			s.ConstructionMap[value] = r.ints()
+		}
+	}
+	return r.finish()
+}
+
+type snapshotWriter struct {
+	buf     []byte
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func newSnapshotWriter() *snapshotWriter {
+	w := &snapshotWriter{buf: make([]byte, 0, 4096)}
+	w.int(snapshotFormat)
+	return w
+}
+
+func (w *snapshotWriter) int(v int) {
+	n := binary.PutVarint(w.scratch[:], int64(v))
+	w.buf = append(w.buf, w.scratch[:n]...)
+}
+
+func (w *snapshotWriter) uint(v uint64) {
+	n := binary.PutUvarint(w.scratch[:], v)
+	w.buf = append(w.buf, w.scratch[:n]...)
+}
+
+func (w *snapshotWriter) bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+func (w *snapshotWriter) string(v string) {
+	w.uint(uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// length writes the length of a slice or map, keeping nil apart from empty.
+func (w *snapshotWriter) length(n int, isNil bool) {
+	if isNil {
+		w.uint(0)
+		return
+	}
+	w.uint(uint64(n) + 1)
+}
+
+func (w *snapshotWriter) ints(v []int) {
+	w.length(len(v), v == nil)
+	for _, i := range v {
+		w.int(i)
+	}
+}
+
+func (w *snapshotWriter) strings(v []string) {
+	w.length(len(v), v == nil)
+	for _, s := range v {
+		w.string(s)
+	}
+}
+
+func (w *snapshotWriter) stringIntMap(m map[string]int) {
+	w.length(len(m), m == nil)
+	for k, v := range m {
+		w.string(k)
+		w.int(v)
+	}
+}
+
+func (w *snapshotWriter) stringMap(m map[string]string) {
+	w.length(len(m), m == nil)
+	for k, v := range m {
+		w.string(k)
+		w.string(v)
+	}
+}
+
+func (w *snapshotWriter) nodes(n *snapshotNodes) {
+	w.strings(n.Files)
+	w.length(len(n.Steps), n.Steps == nil)
+	for i := range n.Steps {
+		w.stepNode(&n.Steps[i])
+	}
+	w.length(len(n.Args), n.Args == nil)
+	for _, a := range n.Args {
+		w.string(a.Name)
+		w.string(a.Value)
+		w.string(string(a.ArgType))
+		w.bool(a.Table != nil)
+		if a.Table != nil {
+			w.tableNode(a.Table)
+		}
+	}
+	w.length(len(n.Scenarios), n.Scenarios == nil)
+	for i := range n.Scenarios {
+		w.scenarioNode(&n.Scenarios[i])
+	}
+	w.length(len(n.Headings), n.Headings == nil)
+	for _, h := range n.Headings {
+		w.string(h.Value)
+		w.int(h.LineNo)
+		w.int(h.SpanEnd)
+		w.int(int(h.HeadingType))
+	}
+	w.length(len(n.Comments), n.Comments == nil)
+	for _, c := range n.Comments {
+		w.string(c.Value)
+		w.int(c.LineNo)
+	}
+	w.length(len(n.Tags), n.Tags == nil)
+	for _, t := range n.Tags {
+		w.length(len(t.RawValues), t.RawValues == nil)
+		for _, values := range t.RawValues {
+			w.strings(values)
+		}
+	}
+	w.length(len(n.Tables), n.Tables == nil)
+	for i := range n.Tables {
+		w.tableNode(&n.Tables[i])
+	}
+	w.length(len(n.DataTables), n.DataTables == nil)
+	for i := range n.DataTables {
+		w.dataTableNode(&n.DataTables[i])
+	}
+	w.length(len(n.TearDowns), n.TearDowns == nil)
+	for _, t := range n.TearDowns {
+		w.int(t.LineNo)
+		w.string(t.Value)
+	}
+	w.length(len(n.FrontMatters), n.FrontMatters == nil)
+	for _, f := range n.FrontMatters {
+		w.int(f.LineNo)
+		w.strings(f.Lines)
+	}
+}
+
+func (w *snapshotWriter) specNode(n *specNode) {
+	w.int(n.Heading)
+	w.ints(n.Scenarios)
+	w.ints(n.Comments)
+	w.dataTableNode(&n.DataTable)
+	w.ints(n.Contexts)
+	w.string(n.FileName)
+	w.int(n.Tags)
+	w.items(n.Items)
+	w.ints(n.TearDownSteps)
+	w.stringMap(n.Metadata)
+}
+
+func (w *snapshotWriter) scenarioNode(n *scenarioNode) {
+	w.int(n.Heading)
+	w.ints(n.Steps)
+	w.ints(n.Comments)
+	w.int(n.Tags)
+	w.items(n.Items)
+	w.dataTableNode(&n.DataTable)
+	w.tableNode(&n.SpecDataTableRow)
+	w.int(n.SpecDataTableRowIndex)
+	w.tableNode(&n.ScenarioDataTableRow)
+	w.int(n.ScenarioDataTableRowIndex)
+	w.bool(n.Span != nil)
+	if n.Span != nil {
+		w.int(n.Span.Start)
+		w.int(n.Span.End)
+	}
+	w.length(len(n.SkipDirectives), n.SkipDirectives == nil)
+	for _, d := range n.SkipDirectives {
+		w.bool(d != nil)
+		if d != nil {
+			w.int(d.LineNo)
+			w.string(d.Reason)
+		}
+	}
+}
+
+func (w *snapshotWriter) stepNode(n *stepNode) {
+	w.int(n.LineNo)
+	w.int(n.File)
+	w.string(n.Value)
+	w.string(n.LineText)
+	w.ints(n.Args)
+	w.bool(n.IsConcept)
+	w.bool(n.Lookup != nil)
+	if n.Lookup != nil {
+		w.stringIntMap(n.Lookup.ParamIndexMap)
+		w.length(len(n.Lookup.Params), n.Lookup.Params == nil)
+		for _, p := range n.Lookup.Params {
+			w.string(p.Name)
+			w.int(p.Arg)
+		}
+	}
+	w.ints(n.ConceptSteps)
+	w.length(len(n.Fragments), n.Fragments == nil)
+	for _, f := range n.Fragments {
+		w.fragment(f)
+	}
+	w.int(n.Parent)
+	w.bool(n.HasInlineTable)
+	w.items(n.Items)
+	w.ints(n.PreComments)
+	w.string(n.Suffix)
+	w.int(n.LineSpanEnd)
+}
+
+func (w *snapshotWriter) fragment(f *gauge_messages.Fragment) {
+	w.bool(f != nil)
+	if f == nil {
+		return
+	}
+	w.int(int(f.FragmentType))
+	w.string(f.Text)
+	p := f.Parameter
+	w.bool(p != nil)
+	if p == nil {
+		return
+	}
+	w.int(int(p.ParameterType))
+	w.string(p.Value)
+	w.string(p.Name)
+	w.bool(p.Table != nil)
+	if p.Table != nil {
+		w.protoTableRow(p.Table.Headers)
+		w.length(len(p.Table.Rows), p.Table.Rows == nil)
+		for _, row := range p.Table.Rows {
+			w.protoTableRow(row)
+		}
+	}
+}
+
+func (w *snapshotWriter) protoTableRow(row *gauge_messages.ProtoTableRow) {
+	w.bool(row != nil)
+	if row != nil {
+		w.strings(row.Cells)
+	}
+}
+
+func (w *snapshotWriter) tableNode(n *tableNode) {
+	w.stringIntMap(n.HeaderIndexMap)
+	w.length(len(n.Columns), n.Columns == nil)
+	for _, cells := range n.Columns {
+		w.length(len(cells), cells == nil)
+		for _, c := range cells {
+			w.string(c.Value)
+			w.string(string(c.CellType))
+		}
+	}
+	w.strings(n.Headers)
+	w.int(n.LineNo)
+}
+
+func (w *snapshotWriter) dataTableNode(n *dataTableNode) {
+	w.int(n.Table)
+	w.string(n.Value)
+	w.int(n.LineNo)
+	w.bool(n.IsExternal)
+}
+
+func (w *snapshotWriter) items(items []itemNode) {
+	w.length(len(items), items == nil)
+	for _, i := range items {
+		w.string(i.Type)
+		w.int(i.Index)
+	}
+}
+
+// snapshotReader reads what snapshotWriter wrote. The first error is kept and every later read gives a zero value.
+//
+// The data is copied into a string once, and the strings read are substrings of it, which saves an allocation for
+// every string of the snapshot.
+type snapshotReader struct {
+	data string
+	err  error
+}
+
+func newSnapshotReader(data []byte) (*snapshotReader, error) {
+	r := &snapshotReader{data: string(data)}
+	if f := r.int(); r.err == nil && f != snapshotFormat {
+		return nil, fmt.Errorf("snapshot has format %d, expected %d", f, snapshotFormat)
+	}
+	return r, r.err
+}
+
+func (r *snapshotReader) finish() error {
+	if r.err == nil && len(r.data) > 0 {
+		return fmt.Errorf("snapshot has %d bytes left over", len(r.data))
+	}
+	return r.err
+}
+
+func (r *snapshotReader) fail() {
+	if r.err == nil {
+		r.err = errSnapshotTruncated
+	}
+	r.data = ""
+}
+
+func (r *snapshotReader) uint() uint64 {
+	var v uint64
+	for i := 0; i < len(r.data) && i < binary.MaxVarintLen64; i++ {
+		b := r.data[i]
+		v |= uint64(b&0x7f) << (7 * uint(i))
+		if b < 0x80 {
+			r.data = r.data[i+1:]
+			return v
+		}
+	}
+	r.fail()
+	return 0
+}
+
+func (r *snapshotReader) int() int {
+	u := r.uint()
+	v := int64(u >> 1)
+	if u&1 != 0 {
+		v = ^v
+	}
+	return int(v)
+}
+
+func (r *snapshotReader) bool() bool {
+	if len(r.data) == 0 {
+		r.fail()
+		return false
+	}
+	v := r.data[0] == 1
+	r.data = r.data[1:]
+	return v
+}
+
+func (r *snapshotReader) string() string {
+	l := r.uint()
+	if l > uint64(len(r.data)) {
+		r.fail()
+		return ""
+	}
+	s := r.data[:l]
+	r.data = r.data[l:]
+	return s
+}
+
+// length reads the length of a slice or map, and whether it is not nil. Every element takes at least a byte, so a
+// length beyond the rest of the data is an error rather than a reason to allocate.
+func (r *snapshotReader) length() (int, bool) {
+	l := r.uint()
+	if l > uint64(len(r.data))+1 {
+		r.fail()
+		return 0, false
+	}
+	if l == 0 {
+		return 0, false
+	}
+	return int(l - 1), true
+}
+
+func (r *snapshotReader) ints() []int {
+	n, ok := r.length()
+	if !ok {
+		return nil
+	}
+	v := make([]int, n)
+	for i := range v {
+		v[i] = r.int()
+	}
+	return v
+}
+
+func (r *snapshotReader) strings() []string {
+	n, ok := r.length()
+	if !ok {
+		return nil
+	}
+	v := make([]string, n)
+	for i := range v {
+		v[i] = r.string()
+	}
+	return v
+}
+
+func (r *snapshotReader) stringIntMap() map[string]int {
+	n, ok := r.length()
+	if !ok {
+		return nil
+	}
+	m := make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		k := r.string()
+		m[k] = r.int()
+	}
+	return m
+}
+
+func (r *snapshotReader) stringMap() map[string]string {
+	n, ok := r.length()
+	if !ok {
+		return nil
+	}
+	m := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		k := r.string()
+		m[k] = r.string()
+	}
+	return m
+}
+
+func (r *snapshotReader) nodes(n *snapshotNodes) {
+	n.Files = r.strings()
+	if l, ok := r.length(); ok {
+		n.Steps = make([]stepNode, l)
+		for i := range n.Steps {
+			r.stepNode(&n.Steps[i])
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.Args = make([]argNode, l)
+		for i := range n.Args {
+			a := &n.Args[i]
+			a.Name = r.string()
+			a.Value = r.string()
+			a.ArgType = ArgType(r.string())
+			if r.bool() {
+				a.Table = new(tableNode)
+				r.tableNode(a.Table)
+			}
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.Scenarios = make([]scenarioNode, l)
+		for i := range n.Scenarios {
+			r.scenarioNode(&n.Scenarios[i])
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.Headings = make([]Heading, l)
+		for i := range n.Headings {
+			n.Headings[i] = Heading{Value: r.string(), LineNo: r.int(), SpanEnd: r.int(), HeadingType: HeadingType(r.int())}
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.Comments = make([]Comment, l)
+		for i := range n.Comments {
+			n.Comments[i] = Comment{Value: r.string(), LineNo: r.int()}
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.Tags = make([]Tags, l)
+		for i := range n.Tags {
+			if m, ok := r.length(); ok {
+				n.Tags[i].RawValues = make([][]string, m)
+				for j := range n.Tags[i].RawValues {
+					n.Tags[i].RawValues[j] = r.strings()
+				}
+			}
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.Tables = make([]tableNode, l)
+		for i := range n.Tables {
+			r.tableNode(&n.Tables[i])
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.DataTables = make([]dataTableNode, l)
+		for i := range n.DataTables {
+			r.dataTableNode(&n.DataTables[i])
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.TearDowns = make([]TearDown, l)
+		for i := range n.TearDowns {
+			n.TearDowns[i] = TearDown{LineNo: r.int(), Value: r.string()}
+		}
+	}
+	if l, ok := r.length(); ok {
+		n.FrontMatters = make([]FrontMatter, l)
+		for i := range n.FrontMatters {
+			n.FrontMatters[i] = FrontMatter{LineNo: r.int(), Lines: r.strings()}
+		}
+	}
+}
+
+func (r *snapshotReader) specNode(n *specNode) {
+	n.Heading = r.int()
+	n.Scenarios = r.ints()
+	n.Comments = r.ints()
+	r.dataTableNode(&n.DataTable)
+	n.Contexts = r.ints()
+	n.FileName = r.string()
+	n.Tags = r.int()
+	n.Items = r.items()
+	n.TearDownSteps = r.ints()
+	n.Metadata = r.stringMap()
+}
+
+func (r *snapshotReader) scenarioNode(n *scenarioNode) {
+	n.Heading = r.int()
+	n.Steps = r.ints()
+	n.Comments = r.ints()
+	n.Tags = r.int()
+	n.Items = r.items()
+	r.dataTableNode(&n.DataTable)
+	r.tableNode(&n.SpecDataTableRow)
+	n.SpecDataTableRowIndex = r.int()
+	r.tableNode(&n.ScenarioDataTableRow)
+	n.ScenarioDataTableRowIndex = r.int()
+	if r.bool() {
+		n.Span = &Span{Start: r.int(), End: r.int()}
+	}
+	if l, ok := r.length(); ok {
+		n.SkipDirectives = make([]*SkipDirective, l)
+		for i := range n.SkipDirectives {
+			if r.bool() {
+				n.SkipDirectives[i] = &SkipDirective{LineNo: r.int(), Reason: r.string()}
+			}
+		}
+	}
+}
+
+func (r *snapshotReader) stepNode(n *stepNode) {
+	n.LineNo = r.int()
+	n.File = r.int()
+	n.Value = r.string()
+	n.LineText = r.string()
+	n.Args = r.ints()
+	n.IsConcept = r.bool()
+	if r.bool() {
+		n.Lookup = &lookupNode{ParamIndexMap: r.stringIntMap()}
+		if l, ok := r.length(); ok {
+			n.Lookup.Params = make([]paramNode, l)
+			for i := range n.Lookup.Params {
+				n.Lookup.Params[i] = paramNode{Name: r.string(), Arg: r.int()}
+			}
+		}
+	}
+	n.ConceptSteps = r.ints()
+	if l, ok := r.length(); ok {
+		n.Fragments = make([]*gauge_messages.Fragment, l)
+		for i := range n.Fragments {
+			n.Fragments[i] = r.fragment()
+		}
+	}
+	n.Parent = r.int()
+	n.HasInlineTable = r.bool()
+	n.Items = r.items()
+	n.PreComments = r.ints()
+	n.Suffix = r.string()
+	n.LineSpanEnd = r.int()
+}
+
+func (r *snapshotReader) fragment() *gauge_messages.Fragment {
+	if !r.bool() {
+		return nil
+	}
+	f := &gauge_messages.Fragment{FragmentType: gauge_messages.Fragment_FragmentType(r.int()), Text: r.string()}
+	if !r.bool() {
+		return f
+	}
+	f.Parameter = &gauge_messages.Parameter{ParameterType: gauge_messages.Parameter_ParameterType(r.int()), Value: r.string(), Name: r.string()}
+	if r.bool() {
+		f.Parameter.Table = &gauge_messages.ProtoTable{Headers: r.protoTableRow()}
+		if l, ok := r.length(); ok {
+			f.Parameter.Table.Rows = make([]*gauge_messages.ProtoTableRow, l)
+			for i := range f.Parameter.Table.Rows {
+				f.Parameter.Table.Rows[i] = r.protoTableRow()
+			}
+		}
+	}
+	return f
+}
+
+func (r *snapshotReader) protoTableRow() *gauge_messages.ProtoTableRow {
+	if !r.bool() {
+		return nil
+	}
+	return &gauge_messages.ProtoTableRow{Cells: r.strings()}
+}
+
+func (r *snapshotReader) tableNode(n *tableNode) {
+	n.HeaderIndexMap = r.stringIntMap()
+	if l, ok := r.length(); ok {
+		n.Columns = make([][]TableCell, l)
+		for i := range n.Columns {
+			if m, ok := r.length(); ok {
+				n.Columns[i] = make([]TableCell, m)
+				for j := range n.Columns[i] {
+					n.Columns[i][j] = TableCell{Value: r.string(), CellType: ArgType(r.string())}
+				}
+			}
+		}
+	}
+	n.Headers = r.strings()
+	n.LineNo = r.int()
+}
+
+func (r *snapshotReader) dataTableNode(n *dataTableNode) {
+	n.Table = r.int()
+	n.Value = r.string()
+	n.LineNo = r.int()
+	n.IsExternal = r.bool()
+}
+
+func (r *snapshotReader) items() []itemNode {
+	n, ok := r.length()
+	if !ok {
+		return nil
+	}
+	items := make([]itemNode, n)
+	for i := range items {
+		items[i] = itemNode{Type: r.string(), Index: r.int()}
+	}
+	return items
+}
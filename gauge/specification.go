@@ -31,6 +31,7 @@ const (
 	TableKind
 	DataTableKind
 	TearDownKind
+	FrontMatterKind
 )
 
 type Specification struct {
@@ -43,6 +44,7 @@ type Specification struct {
 	Tags          *Tags
 	Items         []Item
 	TearDownSteps []*Step
+	Metadata      map[string]string
 }
 
 type Item interface {
@@ -146,6 +148,11 @@ func (spec *Specification) AddExternalDataTable(externalTable *DataTable) {
 	spec.AddItem(externalTable)
 }
 
+func (spec *Specification) AddFrontMatter(frontMatter *FrontMatter, metadata map[string]string) {
+	spec.Metadata = metadata
+	spec.AddItem(frontMatter)
+}
+
 func (spec *Specification) AddTags(tags *Tags) {
 	spec.Tags = tags
 	spec.AddItem(spec.Tags)
@@ -249,6 +256,9 @@ func (spec *Specification) GetSpecItems() []Item {
 
 func (spec *Specification) Traverse(processor ItemProcessor, queue *ItemQueue) {
 	processor.Specification(spec)
+	if item := queue.Peek(); item != nil && item.Kind() == FrontMatterKind {
+		processFrontMatter(processor, queue.Next().(*FrontMatter))
+	}
 	processor.Heading(spec.Heading)
 
 	for queue.Peek() != nil {
@@ -267,12 +277,20 @@ func (spec *Specification) Traverse(processor ItemProcessor, queue *ItemQueue) {
 			processor.Tags(item.(*Tags))
 		case TearDownKind:
 			processor.TearDown(item.(*TearDown))
+		case FrontMatterKind:
+			processFrontMatter(processor, item.(*FrontMatter))
 		case DataTableKind:
 			processor.DataTable(item.(*DataTable))
 		}
 	}
 }
 
+func processFrontMatter(processor ItemProcessor, frontMatter *FrontMatter) {
+	if p, ok := processor.(FrontMatterProcessor); ok {
+		p.FrontMatter(frontMatter)
+	}
+}
+
 func (spec *Specification) AllItems() (items []Item) {
 	for _, item := range spec.Items {
 		items = append(items, item)
@@ -350,6 +368,17 @@ func (t *TearDown) Kind() TokenKind {
 	return TearDownKind
 }
 
+// FrontMatter is the optional metadata block at the top of a spec file, delimited by `---` (YAML style)
+// or `+++` (TOML style). Lines holds the block as written, including the delimiters.
+type FrontMatter struct {
+	LineNo int
+	Lines  []string
+}
+
+func (f *FrontMatter) Kind() TokenKind {
+	return FrontMatterKind
+}
+
 type Tags struct {
 	RawValues [][]string
 }
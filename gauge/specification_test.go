@@ -111,3 +111,41 @@ func (s *MySuite) TestStepsGiveAllStepsPresentInSpec(c *C) {
 
 	c.Assert(spec.Steps(), DeepEquals, []*Step{step1, step2, step3})
 }
+
+type headingCollector struct {
+	headings []string
+}
+
+func (h *headingCollector) Specification(*Specification) {}
+func (h *headingCollector) Heading(heading *Heading)     { h.headings = append(h.headings, heading.Value) }
+func (h *headingCollector) Tags(*Tags)                   {}
+func (h *headingCollector) Table(*Table)                 {}
+func (h *headingCollector) DataTable(*DataTable)         {}
+func (h *headingCollector) Scenario(*Scenario)           {}
+func (h *headingCollector) Step(*Step)                   {}
+func (h *headingCollector) TearDown(*TearDown)           {}
+func (h *headingCollector) Comment(*Comment)             {}
+
+type frontMatterCollector struct {
+	headingCollector
+	frontMatter []*FrontMatter
+}
+
+func (f *frontMatterCollector) FrontMatter(frontMatter *FrontMatter) {
+	f.frontMatter = append(f.frontMatter, frontMatter)
+}
+
+func (s *MySuite) TestTraverseGivesFrontMatterOnlyToFrontMatterProcessors(c *C) {
+	frontMatter := &FrontMatter{LineNo: 1, Lines: []string{"---", "owner: payments", "---"}}
+	scenario := &Scenario{Heading: &Heading{Value: "Scenario"}}
+	spec := &Specification{Heading: &Heading{Value: "Spec"}, Items: []Item{frontMatter, scenario}}
+
+	h := &headingCollector{}
+	spec.Traverse(h, &ItemQueue{Items: spec.Items})
+	c.Assert(h.headings, DeepEquals, []string{"Spec", "Scenario"})
+
+	f := &frontMatterCollector{}
+	spec.Traverse(f, &ItemQueue{Items: spec.Items})
+	c.Assert(f.headings, DeepEquals, []string{"Spec", "Scenario"})
+	c.Assert(f.frontMatter, DeepEquals, []*FrontMatter{frontMatter})
+}
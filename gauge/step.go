@@ -169,14 +169,15 @@ func (step *Step) GetLastArg() *StepArg {
 	return step.Args[len(step.Args)-1]
 }
 
+var parameterPlaceholderPattern = regexp.MustCompile(ParameterPlaceholder)
+
 func (step *Step) PopulateFragments() {
-	r := regexp.MustCompile(ParameterPlaceholder)
 	/*
 		enter {} and {} bar
 		returns
 		[[6 8] [13 15]]
 	*/
-	argSplitIndices := r.FindAllStringSubmatchIndex(step.Value, -1)
+	argSplitIndices := parameterPlaceholderPattern.FindAllStringSubmatchIndex(step.Value, -1)
 	step.Fragments = make([]*gauge_messages.Fragment, 0)
 	if len(step.Args) == 0 {
 		step.Fragments = append(step.Fragments, &gauge_messages.Fragment{FragmentType: gauge_messages.Fragment_Text, Text: step.Value})
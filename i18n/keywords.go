@@ -0,0 +1,141 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+/*
+Package i18n holds the localized reserved words of the spec syntax and the translations of parse and
+validation messages. The language is chosen per project with the gauge_spec_language property.
+
+English keywords are always understood, so specs written in English keep working irrespective of the
+configured language. The formatter writes the keywords of the configured language.
+*/
+package i18n
+
+import (
+	"strings"
+
+	"github.com/getgauge/gauge/env"
+)
+
+// DefaultLanguage is used when gauge_spec_language is not set or is not supported.
+const DefaultLanguage = "en"
+
+// Keywords holds the reserved words of the spec syntax for a language.
+type Keywords struct {
+	// Tags starts a tags line, e.g. `tags: smoke`.
+	Tags string
+	// Table refers to an external data table, e.g. `table: data.csv`, and to a special table param, e.g. `<table:data.csv>`.
+	Table string
+	// File refers to a special file param, e.g. `<file:data.txt>`.
+	File string
+	// TearDown is an alternative to the `___` teardown marker, written as `<keyword>:`. English has none.
+	TearDown string
+}
+
+var english = Keywords{Tags: "tags", Table: "table", File: "file"}
+
+var keywords = map[string]Keywords{
+	"en": english,
+	"de": {Tags: "tags", Table: "tabelle", File: "datei", TearDown: "aufräumen"},
+	"ja": {Tags: "タグ", Table: "テーブル", File: "ファイル", TearDown: "後処理"},
+}
+
+// Language returns the configured spec language, falling back to DefaultLanguage if it is not supported.
+func Language() string {
+	lang := strings.ToLower(strings.TrimSpace(env.SpecLanguage()))
+	if _, ok := keywords[lang]; ok {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// IsSupported reports whether the given language has localized keywords.
+func IsSupported(lang string) bool {
+	_, ok := keywords[strings.ToLower(lang)]
+	return ok
+}
+
+// CurrentKeywords returns the keywords of the configured spec language.
+func CurrentKeywords() Keywords {
+	return keywords[Language()]
+}
+
+func candidates(english, localized string) []string {
+	if localized == "" || strings.EqualFold(english, localized) {
+		return []string{english}
+	}
+	return []string{localized, english}
+}
+
+// TrimKeyword checks if the text starts with the keyword followed by a colon, ignoring case and spaces
+// before the colon. It returns the text after the colon.
+func TrimKeyword(text string, keyword string) (string, bool) {
+	if keyword == "" || len(text) < len(keyword) || !strings.EqualFold(text[:len(keyword)], keyword) {
+		return "", false
+	}
+	rest := strings.TrimLeft(text[len(keyword):], " \t")
+	if !strings.HasPrefix(rest, ":") {
+		return "", false
+	}
+	return rest[1:], true
+}
+
+// TrimTagsKeyword checks if the text is a tags line in English or in the configured language and returns the tags part.
+func TrimTagsKeyword(text string) (string, bool) {
+	for _, k := range candidates(english.Tags, CurrentKeywords().Tags) {
+		if rest, ok := TrimKeyword(text, k); ok {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// TrimTableKeyword checks if the text refers to an external data table in English or in the configured language
+// and returns the table location.
+func TrimTableKeyword(text string) (string, bool) {
+	for _, k := range candidates(english.Table, CurrentKeywords().Table) {
+		if rest, ok := TrimKeyword(text, k); ok {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// TrimFileKeyword checks if the text is a special file param in English or in the configured language, e.g.
+// `datei:data.txt`, and returns the file path.
+func TrimFileKeyword(text string) (string, bool) {
+	for _, k := range candidates(english.File, CurrentKeywords().File) {
+		if rest, ok := TrimKeyword(text, k); ok {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// SpecialParamKeywords returns the types of the special params which refer to files, in English and in the configured
+// language, e.g. file, table, datei and tabelle.
+func SpecialParamKeywords() []string {
+	k := CurrentKeywords()
+	return append(candidates(english.File, k.File), candidates(english.Table, k.Table)...)
+}
+
+// IsTearDownKeyword checks if the text is the localized teardown marker, e.g. `aufräumen:`.
+func IsTearDownKeyword(text string) bool {
+	rest, ok := TrimKeyword(text, CurrentKeywords().TearDown)
+	return ok && strings.TrimSpace(rest) == ""
+}
+
+// CanonicalSpecialType maps a localized special param type, e.g. `datei`, to its English name.
+// Other types are returned as is.
+func CanonicalSpecialType(specialType string) string {
+	k := CurrentKeywords()
+	if k.File != english.File && strings.EqualFold(specialType, k.File) {
+		return english.File
+	}
+	if k.Table != english.Table && strings.EqualFold(specialType, k.Table) {
+		return english.Table
+	}
+	return specialType
+}
@@ -0,0 +1,109 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/env"
+)
+
+func setLanguage(lang string) func() {
+	old := env.SpecLanguage
+	env.SpecLanguage = func() string { return lang }
+	return func() { env.SpecLanguage = old }
+}
+
+func TestLanguageFallsBackToEnglish(t *testing.T) {
+	defer setLanguage("xx")()
+	if got := Language(); got != DefaultLanguage {
+		t.Errorf("expected %s, got %s", DefaultLanguage, got)
+	}
+}
+
+func TestTrimTagsKeyword(t *testing.T) {
+	defer setLanguage("ja")()
+	for _, text := range []string{"タグ: a, b", "tags: a, b", "Tags : a, b"} {
+		rest, ok := TrimTagsKeyword(text)
+		if !ok || rest != " a, b" {
+			t.Errorf("expected %q to be a tags line, got %q, %v", text, rest, ok)
+		}
+	}
+	if _, ok := TrimTagsKeyword("tagsfoo"); ok {
+		t.Errorf("expected tagsfoo not to be a tags line")
+	}
+}
+
+func TestIsTearDownKeyword(t *testing.T) {
+	defer setLanguage("de")()
+	if !IsTearDownKeyword("Aufräumen:") {
+		t.Errorf("expected Aufräumen: to be a teardown marker")
+	}
+	if IsTearDownKeyword("Aufräumen: nach dem Test") {
+		t.Errorf("expected text after the teardown keyword not to be a teardown marker")
+	}
+	defer setLanguage("en")()
+	if IsTearDownKeyword("teardown:") {
+		t.Errorf("expected english to have no teardown keyword")
+	}
+}
+
+func TestCanonicalSpecialType(t *testing.T) {
+	defer setLanguage("de")()
+	for given, want := range map[string]string{"Datei": "file", "tabelle": "table", "file": "file", "foo": "foo"} {
+		if got := CanonicalSpecialType(given); got != want {
+			t.Errorf("expected %s for %s, got %s", want, given, got)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer setLanguage("de")()
+	if got := T("Spec heading not found"); got != "Überschrift der Spezifikation nicht gefunden" {
+		t.Errorf("unexpected translation %s", got)
+	}
+	if got := T("untranslated message"); got != "untranslated message" {
+		t.Errorf("expected untranslated message to be returned as is, got %s", got)
+	}
+}
+
+func TestTrimFileKeyword(t *testing.T) {
+	defer setLanguage("de")()
+	for _, text := range []string{"datei:data.txt", "file:data.txt", "Datei : data.txt"} {
+		rest, ok := TrimFileKeyword(text)
+		if !ok || strings.TrimSpace(rest) != "data.txt" {
+			t.Errorf("expected %q to be a file param, got %q, %v", text, rest, ok)
+		}
+	}
+	if _, ok := TrimFileKeyword("table:data.csv"); ok {
+		t.Errorf("expected table:data.csv not to be a file param")
+	}
+}
+
+func TestSpecialParamKeywords(t *testing.T) {
+	defer setLanguage("de")()
+	if got := strings.Join(SpecialParamKeywords(), ","); got != "datei,file,tabelle,table" {
+		t.Errorf("unexpected special param keywords %s", got)
+	}
+	defer setLanguage("en")()
+	if got := strings.Join(SpecialParamKeywords(), ","); got != "file,table" {
+		t.Errorf("unexpected special param keywords %s", got)
+	}
+}
+
+func TestEveryMessageIsTranslatedInEachLanguage(t *testing.T) {
+	for lang, translations := range messages {
+		for other, otherTranslations := range messages {
+			for message := range otherTranslations {
+				if _, ok := translations[message]; !ok {
+					t.Errorf("%q is translated in %s but not in %s", message, other, lang)
+				}
+			}
+		}
+	}
+}
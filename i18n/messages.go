@@ -0,0 +1,85 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package i18n
+
+// messages maps the English parse and validation messages to their translations. Messages are looked up
+// by their English text, which may be a format string.
+var messages = map[string]map[string]string{
+	"de": {
+		"Spec does not have any elements":                                       "Die Spezifikation enthält keine Elemente",
+		"Spec heading not found":                                                "Überschrift der Spezifikation nicht gefunden",
+		"Spec heading should have at least one character":                       "Die Überschrift der Spezifikation muss mindestens ein Zeichen enthalten",
+		"Spec should have atleast one scenario":                                 "Die Spezifikation muss mindestens ein Szenario enthalten",
+		"Scenario should have atleast one step":                                 "Das Szenario muss mindestens einen Schritt enthalten",
+		"Scenario heading should have at least one character":                   "Die Überschrift des Szenarios muss mindestens ein Zeichen enthalten",
+		"Data table should have at least 1 data row":                            "Die Datentabelle muss mindestens eine Datenzeile enthalten",
+		"Teardown should have at least three underscore characters":             "Der Teardown muss aus mindestens drei Unterstrichen bestehen",
+		"Table location not specified":                                          "Speicherort der Tabelle nicht angegeben",
+		"Table header should not be blank":                                      "Der Tabellenkopf darf nicht leer sein",
+		"Table header cannot have repeated column values":                       "Der Tabellenkopf darf keine doppelten Spaltennamen enthalten",
+		"Table doesn't belong to any step":                                      "Die Tabelle gehört zu keinem Schritt",
+		"Tags can be defined only once per scenario":                            "Tags dürfen pro Szenario nur einmal definiert werden",
+		"Tags can be defined only once per specification":                       "Tags dürfen pro Spezifikation nur einmal definiert werden",
+		"Step should not be blank":                                              "Der Schritt darf nicht leer sein",
+		"Step is not defined inside a concept heading":                          "Der Schritt ist nicht unter einer Konzept-Überschrift definiert",
+		"Concept should have atleast one step":                                  "Das Konzept muss mindestens einen Schritt enthalten",
+		"Concept heading can have only Dynamic Parameters":                      "Die Konzept-Überschrift darf nur dynamische Parameter enthalten",
+		"Scenario Heading is not allowed in concept file":                       "Szenario-Überschriften sind in Konzeptdateien nicht erlaubt",
+		"Duplicate concept definition found":                                    "Doppelte Konzeptdefinition gefunden",
+		"Dynamic parameter <%s> could not be resolved":                          "Dynamischer Parameter <%s> konnte nicht aufgelöst werden",
+		"Could not resolve table from %s":                                       "Tabelle konnte nicht aus %s geladen werden",
+		"Step implementation not found":                                         "Keine Implementierung für den Schritt gefunden",
+		"Duplicate step implementation":                                         "Doppelte Implementierung des Schritts",
+		"Invalid response from runner for Validation request":                   "Ungültige Antwort des Runners auf die Validierungsanfrage",
+		"Dynamic param <%s> could not be resolved, Missing file: %s":            "Dynamischer Parameter <%s> konnte nicht aufgelöst werden, Datei fehlt: %s",
+		"Dynamic param <%s> could not be resolved, Treating it as static param": "Dynamischer Parameter <%s> konnte nicht aufgelöst werden, er wird als statischer Parameter behandelt",
+		"Front matter should be closed with '%s'":                               "Der Front Matter muss mit '%s' abgeschlossen werden",
+		"Front matter entry should be of the form 'key %s value'":               "Einträge im Front Matter müssen die Form 'Schlüssel %s Wert' haben",
+		"Duplicate front matter key '%s'":                                       "Doppelter Schlüssel '%s' im Front Matter",
+	},
+	"ja": {
+		"Spec does not have any elements":                                       "スペックに要素がありません",
+		"Spec heading not found":                                                "スペックの見出しが見つかりません",
+		"Spec heading should have at least one character":                       "スペックの見出しには少なくとも1文字が必要です",
+		"Spec should have atleast one scenario":                                 "スペックには少なくとも1つのシナリオが必要です",
+		"Scenario should have atleast one step":                                 "シナリオには少なくとも1つのステップが必要です",
+		"Scenario heading should have at least one character":                   "シナリオの見出しには少なくとも1文字が必要です",
+		"Data table should have at least 1 data row":                            "データテーブルには少なくとも1行のデータが必要です",
+		"Teardown should have at least three underscore characters":             "ティアダウンには少なくとも3つのアンダースコアが必要です",
+		"Table location not specified":                                          "テーブルの場所が指定されていません",
+		"Table header should not be blank":                                      "テーブルのヘッダーを空にすることはできません",
+		"Table header cannot have repeated column values":                       "テーブルのヘッダーに重複した列名は使用できません",
+		"Table doesn't belong to any step":                                      "テーブルがどのステップにも属していません",
+		"Tags can be defined only once per scenario":                            "タグはシナリオごとに1回だけ定義できます",
+		"Tags can be defined only once per specification":                       "タグはスペックごとに1回だけ定義できます",
+		"Step should not be blank":                                              "ステップを空にすることはできません",
+		"Step is not defined inside a concept heading":                          "ステップがコンセプトの見出しの下に定義されていません",
+		"Concept should have atleast one step":                                  "コンセプトには少なくとも1つのステップが必要です",
+		"Concept heading can have only Dynamic Parameters":                      "コンセプトの見出しには動的パラメータのみ使用できます",
+		"Scenario Heading is not allowed in concept file":                       "コンセプトファイルではシナリオの見出しは使用できません",
+		"Duplicate concept definition found":                                    "コンセプトの定義が重複しています",
+		"Dynamic parameter <%s> could not be resolved":                          "動的パラメータ <%s> を解決できませんでした",
+		"Could not resolve table from %s":                                       "%s からテーブルを解決できませんでした",
+		"Step implementation not found":                                         "ステップの実装が見つかりません",
+		"Duplicate step implementation":                                         "ステップの実装が重複しています",
+		"Invalid response from runner for Validation request":                   "検証リクエストに対するランナーの応答が不正です",
+		"Dynamic param <%s> could not be resolved, Missing file: %s":            "動的パラメータ <%s> を解決できませんでした。ファイルがありません: %s",
+		"Dynamic param <%s> could not be resolved, Treating it as static param": "動的パラメータ <%s> を解決できませんでした。静的パラメータとして扱います",
+		"Front matter should be closed with '%s'":                               "フロントマターは '%s' で閉じる必要があります",
+		"Front matter entry should be of the form 'key %s value'":               "フロントマターの項目は 'キー %s 値' の形式である必要があります",
+		"Duplicate front matter key '%s'":                                       "フロントマターのキー '%s' が重複しています",
+	},
+}
+
+// T returns the translation of the given English message in the configured spec language.
+// The message itself is returned if there is no translation.
+func T(message string) string {
+	if translated, ok := messages[Language()][message]; ok {
+		return translated
+	}
+	return message
+}
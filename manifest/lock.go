@@ -0,0 +1,90 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+// LockFile records the exact versions of the plugins of the project, and the checksums of their archives.
+const LockFile = "gauge.lock"
+
+// Lock is the content of the lock file, keyed by plugin id.
+type Lock struct {
+	Plugins map[string]LockedPlugin `json:"plugins"`
+}
+
+// LockedPlugin is the exact version of a plugin and the checksum of its archive, if it was downloaded.
+type LockedPlugin struct {
+	Version  string `json:"version"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// ProjectLock reads the lock file of the project, which is empty if the plugins have not been locked yet.
+func ProjectLock() (*Lock, error) {
+	return LockAt(config.ProjectRoot)
+}
+
+// LockAt reads the lock file of the project at the given root.
+func LockAt(root string) (*Lock, error) {
+	l := &Lock{Plugins: make(map[string]LockedPlugin)}
+	file := filepath.Join(root, LockFile)
+	if !common.FileExists(file) {
+		return l, nil
+	}
+	contents, err := common.ReadFileContents(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(contents), l); err != nil {
+		return nil, fmt.Errorf("Failed to read %s. %s", LockFile, err.Error())
+	}
+	if l.Plugins == nil {
+		l.Plugins = make(map[string]LockedPlugin)
+	}
+	return l, nil
+}
+
+// Version returns the locked version of the plugin.
+func (l *Lock) Version(pluginID string) (string, bool) {
+	p, ok := l.Plugins[pluginID]
+	return p.Version, ok && p.Version != ""
+}
+
+// Retain removes the plugins which are not in the given list.
+func (l *Lock) Retain(pluginIDs []string) {
+	keep := make(map[string]bool)
+	for _, id := range pluginIDs {
+		keep[id] = true
+	}
+	for id := range l.Plugins {
+		if !keep[id] {
+			delete(l.Plugins, id)
+		}
+	}
+}
+
+// Save writes the lock file to the project root, unless it already has the same content.
+func (l *Lock) Save() error {
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	file := filepath.Join(config.ProjectRoot, LockFile)
+	if existing, err := ioutil.ReadFile(file); err == nil && bytes.Equal(existing, b) {
+		return nil
+	}
+	return ioutil.WriteFile(file, b, common.NewFilePermissions)
+}
@@ -7,6 +7,7 @@
 package manifest
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,15 +17,29 @@ import (
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/version"
 )
 
+// Manifest describes the language and plugins of a project. The plugins are either a list of plugin ids, or an object
+// of plugin id to version constraint, like {"html-report": "^4.1"}.
 type Manifest struct {
 	Language string
 	Plugins  []string
+	Versions map[string]string `json:"-"`
+}
+
+type manifestJSON struct {
+	Language string
+	Plugins  json.RawMessage `json:",omitempty"`
 }
 
 func ProjectManifest() (*Manifest, error) {
-	contents, err := common.ReadFileContents(filepath.Join(config.ProjectRoot, common.ManifestFile))
+	return ManifestAt(config.ProjectRoot)
+}
+
+// ManifestAt reads the manifest of the project at the given root.
+func ManifestAt(root string) (*Manifest, error) {
+	contents, err := common.ReadFileContents(filepath.Join(root, common.ManifestFile))
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +57,101 @@ func ProjectManifest() (*Manifest, error) {
 	return &m, nil
 }
 
+// VersionConstraint returns the version constraint of the plugin, which allows any version if none is given.
+func (m *Manifest) VersionConstraint(pluginID string) (*version.Constraint, error) {
+	c, err := version.ParseConstraint(m.Versions[pluginID])
+	if err != nil {
+		return nil, fmt.Errorf("invalid version of plugin %s in %s. %s", pluginID, common.ManifestFile, err.Error())
+	}
+	return c, nil
+}
+
+// CheckLockedVersion checks that the version of the plugin locked in the lock file still matches the version of the
+// plugin in the manifest, which may have been changed after the lock was written.
+func (m *Manifest) CheckLockedVersion(pluginID, locked string) error {
+	c, err := m.VersionConstraint(pluginID)
+	if err != nil {
+		return err
+	}
+	v, err := version.ParseVersion(locked)
+	if err != nil {
+		return fmt.Errorf("invalid version of plugin %s in %s. %s", pluginID, LockFile, err.Error())
+	}
+	if !c.Allows(v) {
+		return fmt.Errorf("%s is out of date: plugin %s is locked at %s, which does not match %s in %s. Run `gauge install` to update it", LockFile, pluginID, locked, c, common.ManifestFile)
+	}
+	return nil
+}
+
+func (m *Manifest) UnmarshalJSON(b []byte) error {
+	var mj manifestJSON
+	if err := json.Unmarshal(b, &mj); err != nil {
+		return err
+	}
+	m.Language, m.Plugins, m.Versions = mj.Language, nil, nil
+	plugins := bytes.TrimSpace(mj.Plugins)
+	if len(plugins) == 0 || bytes.Equal(plugins, []byte("null")) {
+		return nil
+	}
+	if plugins[0] == '[' {
+		return json.Unmarshal(plugins, &m.Plugins)
+	}
+	// The plugins are read token by token to keep them in the order of the manifest.
+	dec := json.NewDecoder(bytes.NewReader(plugins))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	m.Versions = make(map[string]string)
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var constraint string
+		if err := dec.Decode(&constraint); err != nil {
+			return fmt.Errorf("version of plugin %v should be a string. %s", key, err.Error())
+		}
+		m.Plugins = append(m.Plugins, key.(string))
+		m.Versions[key.(string)] = constraint
+	}
+	return nil
+}
+
+func (m Manifest) MarshalJSON() ([]byte, error) {
+	mj := manifestJSON{Language: m.Language}
+	if len(m.Versions) == 0 {
+		plugins := m.Plugins
+		if plugins == nil {
+			plugins = []string{}
+		}
+		b, err := json.Marshal(plugins)
+		if err != nil {
+			return nil, err
+		}
+		mj.Plugins = b
+		return json.Marshal(mj)
+	}
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, p := range m.Plugins {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		constraint := m.Versions[p]
+		if constraint == "" {
+			constraint = "*"
+		}
+		key, _ := json.Marshal(p)
+		value, _ := json.Marshal(constraint)
+		b.Write(key)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	mj.Plugins = b.Bytes()
+	return json.Marshal(mj)
+}
+
 func (m *Manifest) Save() error {
 	b, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
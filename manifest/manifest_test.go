@@ -0,0 +1,139 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) TestReadingManifestWithListOfPlugins(c *C) {
+	var m Manifest
+	err := json.Unmarshal([]byte(`{"Language": "java", "Plugins": ["html-report", "xml-report"]}`), &m)
+
+	c.Assert(err, IsNil)
+	c.Assert(m.Language, Equals, "java")
+	c.Assert(m.Plugins, DeepEquals, []string{"html-report", "xml-report"})
+	c.Assert(m.Versions, IsNil)
+}
+
+func (s *MySuite) TestReadingManifestWithPluginVersions(c *C) {
+	var m Manifest
+	err := json.Unmarshal([]byte(`{"Language": "java", "Plugins": {"xml-report": "*", "html-report": "^4.1"}}`), &m)
+
+	c.Assert(err, IsNil)
+	c.Assert(m.Plugins, DeepEquals, []string{"xml-report", "html-report"})
+	c.Assert(m.Versions["html-report"], Equals, "^4.1")
+	constraint, err := m.VersionConstraint("html-report")
+	c.Assert(err, IsNil)
+	c.Assert(constraint.String(), Equals, "^4.1")
+}
+
+func (s *MySuite) TestReadingManifestWithInvalidPluginVersion(c *C) {
+	var m Manifest
+	err := json.Unmarshal([]byte(`{"Language": "java", "Plugins": {"html-report": 4}}`), &m)
+
+	c.Assert(err, ErrorMatches, "version of plugin html-report should be a string.*")
+}
+
+func (s *MySuite) TestWritingManifest(c *C) {
+	b, err := json.Marshal(Manifest{Language: "java", Plugins: []string{"html-report"}})
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"Language":"java","Plugins":["html-report"]}`)
+
+	m := Manifest{Language: "java", Plugins: []string{"xml-report", "html-report"}, Versions: map[string]string{"html-report": "^4.1"}}
+	b, err = json.Marshal(m)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, `{"Language":"java","Plugins":{"xml-report":"*","html-report":"^4.1"}}`)
+}
+
+func (s *MySuite) TestSavingAndReadingLock(c *C) {
+	dir, err := ioutil.TempDir("", "lock")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = dir
+	defer func() { config.ProjectRoot = oldRoot }()
+
+	l, err := ProjectLock()
+	c.Assert(err, IsNil)
+	c.Assert(l.Plugins, HasLen, 0)
+
+	l.Plugins["html-report"] = LockedPlugin{Version: "4.1.2", Checksum: "sha256:abc"}
+	l.Plugins["xml-report"] = LockedPlugin{Version: "0.2.3"}
+	l.Retain([]string{"html-report"})
+	c.Assert(l.Save(), IsNil)
+
+	l, err = ProjectLock()
+	c.Assert(err, IsNil)
+	v, ok := l.Version("html-report")
+	c.Assert(ok, Equals, true)
+	c.Assert(v, Equals, "4.1.2")
+	c.Assert(l.Plugins["html-report"].Checksum, Equals, "sha256:abc")
+	_, ok = l.Version("xml-report")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestSavingUnchangedLockDoesNotRewriteIt(c *C) {
+	dir, err := ioutil.TempDir("", "lock")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = dir
+	defer func() { config.ProjectRoot = oldRoot }()
+	l := &Lock{Plugins: map[string]LockedPlugin{"html-report": {Version: "4.1.2"}}}
+	c.Assert(l.Save(), IsNil)
+	file := filepath.Join(dir, LockFile)
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	c.Assert(os.Chtimes(file, past, past), IsNil)
+
+	l, err = ProjectLock()
+	c.Assert(err, IsNil)
+	c.Assert(l.Save(), IsNil)
+
+	info, err := os.Stat(file)
+	c.Assert(err, IsNil)
+	c.Assert(info.ModTime().Equal(past), Equals, true)
+}
+
+func (s *MySuite) TestRecordingKnownProjects(c *C) {
+	home, err := ioutil.TempDir("", "gauge-home")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(home)
+	defer os.Setenv(common.GaugeHome, os.Getenv(common.GaugeHome))
+	os.Setenv(common.GaugeHome, home)
+	oldRoot := config.ProjectRoot
+	defer func() { config.ProjectRoot = oldRoot }()
+	shop, removed := filepath.Join(home, "shop"), filepath.Join(home, "removed")
+	for _, p := range []string{shop, removed} {
+		c.Assert(os.MkdirAll(p, 0750), IsNil)
+		c.Assert(ioutil.WriteFile(filepath.Join(p, common.ManifestFile), []byte(`{"Language":"java"}`), 0640), IsNil)
+		config.ProjectRoot = p
+		c.Assert(RecordProject(), IsNil)
+		c.Assert(RecordProject(), IsNil)
+	}
+	c.Assert(os.RemoveAll(removed), IsNil)
+
+	projects, err := KnownProjects()
+
+	c.Assert(err, IsNil)
+	c.Assert(projects, DeepEquals, []string{shop})
+}
@@ -0,0 +1,92 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+// ProjectsFile lists the projects which have run or installed their plugins, in the config directory of Gauge. The
+// versions of the plugins they use are kept when removing unused plugins.
+const ProjectsFile = "projects.json"
+
+func projectsFile() (string, error) {
+	dir, err := common.GetConfigurationDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ProjectsFile), nil
+}
+
+// RecordProject adds the current project to the known projects, if it has a manifest. Projects which no longer exist
+// are dropped.
+func RecordProject() error {
+	root, err := filepath.Abs(config.ProjectRoot)
+	if err != nil {
+		return err
+	}
+	if !common.FileExists(filepath.Join(root, common.ManifestFile)) {
+		return nil
+	}
+	projects, err := KnownProjects()
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		if p == root {
+			return nil
+		}
+	}
+	projects = append(projects, root)
+	sort.Strings(projects)
+	file, err := projectsFile()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(file), common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(b, '\n'), common.NewFilePermissions)
+}
+
+// KnownProjects returns the roots of the recorded projects which still have a manifest.
+func KnownProjects() ([]string, error) {
+	file, err := projectsFile()
+	if err != nil {
+		return nil, err
+	}
+	if !common.FileExists(file) {
+		return nil, nil
+	}
+	contents, err := common.ReadFileContents(file)
+	if err != nil {
+		return nil, err
+	}
+	var recorded []string
+	if err = json.Unmarshal([]byte(contents), &recorded); err != nil {
+		return nil, fmt.Errorf("Failed to read %s. %s", file, err.Error())
+	}
+	var projects []string
+	for _, p := range recorded {
+		if common.FileExists(filepath.Join(p, common.ManifestFile)) {
+			projects = append(projects, p)
+		}
+	}
+	return projects, nil
+}
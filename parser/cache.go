@@ -0,0 +1,359 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/version"
+)
+
+// The parse cache keeps the specifications and the concept dictionary of earlier runs in .gauge/cache, so that only
+// the spec and concept files which changed are parsed again.
+//
+// Entries are keyed by the hash of the file content. An entry of a spec also records the concepts it uses, with the
+// hash of the files they are defined in, and the steps which were not concepts. It is used only if all those concept
+// files are unchanged and none of the steps has become a concept since, so a change in a concept file invalidates the
+// specs which depend on it, and no others.
+//
+// Specs and concepts which read other files while parsing, i.e. external data tables and special params, and specs
+// with parse errors are not cached.
+const (
+	parseCacheDir      = "cache"
+	specCacheDir       = "specs"
+	conceptCacheFile   = "concepts"
+	parseCacheVersion  = 1
+	specCacheRetention = 30 * 24 * time.Hour
+)
+
+// An entry is a line of JSON with the fields below, followed by the binary form of the snapshot of the spec or the
+// concept dictionary.
+type specCacheEntry struct {
+	Hash     string
+	Concepts map[string]string
+	Files    map[string]string
+	Steps    []string
+	Warnings []*Warning
+}
+
+type conceptCacheEntry struct {
+	Hash  string
+	Files map[string]string
+}
+
+// specCache looks up and stores the specs parsed against a concept dictionary. A nil specCache is a disabled cache.
+type specCache struct {
+	dir        string
+	dict       *gauge.ConceptDictionary
+	mutex      sync.Mutex
+	fileHashes map[string]string
+}
+
+func parseCacheEnabled() bool {
+	return env.EnableParseCache() && config.ProjectRoot != ""
+}
+
+func parseCachePath(elem ...string) string {
+	return filepath.Join(append([]string{config.ProjectRoot, common.DotGauge, parseCacheDir}, elem...)...)
+}
+
+// contentHash hashes the content along with the version of gauge and the settings which change how it is parsed.
+func contentHash(content string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00%s\x00%t\x00%t\x00", parseCacheVersion, version.FullVersion(), i18n.Language(), env.AllowMultiLineStep(), env.AllowScenarioDatatable())
+	h.Write([]byte(content))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func newSpecCache(dict *gauge.ConceptDictionary) *specCache {
+	if !parseCacheEnabled() {
+		return nil
+	}
+	return &specCache{dir: parseCachePath(specCacheDir), dict: dict, fileHashes: make(map[string]string)}
+}
+
+func (c *specCache) entryPath(specFile string) string {
+	if abs, err := filepath.Abs(specFile); err == nil {
+		specFile = abs
+	}
+	h := sha256.Sum256([]byte(specFile))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:]))
+}
+
+// fileHash gives the hash of the current content of a concept file, or an empty string if it cannot be read.
+func (c *specCache) fileHash(file string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if h, ok := c.fileHashes[file]; ok {
+		return h
+	}
+	h := ""
+	if content, err := common.ReadFileContents(file); err == nil {
+		h = contentHash(content)
+	}
+	c.fileHashes[file] = h
+	return h
+}
+
+func (c *specCache) get(specFile, content string) (*gauge.Specification, *ParseResult, bool) {
+	if c == nil {
+		return nil, nil, false
+	}
+	var entry specCacheEntry
+	b, err := readCacheEntry(c.entryPath(specFile), &entry)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debugf(true, "Discarding parse cache entry of %s. Reason: %s", specFile, err.Error())
+		}
+		return nil, nil, false
+	}
+	if entry.Hash != contentHash(content) || !c.dependenciesUnchanged(&entry) {
+		return nil, nil, false
+	}
+	var snapshot gauge.SpecSnapshot
+	if err := snapshot.UnmarshalBinary(b); err != nil {
+		logger.Debugf(true, "Discarding parse cache entry of %s. Reason: %s", specFile, err.Error())
+		return nil, nil, false
+	}
+	return snapshot.Specification(), &ParseResult{Ok: true, FileName: specFile, Warnings: entry.Warnings}, true
+}
+
+func (c *specCache) dependenciesUnchanged(entry *specCacheEntry) bool {
+	for value, file := range entry.Concepts {
+		if concept := c.dict.Search(value); concept == nil || concept.FileName != file {
+			return false
+		}
+	}
+	for file, hash := range entry.Files {
+		if c.fileHash(file) != hash {
+			return false
+		}
+	}
+	for _, value := range entry.Steps {
+		if c.dict.Search(value) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// put stores the spec, if it has no parse errors. It must not run while other specs are parsed against the same
+// dictionary, as the steps of concepts are shared between the specs which use them.
+func (c *specCache) put(specFile, content string, spec *gauge.Specification, res *ParseResult) {
+	if c == nil || spec == nil || !res.Ok || len(res.ParseErrors) > 0 || readsOtherFiles(spec) {
+		return
+	}
+	entry := &specCacheEntry{Hash: contentHash(content), Concepts: make(map[string]string), Files: make(map[string]string), Warnings: res.Warnings}
+	steps := make(map[string]bool)
+	c.collectDependencies(allSteps(spec), entry, steps)
+	for value := range steps {
+		entry.Steps = append(entry.Steps, value)
+	}
+	snapshot, err := gauge.NewSpecSnapshot(spec)
+	var b []byte
+	if err == nil {
+		b, err = snapshot.MarshalBinary()
+	}
+	if err != nil {
+		logger.Debugf(true, "Not caching %s. Reason: %s", specFile, err.Error())
+		return
+	}
+	if err := writeCacheEntry(c.entryPath(specFile), entry, b); err != nil {
+		logger.Debugf(true, "Unable to cache %s. Reason: %s", specFile, err.Error())
+	}
+}
+
+func (c *specCache) collectDependencies(steps []*gauge.Step, entry *specCacheEntry, plainSteps map[string]bool) {
+	for _, step := range steps {
+		if !step.IsConcept {
+			plainSteps[step.Value] = true
+			continue
+		}
+		if concept := c.dict.Search(step.Value); concept != nil {
+			entry.Concepts[step.Value] = concept.FileName
+			entry.Files[concept.FileName] = c.fileHash(concept.FileName)
+		}
+		c.collectDependencies(step.ConceptSteps, entry, plainSteps)
+	}
+}
+
+// prune removes the entries which were not written for a while, e.g. those of deleted specs.
+func (c *specCache) prune() {
+	if c == nil {
+		return
+	}
+	files, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if time.Since(f.ModTime()) > specCacheRetention {
+			os.Remove(filepath.Join(c.dir, f.Name()))
+		}
+	}
+}
+
+func allSteps(spec *gauge.Specification) []*gauge.Step {
+	steps := append([]*gauge.Step{}, spec.Contexts...)
+	for _, scenario := range spec.Scenarios {
+		steps = append(steps, scenario.Steps...)
+	}
+	return append(steps, spec.TearDownSteps...)
+}
+
+func readsOtherFiles(spec *gauge.Specification) bool {
+	if spec.DataTable.IsExternal {
+		return true
+	}
+	for _, scenario := range spec.Scenarios {
+		if scenario.DataTable.IsExternal {
+			return true
+		}
+	}
+	return stepsReadOtherFiles(allSteps(spec))
+}
+
+func stepsReadOtherFiles(steps []*gauge.Step) bool {
+	for _, step := range steps {
+		for _, arg := range step.Args {
+			if isSpecialArg(arg.ArgType) {
+				return true
+			}
+			for _, cells := range arg.Table.Columns {
+				for _, cell := range cells {
+					if isSpecialArg(cell.CellType) {
+						return true
+					}
+				}
+			}
+		}
+		if stepsReadOtherFiles(step.ConceptSteps) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpecialArg(argType gauge.ArgType) bool {
+	return argType == gauge.SpecialString || argType == gauge.SpecialTable
+}
+
+// cachedConcepts gives the concept dictionary of an earlier run if none of the concept files changed since.
+func cachedConcepts(conceptFiles []string) (*gauge.ConceptDictionary, map[string]string, bool) {
+	if !parseCacheEnabled() {
+		return nil, nil, false
+	}
+	files := make(map[string]string)
+	for _, file := range conceptFiles {
+		content, err := common.ReadFileContents(file)
+		if err != nil {
+			return nil, nil, false
+		}
+		files[file] = contentHash(content)
+	}
+	var entry conceptCacheEntry
+	b, err := readCacheEntry(parseCachePath(conceptCacheFile), &entry)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debugf(true, "Discarding parse cache entry of concepts. Reason: %s", err.Error())
+		}
+		return nil, files, false
+	}
+	if entry.Hash != contentHash("") || len(entry.Files) != len(files) {
+		return nil, files, false
+	}
+	for file, hash := range files {
+		if entry.Files[file] != hash {
+			return nil, files, false
+		}
+	}
+	var snapshot gauge.ConceptDictionarySnapshot
+	if err := snapshot.UnmarshalBinary(b); err != nil {
+		logger.Debugf(true, "Discarding parse cache entry of concepts. Reason: %s", err.Error())
+		return nil, files, false
+	}
+	return snapshot.ConceptDictionary(), files, true
+}
+
+func cacheConcepts(dict *gauge.ConceptDictionary, files map[string]string) {
+	if files == nil {
+		return
+	}
+	for _, concept := range dict.ConceptsMap {
+		if stepsReadOtherFiles([]*gauge.Step{concept.ConceptStep}) {
+			return
+		}
+	}
+	snapshot, err := gauge.NewConceptDictionarySnapshot(dict)
+	var b []byte
+	if err == nil {
+		b, err = snapshot.MarshalBinary()
+	}
+	if err != nil {
+		logger.Debugf(true, "Not caching concepts. Reason: %s", err.Error())
+		return
+	}
+	if err := writeCacheEntry(parseCachePath(conceptCacheFile), &conceptCacheEntry{Hash: contentHash(""), Files: files}, b); err != nil {
+		logger.Debugf(true, "Unable to cache concepts. Reason: %s", err.Error())
+	}
+}
+
+// readCacheEntry reads the JSON line of the entry into the given value and gives the snapshot which follows it.
+func readCacheEntry(path string, entry interface{}) ([]byte, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	i := bytes.IndexByte(b, '\n')
+	if i < 0 {
+		return nil, fmt.Errorf("entry %s has no snapshot", path)
+	}
+	if err := json.Unmarshal(b[:i], entry); err != nil {
+		return nil, err
+	}
+	return b[i+1:], nil
+}
+
+// writeCacheEntry writes the entry to a temporary file first, so that a parallel run never reads half an entry.
+func writeCacheEntry(path string, entry interface{}, snapshot []byte) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	b = append(append(b, '\n'), snapshot...)
+	if err := os.MkdirAll(filepath.Dir(path), common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), "entry")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,353 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+const enableParseCacheEnv = "enable_parse_cache"
+
+var cacheProjectFiles = map[string]string{
+	"concepts/login.cpt": `# Login as <user>
+* Enter user <user>
+* Submit
+
+# Logout
+* Click logout
+`,
+	"concepts/admin.cpt": `# Setup admin <name>
+* Login as <name>
+* Grant rights
+`,
+	"specs/login.spec": `---
+owner: payments
+---
+# Login
+tags: auth
+
+|user |
+|-----|
+|alice|
+|bob  |
+
+* Open app
+
+## Login as user
+tags: smoke
+* Login as <user>
+* Check "welcome" with table
+   |id|name|
+   |--|----|
+   |1 |a   |
+A comment
+* Logout
+
+___
+* Close app
+`,
+	"specs/admin.spec": `# Admin
+
+## Setup
+* Setup admin "root"
+* Verify rights
+`,
+}
+
+func writeProjectFile(c *C, dir, name, content string) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	c.Assert(os.MkdirAll(filepath.Dir(path), common.NewDirectoryPermissions), IsNil)
+	c.Assert(ioutil.WriteFile(path, []byte(content), common.NewFilePermissions), IsNil)
+}
+
+func newCacheProject(c *C) string {
+	dir, err := ioutil.TempDir("", "gauge-parse-cache")
+	c.Assert(err, IsNil)
+	for name, content := range cacheProjectFiles {
+		writeProjectFile(c, dir, name, content)
+	}
+	config.ProjectRoot = dir
+	return dir
+}
+
+func specFiles(dir string) []string {
+	return []string{filepath.Join(dir, "specs", "admin.spec"), filepath.Join(dir, "specs", "login.spec")}
+}
+
+func parseProject(c *C, dir string) ([]*gauge.Specification, *gauge.ConceptDictionary) {
+	dict, res, err := CreateConceptsDictionary()
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+	specs, results := ParseSpecFiles(specFiles(dir), dict, gauge.NewBuildErrors())
+	for _, res := range results {
+		c.Assert(res.Ok, Equals, true)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].FileName < specs[j].FileName })
+	return specs, dict
+}
+
+func cachedSpec(c *C, dict *gauge.ConceptDictionary, specFile string) (*gauge.Specification, bool) {
+	content, err := common.ReadFileContents(specFile)
+	c.Assert(err, IsNil)
+	spec, _, ok := newSpecCache(dict).get(specFile, content)
+	return spec, ok
+}
+
+func (s *MySuite) TestSpecSnapshotKeepsTheSpecification(c *C) {
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	specs, _ := parseProject(c, dir)
+
+	for _, spec := range specs {
+		snapshot, err := gauge.NewSpecSnapshot(spec)
+		c.Assert(err, IsNil)
+		b, err := snapshot.MarshalBinary()
+		c.Assert(err, IsNil)
+		var decoded gauge.SpecSnapshot
+		c.Assert(decoded.UnmarshalBinary(b), IsNil)
+
+		c.Assert(decoded.Specification(), DeepEquals, spec)
+	}
+}
+
+func (s *MySuite) TestSpecSnapshotKeepsSharedNodes(c *C) {
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	specs, _ := parseProject(c, dir)
+	snapshot, err := gauge.NewSpecSnapshot(specs[1])
+	c.Assert(err, IsNil)
+
+	spec := snapshot.Specification()
+
+	scenario := spec.Scenarios[0]
+	var dataTable *gauge.DataTable
+	var scenarioItem *gauge.Scenario
+	for _, item := range spec.Items {
+		switch i := item.(type) {
+		case *gauge.DataTable:
+			dataTable = i
+		case *gauge.Scenario:
+			scenarioItem = i
+		}
+	}
+	c.Assert(dataTable, Equals, &spec.DataTable)
+	c.Assert(scenarioItem, Equals, scenario)
+	concept := scenario.Steps[0]
+	c.Assert(concept.IsConcept, Equals, true)
+	c.Assert(scenario.Items[1], Equals, concept)
+	c.Assert(concept.ConceptSteps[0].Parent, Equals, concept)
+}
+
+func (s *MySuite) TestConceptDictionarySnapshotKeepsTheDictionary(c *C) {
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	dict, _, err := CreateConceptsDictionary()
+	c.Assert(err, IsNil)
+
+	snapshot, err := gauge.NewConceptDictionarySnapshot(dict)
+	c.Assert(err, IsNil)
+	b, err := snapshot.MarshalBinary()
+	c.Assert(err, IsNil)
+	var decoded gauge.ConceptDictionarySnapshot
+	c.Assert(decoded.UnmarshalBinary(b), IsNil)
+
+	c.Assert(decoded.ConceptDictionary(), DeepEquals, dict)
+}
+
+func (s *MySuite) TestParseCacheIsDisabledByDefault(c *C) {
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+
+	_, dict := parseProject(c, dir)
+
+	c.Assert(newSpecCache(dict), IsNil)
+	c.Assert(common.DirExists(filepath.Join(dir, common.DotGauge, parseCacheDir)), Equals, false)
+}
+
+func (s *MySuite) TestParseCacheReusesUnchangedSpecs(c *C) {
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+
+	specs, dict := parseProject(c, dir)
+
+	for i, file := range specFiles(dir) {
+		spec, ok := cachedSpec(c, dict, file)
+		c.Assert(ok, Equals, true)
+		c.Assert(spec, DeepEquals, specs[i])
+	}
+	specs, _ = parseProject(c, dir)
+	c.Assert(specs[1].Heading.Value, Equals, "Login")
+	c.Assert(specs[1].Metadata, DeepEquals, map[string]string{"owner": "payments"})
+}
+
+func (s *MySuite) TestParseCacheInvalidatesAChangedSpec(c *C) {
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	parseProject(c, dir)
+
+	writeProjectFile(c, dir, "specs/admin.spec", "# Admin\n\n## Setup\n* Verify rights\n")
+	specs, dict := parseProject(c, dir)
+
+	c.Assert(len(specs[0].Scenarios[0].Steps), Equals, 1)
+	_, ok := cachedSpec(c, dict, specFiles(dir)[0])
+	c.Assert(ok, Equals, true)
+}
+
+func (s *MySuite) TestParseCacheInvalidatesOnlySpecsUsingAChangedConcept(c *C) {
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	parseProject(c, dir)
+
+	writeProjectFile(c, dir, "concepts/admin.cpt", "# Setup admin <name>\n* Login as <name>\n* Grant rights\n* Audit\n")
+	dict, _, err := CreateConceptsDictionary()
+	c.Assert(err, IsNil)
+
+	_, ok := cachedSpec(c, dict, specFiles(dir)[0])
+	c.Assert(ok, Equals, false)
+	_, ok = cachedSpec(c, dict, specFiles(dir)[1])
+	c.Assert(ok, Equals, true)
+	specs, _ := parseProject(c, dir)
+	c.Assert(len(specs[0].Scenarios[0].Steps[0].ConceptSteps), Equals, 3)
+}
+
+func (s *MySuite) TestParseCacheInvalidatesSpecsWhoseStepBecomesAConcept(c *C) {
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	parseProject(c, dir)
+
+	writeProjectFile(c, dir, "concepts/rights.cpt", "# Verify rights\n* Open rights\n")
+	dict, _, err := CreateConceptsDictionary()
+	c.Assert(err, IsNil)
+
+	_, ok := cachedSpec(c, dict, specFiles(dir)[0])
+	c.Assert(ok, Equals, false)
+	_, ok = cachedSpec(c, dict, specFiles(dir)[1])
+	c.Assert(ok, Equals, true)
+	specs, _ := parseProject(c, dir)
+	c.Assert(specs[0].Scenarios[0].Steps[1].IsConcept, Equals, true)
+}
+
+func (s *MySuite) TestParseCacheDiscardsCorruptEntries(c *C) {
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	_, dict := parseProject(c, dir)
+	path := newSpecCache(dict).entryPath(specFiles(dir)[1])
+	b, err := ioutil.ReadFile(path)
+	c.Assert(err, IsNil)
+
+	c.Assert(ioutil.WriteFile(path, b[:len(b)-10], common.NewFilePermissions), IsNil)
+
+	_, ok := cachedSpec(c, dict, specFiles(dir)[1])
+	c.Assert(ok, Equals, false)
+	specs, _ := parseProject(c, dir)
+	c.Assert(specs[1].Heading.Value, Equals, "Login")
+	_, ok = cachedSpec(c, dict, specFiles(dir)[1])
+	c.Assert(ok, Equals, true)
+}
+
+func (s *MySuite) TestParseCacheSkipsSpecsReadingOtherFiles(c *C) {
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	writeProjectFile(c, dir, "specs/data.txt", "data")
+	writeProjectFile(c, dir, "specs/admin.spec", "# Admin\n\n## Setup\n* Read <file:"+filepath.Join(dir, "specs", "data.txt")+">\n")
+
+	_, dict := parseProject(c, dir)
+
+	_, ok := cachedSpec(c, dict, specFiles(dir)[0])
+	c.Assert(ok, Equals, false)
+	_, ok = cachedSpec(c, dict, specFiles(dir)[1])
+	c.Assert(ok, Equals, true)
+}
+
+func (s *MySuite) TestConceptsAreReusedUntilAConceptFileChanges(c *C) {
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dir := newCacheProject(c)
+	defer os.RemoveAll(dir)
+	dict, _, err := CreateConceptsDictionary()
+	c.Assert(err, IsNil)
+	files := []string{filepath.Join(dir, "concepts", "admin.cpt"), filepath.Join(dir, "concepts", "login.cpt")}
+
+	cached, _, ok := cachedConcepts(files)
+	c.Assert(ok, Equals, true)
+	c.Assert(cached, DeepEquals, dict)
+
+	writeProjectFile(c, dir, "concepts/admin.cpt", "# Setup admin <name>\n* Grant rights\n")
+	_, _, ok = cachedConcepts(files)
+	c.Assert(ok, Equals, false)
+}
+
+// writeBenchmarkProject writes a project of 200 specs with 10 scenarios each, using the concepts of two files.
+func writeBenchmarkProject(dir string) []string {
+	for name, content := range cacheProjectFiles {
+		if !strings.HasSuffix(name, ".cpt") {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), common.NewDirectoryPermissions)
+		ioutil.WriteFile(path, []byte(content), common.NewFilePermissions)
+	}
+	var files []string
+	for i := 0; i < 200; i++ {
+		spec := fmt.Sprintf("# Spec %d\ntags: bench\n\n* Open app\n", i)
+		for j := 0; j < 10; j++ {
+			spec += fmt.Sprintf("\n## Scenario %d\n* Setup admin \"user %d\"\n* Check \"%d\" with table\n   |id|name|\n   |--|----|\n   |1 |a   |\n* Logout\n", j, j, j)
+		}
+		file := filepath.Join(dir, "specs", fmt.Sprintf("spec%d.spec", i))
+		os.MkdirAll(filepath.Dir(file), common.NewDirectoryPermissions)
+		ioutil.WriteFile(file, []byte(spec), common.NewFilePermissions)
+		files = append(files, file)
+	}
+	return files
+}
+
+func BenchmarkParseSpecFiles(b *testing.B) {
+	dir, err := ioutil.TempDir("", "gauge-parse-cache")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config.ProjectRoot = dir
+	defer func() { config.ProjectRoot = "" }()
+	files := writeBenchmarkProject(dir)
+	parse := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dict, _, _ := CreateConceptsDictionary()
+			ParseSpecFiles(files, dict, gauge.NewBuildErrors())
+		}
+	}
+
+	b.Run("uncached", parse)
+	os.Setenv(enableParseCacheEnv, "true")
+	defer os.Unsetenv(enableParseCacheEnv)
+	dict, _, _ := CreateConceptsDictionary()
+	ParseSpecFiles(files, dict, gauge.NewBuildErrors())
+	b.Run("cached", parse)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/util"
 )
@@ -57,7 +58,7 @@ func (parser *ConceptParser) createConcepts(tokens []*Token, fileName string) ([
 		if parser.isConceptHeading(token) {
 			if isInState(parser.currentState, conceptScope, stepScope) {
 				if len(parser.currentConcept.ConceptSteps) < 1 {
-					parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: parser.currentConcept.LineNo, SpanEnd: parser.currentConcept.LineSpanEnd, Message: "Concept should have atleast one step", LineText: parser.currentConcept.LineText})
+					parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: parser.currentConcept.LineNo, SpanEnd: parser.currentConcept.LineSpanEnd, Message: i18n.T("Concept should have atleast one step"), LineText: parser.currentConcept.LineText})
 					continue
 				}
 				concepts = append(concepts, parser.currentConcept)
@@ -76,7 +77,7 @@ func (parser *ConceptParser) createConcepts(tokens []*Token, fileName string) ([
 			addStates(&parser.currentState, conceptScope)
 		} else if parser.isStep(token) {
 			if !isInState(parser.currentState, conceptScope) {
-				parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: "Step is not defined inside a concept heading", LineText: token.LineText()})
+				parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: i18n.T("Step is not defined inside a concept heading"), LineText: token.LineText()})
 				continue
 			}
 			if errs := parser.processConceptStep(token, fileName); len(errs) > 0 {
@@ -85,13 +86,13 @@ func (parser *ConceptParser) createConcepts(tokens []*Token, fileName string) ([
 			addStates(&parser.currentState, stepScope)
 		} else if parser.isTableHeader(token) {
 			if !isInState(parser.currentState, stepScope) {
-				parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: "Table doesn't belong to any step", LineText: token.LineText()})
+				parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: i18n.T("Table doesn't belong to any step"), LineText: token.LineText()})
 				continue
 			}
 			parser.processTableHeader(token)
 			addStates(&parser.currentState, tableScope)
 		} else if parser.isScenarioHeading(token) {
-			parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: "Scenario Heading is not allowed in concept file", LineText: token.LineText()})
+			parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: i18n.T("Scenario Heading is not allowed in concept file"), LineText: token.LineText()})
 			continue
 		} else if parser.isTableDataRow(token) {
 			if areUnderlined(token.Args) && !isInState(parser.currentState, tableSeparatorScope) {
@@ -112,7 +113,7 @@ func (parser *ConceptParser) createConcepts(tokens []*Token, fileName string) ([
 		}
 	}
 	if parser.currentConcept != nil && len(parser.currentConcept.ConceptSteps) < 1 {
-		parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: parser.currentConcept.LineNo, SpanEnd: parser.currentConcept.LineSpanEnd, Message: "Concept should have atleast one step", LineText: parser.currentConcept.LineText})
+		parseRes.ParseErrors = append(parseRes.ParseErrors, ParseError{FileName: fileName, LineNo: parser.currentConcept.LineNo, SpanEnd: parser.currentConcept.LineSpanEnd, Message: i18n.T("Concept should have atleast one step"), LineText: parser.currentConcept.LineText})
 		return nil, parseRes
 	}
 
@@ -152,7 +153,7 @@ func (parser *ConceptParser) processConceptHeading(token *Token, fileName string
 		return nil, parseRes
 	}
 	if !parser.hasOnlyDynamicParams(concept) {
-		parseRes.ParseErrors = []ParseError{ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: "Concept heading can have only Dynamic Parameters", LineText: token.LineText()}}
+		parseRes.ParseErrors = []ParseError{ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: i18n.T("Concept heading can have only Dynamic Parameters"), LineText: token.LineText()}}
 		return nil, parseRes
 	}
 
@@ -214,6 +215,10 @@ func CreateConceptsDictionary() (*gauge.ConceptDictionary, *ParseResult, error)
 	for cpt := range cptFilesMap {
 		conceptFiles = append(conceptFiles, cpt)
 	}
+	cached, fileHashes, ok := cachedConcepts(conceptFiles)
+	if ok {
+		return cached, &ParseResult{Ok: true}, nil
+	}
 	conceptsDictionary := gauge.NewConceptDictionary()
 	res := &ParseResult{Ok: true}
 	if _, errs, e := AddConcepts(conceptFiles, conceptsDictionary); len(errs) > 0 {
@@ -231,6 +236,9 @@ func CreateConceptsDictionary() (*gauge.ConceptDictionary, *ParseResult, error)
 		res.Ok = false
 		res.ParseErrors = append(res.ParseErrors, vRes.ParseErrors...)
 	}
+	if res.Ok {
+		cacheConcepts(conceptsDictionary, fileHashes)
+	}
 	return conceptsDictionary, res, nil
 }
 
@@ -243,14 +251,14 @@ func AddConcept(concepts []*gauge.Step, file string, conceptDictionary *gauge.Co
 				FileName: file,
 				LineNo:   conceptStep.LineNo,
 				SpanEnd:  conceptStep.LineSpanEnd,
-				Message:  "Duplicate concept definition found",
+				Message:  i18n.T("Duplicate concept definition found"),
 				LineText: conceptStep.LineText,
 			},
 				ParseError{
 					FileName: dupConcept.FileName,
 					LineNo:   dupConcept.ConceptStep.LineNo,
 					SpanEnd:  conceptStep.LineSpanEnd,
-					Message:  "Duplicate concept definition found",
+					Message:  i18n.T("Duplicate concept definition found"),
 					LineText: dupConcept.ConceptStep.LineText,
 				})
 		}
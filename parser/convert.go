@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 	"github.com/getgauge/gauge/util"
 )
 
@@ -21,7 +23,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		return token.Kind == gauge.SpecKind
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		if spec.Heading != nil {
-			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, token.SpanEnd, "Multiple spec headings found in same file", token.LineText()}}}
+			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: "Multiple spec headings found in same file", LineText: token.LineText()}}}
 		}
 
 		spec.AddHeading(&gauge.Heading{LineNo: token.LineNo, Value: token.Value, SpanEnd: token.SpanEnd})
@@ -33,11 +35,11 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		return token.Kind == gauge.ScenarioKind
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		if spec.Heading == nil {
-			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, token.SpanEnd, "Scenario should be defined after the spec heading", token.LineText()}}}
+			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: "Scenario should be defined after the spec heading", LineText: token.LineText()}}}
 		}
 		for _, scenario := range spec.Scenarios {
 			if strings.EqualFold(scenario.Heading.Value, token.Value) {
-				return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, token.SpanEnd, "Duplicate scenario definition '" + scenario.Heading.Value + "' found in the same specification", token.LineText()}}}
+				return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: "Duplicate scenario definition '" + scenario.Heading.Value + "' found in the same specification", LineText: token.LineText(), Code: DuplicateScenarioCode}}}
 			}
 		}
 		scenario := &gauge.Scenario{Span: &gauge.Span{Start: token.LineNo, End: token.LineNo}}
@@ -125,13 +127,26 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		return token.Kind == gauge.CommentKind
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		comment := &gauge.Comment{Value: token.Value, LineNo: token.LineNo}
+		var errs []ParseError
 		if isInState(*state, scenarioScope) {
-			spec.LatestScenario().AddComment(comment)
+			scenario := spec.LatestScenario()
+			scenario.AddComment(comment)
+			if isSkipDirective(token.Value) {
+				directive, err := parseSkipDirective(token.Value, token.LineNo)
+				if err != nil {
+					errs = append(errs, ParseError{FileName: spec.FileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: err.Error(), LineText: token.LineText()})
+				} else {
+					scenario.AddSkipDirective(directive)
+				}
+			}
 		} else {
 			spec.AddComment(comment)
 		}
 		retainStates(state, specScope, scenarioScope, tearDownScope)
 		addStates(state, commentScope)
+		if len(errs) > 0 {
+			return ParseResult{Ok: false, ParseErrors: errs}
+		}
 		return ParseResult{Ok: true}
 	})
 
@@ -140,7 +155,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		resolvedArg, err := newSpecialTypeResolver().resolve(token.Value)
 		if resolvedArg == nil || err != nil {
-			e := ParseError{FileName: spec.FileName, LineNo: token.LineNo, LineText: token.LineText(), Message: fmt.Sprintf("Could not resolve table from %s", token.LineText())}
+			e := ParseError{FileName: spec.FileName, LineNo: token.LineNo, LineText: token.LineText(), Message: fmt.Sprintf(i18n.T("Could not resolve table from %s"), token.LineText())}
 			return ParseResult{ParseErrors: []ParseError{e}, Ok: false}
 		}
 		if isInAnyState(*state, scenarioScope) {
@@ -274,6 +289,17 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		return result
 	})
 
+	frontMatterConverter := converterFn(func(token *Token, state *int) bool {
+		return token.Kind == gauge.FrontMatterKind
+	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
+		metadata, errs := parseFrontMatter(token, spec.FileName)
+		spec.AddFrontMatter(&gauge.FrontMatter{LineNo: token.LineNo, Lines: token.Lines}, metadata)
+		if len(errs) > 0 {
+			return ParseResult{Ok: false, ParseErrors: errs}
+		}
+		return ParseResult{Ok: true}
+	})
+
 	tagConverter := converterFn(func(token *Token, state *int) bool {
 		return (token.Kind == gauge.TagKind)
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
@@ -283,7 +309,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 				spec.LatestScenario().Tags.Add(tags.RawValues[0])
 			} else {
 				if spec.LatestScenario().NTags() != 0 {
-					return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, Message: "Tags can be defined only once per scenario", LineText: token.LineText()}}}
+					return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, Message: i18n.T("Tags can be defined only once per scenario"), LineText: token.LineText()}}}
 				}
 				spec.LatestScenario().AddTags(tags)
 			}
@@ -292,7 +318,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 				spec.Tags.Add(tags.RawValues[0])
 			} else {
 				if spec.NTags() != 0 {
-					return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, Message: "Tags can be defined only once per specification", LineText: token.LineText()}}}
+					return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{FileName: spec.FileName, LineNo: token.LineNo, Message: i18n.T("Tags can be defined only once per specification"), LineText: token.LineText()}}}
 				}
 				spec.AddTags(tags)
 			}
@@ -302,7 +328,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	})
 
 	converter := []func(*Token, *int, *gauge.Specification) ParseResult{
-		specConverter, scenarioConverter, stepConverter, contextConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, keywordConverter, tearDownConverter, tearDownStepConverter,
+		frontMatterConverter, specConverter, scenarioConverter, stepConverter, contextConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, keywordConverter, tearDownConverter, tearDownStepConverter,
 	}
 
 	return converter
@@ -334,9 +360,22 @@ func addInlineTableRow(step *gauge.Step, token *Token, argLookup *gauge.ArgLooku
 	return ParseResult{Ok: true, Warnings: warnings}
 }
 
+var dynamicArgMatcher = regexp.MustCompile("^<(.*)>$")
+
+// specialArgMatchers holds the matcher of special params in table cells for each localized file keyword.
+var specialArgMatchers sync.Map
+
+func tableSpecialArgMatcher() *regexp.Regexp {
+	keyword := i18n.CurrentKeywords().File
+	if m, ok := specialArgMatchers.Load(keyword); ok {
+		return m.(*regexp.Regexp)
+	}
+	m, _ := specialArgMatchers.LoadOrStore(keyword, regexp.MustCompile(fmt.Sprintf("^<((?:file|(?i:%s))\\s*:(.*))>$", regexp.QuoteMeta(keyword))))
+	return m.(*regexp.Regexp)
+}
+
 func validateTableRows(token *Token, argLookup *gauge.ArgLookup, fileName string) ([]gauge.TableCell, []*Warning, []ParseError) {
-	dynamicArgMatcher := regexp.MustCompile("^<(.*)>$")
-	specialArgMatcher := regexp.MustCompile("^<(file:.*)>$")
+	specialArgMatcher := tableSpecialArgMatcher()
 	tableValues := make([]gauge.TableCell, 0)
 	warnings := make([]*Warning, 0)
 	error := make([]ParseError, 0)
@@ -344,17 +383,17 @@ func validateTableRows(token *Token, argLookup *gauge.ArgLookup, fileName string
 		if specialArgMatcher.MatchString(tableValue) {
 			match := specialArgMatcher.FindAllStringSubmatch(tableValue, -1)
 			param := match[0][1]
-			file := strings.TrimSpace(strings.TrimPrefix(param, "file:"))
+			file := strings.TrimSpace(match[0][2])
 			tableValues = append(tableValues, gauge.TableCell{Value: param, CellType: gauge.SpecialString})
 			if _, err := util.GetFileContents(file); err != nil {
-				error = append(error, ParseError{FileName: fileName, LineNo: token.LineNo, Message: fmt.Sprintf("Dynamic param <%s> could not be resolved, Missing file: %s", param, file), LineText: token.LineText()})
+				error = append(error, ParseError{FileName: fileName, LineNo: token.LineNo, Message: fmt.Sprintf(i18n.T("Dynamic param <%s> could not be resolved, Missing file: %s"), param, file), LineText: token.LineText()})
 			}
 		} else if dynamicArgMatcher.MatchString(tableValue) {
 			match := dynamicArgMatcher.FindAllStringSubmatch(tableValue, -1)
 			param := match[0][1]
 			if !argLookup.ContainsArg(param) {
 				tableValues = append(tableValues, gauge.TableCell{Value: tableValue, CellType: gauge.Static})
-				warnings = append(warnings, &Warning{FileName: fileName, LineNo: token.LineNo, Message: fmt.Sprintf("Dynamic param <%s> could not be resolved, Treating it as static param", param)})
+				warnings = append(warnings, &Warning{FileName: fileName, LineNo: token.LineNo, Message: fmt.Sprintf(i18n.T("Dynamic param <%s> could not be resolved, Treating it as static param"), param)})
 			} else {
 				tableValues = append(tableValues, gauge.TableCell{Value: param, CellType: gauge.Dynamic})
 			}
@@ -0,0 +1,84 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
+)
+
+const (
+	yamlFrontMatterDelimiter = "---"
+	tomlFrontMatterDelimiter = "+++"
+)
+
+func isFrontMatterDelimiter(text string) bool {
+	return text == yamlFrontMatterDelimiter || text == tomlFrontMatterDelimiter
+}
+
+// frontMatterToken reads the lines of a front matter block up to the closing delimiter.
+// The opening delimiter has already been read.
+func (parser *SpecParser) frontMatterToken(line string) (*Token, error) {
+	delimiter := strings.TrimSpace(line)
+	token := &Token{Kind: gauge.FrontMatterKind, LineNo: parser.lineNo, Lines: []string{line}, Value: delimiter}
+	for l, hasLine, err := parser.nextLine(); hasLine; l, hasLine, err = parser.nextLine() {
+		if err != nil {
+			return token, err
+		}
+		token.Lines = append(token.Lines, l)
+		if strings.TrimSpace(l) == delimiter {
+			token.SpanEnd = parser.lineNo
+			return token, nil
+		}
+	}
+	token.SpanEnd = parser.lineNo
+	return token, fmt.Errorf(i18n.T("Front matter should be closed with '%s'"), delimiter)
+}
+
+// parseFrontMatter reads the `key: value` (YAML style) or `key = value` (TOML style) entries of a front matter block.
+// Only flat entries with scalar values are supported. Blank lines and lines starting with '#' are ignored.
+func parseFrontMatter(token *Token, fileName string) (map[string]string, []ParseError) {
+	separator := ":"
+	if token.Value == tomlFrontMatterDelimiter {
+		separator = "="
+	}
+	metadata := make(map[string]string)
+	var errs []ParseError
+	lines := token.Lines[1:]
+	if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == token.Value {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lineNo := token.LineNo + i + 1
+		parts := strings.SplitN(trimmed, separator, 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			errs = append(errs, ParseError{FileName: fileName, LineNo: lineNo, SpanEnd: lineNo, Message: fmt.Sprintf(i18n.T("Front matter entry should be of the form 'key %s value'"), separator), LineText: line})
+			continue
+		}
+		if _, exists := metadata[key]; exists {
+			errs = append(errs, ParseError{FileName: fileName, LineNo: lineNo, SpanEnd: lineNo, Message: fmt.Sprintf(i18n.T("Duplicate front matter key '%s'"), key), LineText: line})
+			continue
+		}
+		metadata[key] = unquote(strings.TrimSpace(parts[1]))
+	}
+	return metadata, errs
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -9,12 +9,12 @@ package parser
 import (
 	"bufio"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 )
 
 const (
@@ -59,6 +59,7 @@ func (parser *SpecParser) initialize() {
 	parser.processors[gauge.TableRow] = processTable
 	parser.processors[gauge.DataTableKind] = processDataTable
 	parser.processors[gauge.TearDownKind] = processTearDown
+	parser.processors[gauge.FrontMatterKind] = processFrontMatter
 }
 
 // GenerateTokens gets tokens based on the parsed line.
@@ -75,7 +76,12 @@ func (parser *SpecParser) GenerateTokens(specText, fileName string) ([]*Token, [
 			return nil, errors
 		}
 		trimmedLine := strings.TrimSpace(line)
-		if len(trimmedLine) == 0 {
+		if parser.lineNo == 1 && isFrontMatterDelimiter(trimmedLine) {
+			var fmErr error
+			if newToken, fmErr = parser.frontMatterToken(line); fmErr != nil {
+				errors = append(errors, ParseError{FileName: fileName, LineNo: newToken.LineNo, SpanEnd: newToken.SpanEnd, Message: fmErr.Error(), LineText: line})
+			}
+		} else if len(trimmedLine) == 0 {
 			addStates(&parser.currentState, newLineScope)
 			if newToken != nil && newToken.Kind == gauge.StepKind {
 				newToken.Suffix = "\n"
@@ -149,13 +155,8 @@ func (parser *SpecParser) tokenKindBasedOnCurrentState(state int, matchingToken
 }
 
 func (parser *SpecParser) checkTag(text string) (bool, int) {
-	lowerCased := strings.ToLower
-	tagColon := "tags:"
-	tagSpaceColon := "tags :"
-	if tagStartIndex := strings.Index(lowerCased(text), tagColon); tagStartIndex == 0 {
-		return true, len(tagColon)
-	} else if tagStartIndex := strings.Index(lowerCased(text), tagSpaceColon); tagStartIndex == 0 {
-		return true, len(tagSpaceColon)
+	if rest, ok := i18n.TrimTagsKeyword(text); ok {
+		return true, len(text) - len(rest)
 	}
 	return false, -1
 }
@@ -196,7 +197,7 @@ func (parser *SpecParser) isTableRow(text string) bool {
 }
 
 func (parser *SpecParser) isTearDown(text string) bool {
-	return isUnderline(text, rune('_'))
+	return isUnderline(text, rune('_')) || i18n.IsTearDownKeyword(text)
 }
 
 func (parser *SpecParser) isSpecUnderline(text string) bool {
@@ -204,11 +205,8 @@ func (parser *SpecParser) isSpecUnderline(text string) bool {
 }
 
 func (parser *SpecParser) isDataTable(text string) (string, bool) {
-	if regexp.MustCompile(`^\s*[tT][aA][bB][lL][eE]\s*:(\s*)`).FindIndex([]byte(text)) != nil {
-		index := strings.Index(text, ":")
-		if index != -1 {
-			return "table:" + " " + strings.TrimSpace(strings.SplitAfterN(text, ":", 2)[1]), true
-		}
+	if location, ok := i18n.TrimTableKeyword(text); ok {
+		return "table:" + " " + strings.TrimSpace(location), true
 	}
 	return "", false
 }
@@ -690,3 +690,92 @@ func (s *MySuite) TestParsingSpecWithTearDownSteps(c *C) {
 	c.Assert(tokens[6].Kind, Equals, gauge.StepKind)
 	c.Assert(tokens[6].Value, Equals, "step2")
 }
+
+func (s *MySuite) TestParsingSpecWithLocalizedKeywords(c *C) {
+	oldSpecLanguage := env.SpecLanguage
+	env.SpecLanguage = func() string { return "de" }
+	defer func() { env.SpecLanguage = oldSpecLanguage }()
+	parser := new(SpecParser)
+	specText := newSpecBuilder().specHeading("Spezifikation").
+		text("Tags: schnell, langsam").
+		text("Tabelle: daten/foo.csv").
+		scenarioHeading("Szenario").
+		step("Schritt").
+		text("Aufräumen:").
+		step("step1").String()
+
+	tokens, err := parser.GenerateTokens(specText, "")
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 7)
+
+	c.Assert(tokens[1].Kind, Equals, gauge.TagKind)
+	c.Assert(tokens[1].Args, DeepEquals, []string{"schnell", "langsam"})
+	c.Assert(tokens[2].Kind, Equals, gauge.DataTableKind)
+	c.Assert(tokens[2].Value, Equals, "table: daten/foo.csv")
+	c.Assert(tokens[5].Kind, Equals, gauge.TearDownKind)
+}
+
+func (s *MySuite) TestParsingSpecWithEnglishKeywordsWhenLanguageIsLocalized(c *C) {
+	oldSpecLanguage := env.SpecLanguage
+	env.SpecLanguage = func() string { return "ja" }
+	defer func() { env.SpecLanguage = oldSpecLanguage }()
+	parser := new(SpecParser)
+	specText := newSpecBuilder().specHeading("Spec heading").
+		text("tags: foo").
+		text("テーブル: data/foo.csv").
+		scenarioHeading("Scenario Heading").
+		step("step").String()
+
+	tokens, err := parser.GenerateTokens(specText, "")
+	c.Assert(err, IsNil)
+	c.Assert(tokens[1].Kind, Equals, gauge.TagKind)
+	c.Assert(tokens[2].Kind, Equals, gauge.DataTableKind)
+	c.Assert(tokens[2].Value, Equals, "table: data/foo.csv")
+}
+
+func (s *MySuite) TestParsingSpecWithFrontMatter(c *C) {
+	parser := new(SpecParser)
+	specText := "---\nowner: payments\npriority: \"high\"\n---\n" + newSpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("step").String()
+
+	tokens, err := parser.GenerateTokens(specText, "")
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 4)
+	c.Assert(tokens[0].Kind, Equals, gauge.FrontMatterKind)
+	c.Assert(tokens[0].LineNo, Equals, 1)
+	c.Assert(tokens[0].SpanEnd, Equals, 4)
+	c.Assert(tokens[0].Lines, DeepEquals, []string{"---", "owner: payments", "priority: \"high\"", "---"})
+	c.Assert(tokens[1].Kind, Equals, gauge.SpecKind)
+	c.Assert(tokens[1].LineNo, Equals, 5)
+}
+
+func (s *MySuite) TestParsingUnclosedFrontMatterShouldGiveError(c *C) {
+	parser := new(SpecParser)
+	specText := "+++\nowner = \"payments\"\n" + newSpecBuilder().specHeading("Spec heading").String()
+
+	_, errs := parser.GenerateTokens(specText, "foo.spec")
+	c.Assert(len(errs), Equals, 1)
+	c.Assert(errs[0].LineNo, Equals, 1)
+	c.Assert(errs[0].Message, Equals, "Front matter should be closed with '+++'")
+}
+
+func (s *MySuite) TestUnclosedFrontMatterErrorIsLocalized(c *C) {
+	oldSpecLanguage := env.SpecLanguage
+	env.SpecLanguage = func() string { return "de" }
+	defer func() { env.SpecLanguage = oldSpecLanguage }()
+	parser := new(SpecParser)
+	specText := "+++\nowner = \"payments\"\n" + newSpecBuilder().specHeading("Spec heading").String()
+
+	_, errs := parser.GenerateTokens(specText, "foo.spec")
+	c.Assert(len(errs), Equals, 1)
+	c.Assert(errs[0].Message, Equals, "Der Front Matter muss mit '+++' abgeschlossen werden")
+}
+
+func (s *MySuite) TestFrontMatterDelimiterIsOnlyRecognisedOnFirstLine(c *C) {
+	parser := new(SpecParser)
+	specText := newSpecBuilder().specHeading("Spec heading").text("---").String()
+
+	tokens, err := parser.GenerateTokens(specText, "")
+	c.Assert(err, IsNil)
+	c.Assert(len(tokens), Equals, 2)
+	c.Assert(tokens[1].Kind, Equals, gauge.CommentKind)
+}
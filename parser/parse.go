@@ -47,28 +47,30 @@ import (
 type parseInfo struct {
 	parseResult *ParseResult
 	spec        *gauge.Specification
+	// content is set for specs which were parsed rather than taken from the parse cache, to be cached afterwards.
+	content string
 }
 
 func newParseInfo(spec *gauge.Specification, pr *ParseResult) *parseInfo {
 	return &parseInfo{spec: spec, parseResult: pr}
 }
 
-func parse(wg *sync.WaitGroup, sfc *specFileCollection, cpt *gauge.ConceptDictionary, piChan chan *parseInfo) {
+func parse(wg *sync.WaitGroup, sfc *specFileCollection, cpt *gauge.ConceptDictionary, cache *specCache, piChan chan *parseInfo) {
 	defer wg.Done()
 	for {
 		if s, err := sfc.Next(); err == nil {
-			piChan <- newParseInfo(parseSpec(s, cpt))
+			piChan <- parseSpec(s, cpt, cache)
 		} else {
 			return
 		}
 	}
 }
 
-func parseSpecFiles(sfc *specFileCollection, conceptDictionary *gauge.ConceptDictionary, piChan chan *parseInfo, limit int) {
+func parseSpecFiles(sfc *specFileCollection, conceptDictionary *gauge.ConceptDictionary, cache *specCache, piChan chan *parseInfo, limit int) {
 	wg := &sync.WaitGroup{}
 	for i := 0; i < limit; i++ {
 		wg.Add(1)
-		go parse(wg, sfc, conceptDictionary, piChan)
+		go parse(wg, sfc, conceptDictionary, cache, piChan)
 	}
 	wg.Wait()
 	close(piChan)
@@ -84,10 +86,15 @@ func ParseSpecFiles(specFiles []string, conceptDictionary *gauge.ConceptDictiona
 			"Starting %d routines for parallel parsing.", limit, rLimit, rLimit/2)
 		limit = rLimit / 2
 	}
-	go parseSpecFiles(sfc, conceptDictionary, piChan, limit)
+	cache := newSpecCache(conceptDictionary)
+	go parseSpecFiles(sfc, conceptDictionary, cache, piChan, limit)
 	var parseResults []*ParseResult
 	var specs []*gauge.Specification
+	var parsed []*parseInfo
 	for r := range piChan {
+		if cache != nil && r.content != "" {
+			parsed = append(parsed, r)
+		}
 		if r.spec != nil {
 			specs = append(specs, r.spec)
 			var parseErrs []error
@@ -100,9 +107,35 @@ func ParseSpecFiles(specFiles []string, conceptDictionary *gauge.ConceptDictiona
 		}
 		parseResults = append(parseResults, r.parseResult)
 	}
+	cacheSpecs(cache, parsed, limit)
 	return specs, parseResults
 }
 
+// cacheSpecs stores the specs which were parsed. This waits for the parsing to finish, as the specs share the steps of
+// the concepts they use.
+func cacheSpecs(cache *specCache, parsed []*parseInfo, limit int) {
+	if len(parsed) == 0 {
+		return
+	}
+	piChan := make(chan *parseInfo)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < limit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range piChan {
+				cache.put(r.parseResult.FileName, r.content, r.spec, r.parseResult)
+			}
+		}()
+	}
+	for _, r := range parsed {
+		piChan <- r
+	}
+	close(piChan)
+	wg.Wait()
+	cache.prune()
+}
+
 // ParseSpecs parses specs in the give directory and gives specification and pass/fail status, used in validation.
 func ParseSpecs(args []string, conceptsDictionary *gauge.ConceptDictionary, buildErrors *gauge.BuildErrors) ([]*gauge.Specification, bool) {
 	specs, failed := parseSpecsInDirs(conceptsDictionary, args, buildErrors)
@@ -122,16 +155,21 @@ func ParseConcepts() (*gauge.ConceptDictionary, *ParseResult, error) {
 	return conceptsDictionary, conceptParseResult, nil
 }
 
-func parseSpec(specFile string, conceptDictionary *gauge.ConceptDictionary) (*gauge.Specification, *ParseResult) {
+func parseSpec(specFile string, conceptDictionary *gauge.ConceptDictionary, cache *specCache) *parseInfo {
 	specFileContent, err := common.ReadFileContents(specFile)
 	if err != nil {
-		return nil, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: specFile, Message: err.Error()}}, Ok: false}
+		return newParseInfo(nil, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: specFile, Message: err.Error()}}, Ok: false})
+	}
+	if spec, parseResult, ok := cache.get(specFile, specFileContent); ok {
+		return newParseInfo(spec, parseResult)
 	}
 	spec, parseResult, err := new(SpecParser).Parse(specFileContent, conceptDictionary, specFile)
 	if err != nil {
 		logger.Fatalf(true, err.Error())
 	}
-	return spec, parseResult
+	pi := newParseInfo(spec, parseResult)
+	pi.content = specFileContent
+	return pi
 }
 
 type specFile struct {
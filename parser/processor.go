@@ -8,10 +8,11 @@ package parser
 
 import (
 	"bytes"
-	"fmt"
+	"errors"
 	"strings"
 
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 )
 
 func processSpec(parser *SpecParser, token *Token) ([]error, bool) {
@@ -20,21 +21,26 @@ func processSpec(parser *SpecParser, token *Token) ([]error, bool) {
 
 func processTearDown(parser *SpecParser, token *Token) ([]error, bool) {
 	if len(token.Value) < 3 {
-		return []error{fmt.Errorf("Teardown should have at least three underscore characters")}, true
+		return []error{errors.New(i18n.T("Teardown should have at least three underscore characters"))}, true
 	}
 	return []error{}, false
 }
 
+func processFrontMatter(parser *SpecParser, token *Token) ([]error, bool) {
+	parser.clearState()
+	return []error{}, false
+}
+
 func processDataTable(parser *SpecParser, token *Token) ([]error, bool) {
 	if len(strings.TrimSpace(strings.Replace(token.Value, "table:", "", 1))) == 0 {
-		return []error{fmt.Errorf("Table location not specified")}, true
+		return []error{errors.New(i18n.T("Table location not specified"))}, true
 	}
 	return []error{}, false
 }
 
 func processScenario(parser *SpecParser, token *Token) ([]error, bool) {
 	if len(strings.TrimSpace(token.Value)) < 1 {
-		return []error{fmt.Errorf("Scenario heading should have at least one character")}, true
+		return []error{errors.New(i18n.T("Scenario heading should have at least one character"))}, true
 	}
 	parser.clearState()
 	return []error{}, false
@@ -86,9 +92,9 @@ func processTable(parser *SpecParser, token *Token) ([]error, bool) {
 
 			if token.Kind == gauge.TableHeader {
 				if len(trimmedValue) == 0 {
-					errs = append(errs, fmt.Errorf("Table header should not be blank"))
+					errs = append(errs, errors.New(i18n.T("Table header should not be blank")))
 				} else if arrayContains(token.Args, trimmedValue) {
-					errs = append(errs, fmt.Errorf("Table header cannot have repeated column values"))
+					errs = append(errs, errors.New(i18n.T("Table header cannot have repeated column values")))
 				}
 			}
 			token.Args = append(token.Args, trimmedValue)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 	"github.com/getgauge/gauge/util"
 )
 
@@ -163,12 +164,13 @@ func initializePredefinedResolvers() map[string]resolverFn {
 	}
 }
 
+var specialTypePattern = regexp.MustCompile("(.*?):(.*)")
+
 func (resolver *specialTypeResolver) resolve(arg string) (*gauge.StepArg, error) {
 	if util.IsWindows() {
 		arg = GetUnescapedString(arg)
 	}
-	regEx := regexp.MustCompile("(.*?):(.*)")
-	match := regEx.FindAllStringSubmatch(arg, -1)
+	match := specialTypePattern.FindAllStringSubmatch(arg, -1)
 	specialType := strings.TrimSpace(match[0][1])
 	value := strings.TrimSpace(match[0][2])
 	stepArg, err := resolver.getStepArg(specialType, value, arg)
@@ -179,7 +181,7 @@ func (resolver *specialTypeResolver) resolve(arg string) (*gauge.StepArg, error)
 }
 
 func (resolver *specialTypeResolver) getStepArg(specialType string, value string, arg string) (*gauge.StepArg, error) {
-	resolveFunc, found := resolver.predefinedResolvers[specialType]
+	resolveFunc, found := resolver.predefinedResolvers[i18n.CanonicalSpecialType(specialType)]
 	if found {
 		return resolveFunc(value)
 	}
@@ -8,6 +8,12 @@ package parser
 
 import "fmt"
 
+// Codes of the parse errors which tools act on, like the quick fixes of the language server.
+const (
+	DuplicateScenarioCode = "duplicate-scenario"
+	EmptyScenarioCode     = "empty-scenario"
+)
+
 // ParseError holds information about a parse failure
 type ParseError struct {
 	FileName string
@@ -15,6 +21,8 @@ type ParseError struct {
 	SpanEnd  int
 	Message  string
 	LineText string
+	// Code identifies the kind of the error irrespective of the language of the message. It is empty for most errors.
+	Code string
 }
 
 // Error prints error with filename, line number, error message and step text.
@@ -0,0 +1,90 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/getgauge/gauge/filter"
+	"github.com/getgauge/gauge/gauge"
+)
+
+const (
+	skipDirectivePrefix   = "@skip"
+	skipIfDirectivePrefix = "@skip-if"
+)
+
+// isSkipDirective reports whether a comment line of a scenario is meant to be a skip directive.
+func isSkipDirective(text string) bool {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, skipDirectivePrefix) {
+		return false
+	}
+	rest := text[len(skipDirectivePrefix):]
+	return rest == "" || strings.HasPrefix(rest, "(") || strings.HasPrefix(rest, "-if") || unicode.IsSpace(rune(rest[0]))
+}
+
+// parseSkipDirective parses `@skip`, `@skip("reason")`, `@skip-if(condition)` and `@skip-if(condition, "reason")`.
+// The condition is a filter.Condition on env properties, written like a tag expression, e.g.
+// `env.BROWSER == "safari" && !env.CI`.
+func parseSkipDirective(text string, lineNo int) (*gauge.SkipDirective, error) {
+	text = strings.TrimSpace(text)
+	isSkipIf := strings.HasPrefix(text, skipIfDirectivePrefix)
+	name := skipDirectivePrefix
+	if isSkipIf {
+		name = skipIfDirectivePrefix
+	}
+	args := strings.TrimSpace(text[len(name):])
+	if args == "" && !isSkipIf {
+		return &gauge.SkipDirective{LineNo: lineNo}, nil
+	}
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return nil, fmt.Errorf("%s directive should be of the form %s", name, skipDirectiveUsage(isSkipIf))
+	}
+	args = args[1 : len(args)-1]
+	directive := &gauge.SkipDirective{LineNo: lineNo}
+	reason := args
+	if isSkipIf {
+		condition, rest, err := filter.ParseCondition(args)
+		if err != nil {
+			if e, ok := err.(*filter.TagExpressionError); ok {
+				return nil, fmt.Errorf("Invalid condition in %s directive: %s at position %d. Expected %s", name, e.Message, e.Position, skipDirectiveUsage(isSkipIf))
+			}
+			return nil, err
+		}
+		directive.Condition = condition
+		if rest == "" {
+			return directive, nil
+		}
+		reason = strings.TrimPrefix(rest, ",")
+	} else if strings.TrimSpace(reason) == "" {
+		return directive, nil
+	}
+	var err error
+	if directive.Reason, err = unquoteReason(reason); err != nil {
+		return nil, err
+	}
+	return directive, nil
+}
+
+func skipDirectiveUsage(isSkipIf bool) string {
+	if isSkipIf {
+		return `@skip-if(env.NAME == "value", "optional reason")`
+	}
+	return `@skip("reason")`
+}
+
+// unquoteReason returns the reason of a skip directive, which is quoted with either double or single quotes.
+func unquoteReason(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if len(text) < 2 || (text[0] != '"' && text[0] != '\'') || text[len(text)-1] != text[0] || strings.IndexByte(text[1:len(text)-1], text[0]) >= 0 {
+		return "", fmt.Errorf("Expected a quoted reason in skip directive, found '%s'", text)
+	}
+	return text[1 : len(text)-1], nil
+}
@@ -0,0 +1,88 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package parser
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func getenvFrom(props map[string]string) func(string) string {
+	return func(name string) string { return props[name] }
+}
+
+func (s *MySuite) TestIsSkipDirective(c *C) {
+	c.Assert(isSkipDirective(`@skip`), Equals, true)
+	c.Assert(isSkipDirective(` @skip("flaky")`), Equals, true)
+	c.Assert(isSkipDirective(`@skip-if(env.BROWSER == "safari")`), Equals, true)
+	c.Assert(isSkipDirective(`@skipper is a comment`), Equals, false)
+	c.Assert(isSkipDirective(`skip this`), Equals, false)
+}
+
+func (s *MySuite) TestParseSkipDirectiveWithReason(c *C) {
+	d, err := parseSkipDirective(`@skip("waiting for #123")`, 3)
+
+	c.Assert(err, IsNil)
+	c.Assert(d.LineNo, Equals, 3)
+	c.Assert(d.Condition, IsNil)
+	reason, ok := d.ShouldSkip(getenvFrom(nil))
+	c.Assert(ok, Equals, true)
+	c.Assert(reason, Equals, "waiting for #123")
+}
+
+func (s *MySuite) TestParseSkipDirectiveWithoutReason(c *C) {
+	d, err := parseSkipDirective(`@skip`, 3)
+
+	c.Assert(err, IsNil)
+	reason, ok := d.ShouldSkip(getenvFrom(nil))
+	c.Assert(ok, Equals, true)
+	c.Assert(reason, Equals, "Marked with @skip")
+}
+
+func (s *MySuite) TestParseSkipIfDirective(c *C) {
+	d, err := parseSkipDirective(`@skip-if(env.BROWSER == "safari" && env.CI != 'true')`, 3)
+	c.Assert(err, IsNil)
+
+	reason, ok := d.ShouldSkip(getenvFrom(map[string]string{"BROWSER": "safari"}))
+	c.Assert(ok, Equals, true)
+	c.Assert(reason, Equals, `env.BROWSER == "safari" && env.CI != 'true' is true`)
+
+	_, ok = d.ShouldSkip(getenvFrom(map[string]string{"BROWSER": "safari", "CI": "true"}))
+	c.Assert(ok, Equals, false)
+	_, ok = d.ShouldSkip(getenvFrom(map[string]string{"BROWSER": "chrome"}))
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestParseSkipIfDirectiveWithReason(c *C) {
+	d, err := parseSkipDirective(`@skip-if(!(env.gauge_env == "dev" || env.FEATURE_X), "needs dev env")`, 3)
+	c.Assert(err, IsNil)
+
+	reason, ok := d.ShouldSkip(getenvFrom(map[string]string{"gauge_env": "ci"}))
+	c.Assert(ok, Equals, true)
+	c.Assert(reason, Equals, "needs dev env")
+
+	_, ok = d.ShouldSkip(getenvFrom(map[string]string{"gauge_env": "ci", "FEATURE_X": "on"}))
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestParseInvalidSkipDirectives(c *C) {
+	invalid := []string{
+		`@skip(flaky)`,
+		`@skip("flaky"`,
+		`@skip("flaky", "again")`,
+		`@skip-if`,
+		`@skip-if()`,
+		`@skip-if(BROWSER == "safari")`,
+		`@skip-if(env.BROWSER == safari)`,
+		`@skip-if(env.BROWSER == "safari)`,
+		`@skip-if((env.BROWSER == "safari")`,
+		`@skip-if(env.BROWSER == "safari", reason)`,
+	}
+	for _, text := range invalid {
+		_, err := parseSkipDirective(text, 1)
+		c.Assert(err, NotNil, Commentf("expected %s to be invalid", text))
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 )
 
 // SpecParser is responsible for parsing a Specification. It delegates to respective processors composed sub-entities
@@ -96,26 +97,26 @@ func (parser *SpecParser) createSpecification(tokens []*Token, specFile string)
 func (parser *SpecParser) validateSpec(specification *gauge.Specification) error {
 	if len(specification.Items) == 0 {
 		specification.AddHeading(&gauge.Heading{})
-		return ParseError{FileName: specification.FileName, LineNo: 1, SpanEnd: 1, Message: "Spec does not have any elements"}
+		return ParseError{FileName: specification.FileName, LineNo: 1, SpanEnd: 1, Message: i18n.T("Spec does not have any elements")}
 	}
 	if specification.Heading == nil {
 		specification.AddHeading(&gauge.Heading{})
-		return ParseError{FileName: specification.FileName, LineNo: 1, SpanEnd: 1, Message: "Spec heading not found"}
+		return ParseError{FileName: specification.FileName, LineNo: 1, SpanEnd: 1, Message: i18n.T("Spec heading not found")}
 	}
 	if len(strings.TrimSpace(specification.Heading.Value)) < 1 {
-		return ParseError{FileName: specification.FileName, LineNo: specification.Heading.LineNo, SpanEnd: specification.Heading.LineNo, Message: "Spec heading should have at least one character"}
+		return ParseError{FileName: specification.FileName, LineNo: specification.Heading.LineNo, SpanEnd: specification.Heading.LineNo, Message: i18n.T("Spec heading should have at least one character")}
 	}
 
 	dataTable := specification.DataTable.Table
 	if dataTable.IsInitialized() && dataTable.GetRowCount() == 0 {
-		return ParseError{FileName: specification.FileName, LineNo: dataTable.LineNo, SpanEnd: dataTable.LineNo, Message: "Data table should have at least 1 data row"}
+		return ParseError{FileName: specification.FileName, LineNo: dataTable.LineNo, SpanEnd: dataTable.LineNo, Message: i18n.T("Data table should have at least 1 data row")}
 	}
 	if len(specification.Scenarios) == 0 {
-		return ParseError{FileName: specification.FileName, LineNo: specification.Heading.LineNo, SpanEnd: specification.Heading.SpanEnd, Message: "Spec should have atleast one scenario"}
+		return ParseError{FileName: specification.FileName, LineNo: specification.Heading.LineNo, SpanEnd: specification.Heading.SpanEnd, Message: i18n.T("Spec should have atleast one scenario")}
 	}
 	for _, sce := range specification.Scenarios {
 		if len(sce.Steps) == 0 {
-			return ParseError{FileName: specification.FileName, LineNo: sce.Heading.LineNo, SpanEnd: sce.Heading.SpanEnd, Message: "Scenario should have atleast one step"}
+			return ParseError{FileName: specification.FileName, LineNo: sce.Heading.LineNo, SpanEnd: sce.Heading.SpanEnd, Message: i18n.T("Scenario should have atleast one step"), Code: EmptyScenarioCode}
 		}
 	}
 	return nil
@@ -138,7 +139,7 @@ func createStep(spec *gauge.Specification, scn *gauge.Scenario, stepToken *Token
 func CreateStepUsingLookup(stepToken *Token, lookup *gauge.ArgLookup, specFileName string) (*gauge.Step, *ParseResult) {
 	stepValue, argsType := extractStepValueAndParameterTypes(stepToken.Value)
 	if argsType != nil && len(argsType) != len(stepToken.Args) {
-		return nil, &ParseResult{ParseErrors: []ParseError{ParseError{specFileName, stepToken.LineNo, stepToken.SpanEnd, "Step text should not have '{static}' or '{dynamic}' or '{special}'", stepToken.LineText()}}, Warnings: nil}
+		return nil, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: specFileName, LineNo: stepToken.LineNo, SpanEnd: stepToken.SpanEnd, Message: "Step text should not have '{static}' or '{dynamic}' or '{special}'", LineText: stepToken.LineText()}}, Warnings: nil}
 	}
 	lineText := strings.Join(stepToken.Lines, " ")
 	step := &gauge.Step{FileName: specFileName, LineNo: stepToken.LineNo, Value: stepValue, LineText: strings.TrimSpace(lineText), LineSpanEnd: stepToken.SpanEnd}
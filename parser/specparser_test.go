@@ -1365,3 +1365,71 @@ func (s *MySuite) TestDataTableForSpecialParameterWhenFileIsNotFound(c *C) {
 	c.Assert(res.ParseErrors[0].Message, Equals, "Dynamic param <file:notFound.txt> could not be resolved, Missing file: notFound.txt")
 	c.Assert(res.ParseErrors[0].LineText, Equals, "|james|<file:notFound.txt>|")
 }
+
+func (s *MySuite) TestParsingYAMLFrontMatterIntoSpecMetadata(c *C) {
+	parser := new(SpecParser)
+	specText := "---\n# ownership\nowner: payments\npriority: 'high'\n---\n" + newSpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("step").String()
+
+	spec, res := parser.ParseSpecText(specText, "")
+
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(spec.Metadata, DeepEquals, map[string]string{"owner": "payments", "priority": "high"})
+	c.Assert(spec.Heading.Value, Equals, "Spec heading")
+	c.Assert(spec.Items[0].Kind(), Equals, gauge.FrontMatterKind)
+}
+
+func (s *MySuite) TestParsingTOMLFrontMatterIntoSpecMetadata(c *C) {
+	parser := new(SpecParser)
+	specText := "+++\nowner = \"payments\"\njira = PAY-12\n+++\n" + newSpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("step").String()
+
+	spec, res := parser.ParseSpecText(specText, "")
+
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(spec.Metadata, DeepEquals, map[string]string{"owner": "payments", "jira": "PAY-12"})
+}
+
+func (s *MySuite) TestParsingFrontMatterWithInvalidEntries(c *C) {
+	parser := new(SpecParser)
+	specText := "---\nowner: payments\nowner: billing\nnot an entry\n---\n" + newSpecBuilder().specHeading("Spec heading").scenarioHeading("Scenario").step("step").String()
+
+	_, res := parser.ParseSpecText(specText, "foo.spec")
+
+	c.Assert(res.Ok, Equals, false)
+	c.Assert(len(res.ParseErrors), Equals, 2)
+	c.Assert(res.ParseErrors[0].Message, Equals, "Duplicate front matter key 'owner'")
+	c.Assert(res.ParseErrors[0].LineNo, Equals, 3)
+	c.Assert(res.ParseErrors[1].Message, Equals, "Front matter entry should be of the form 'key : value'")
+	c.Assert(res.ParseErrors[1].LineNo, Equals, 4)
+}
+
+func (s *MySuite) TestParsingScenarioWithSkipDirectives(c *C) {
+	parser := new(SpecParser)
+	specText := newSpecBuilder().specHeading("Spec heading").
+		scenarioHeading("First scenario").
+		text(`@skip-if(env.BROWSER == "safari", "not supported on safari")`).
+		step("step").
+		scenarioHeading("Second scenario").
+		step("step").String()
+
+	spec, res := parser.ParseSpecText(specText, "")
+
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(len(spec.Scenarios[0].SkipDirectives), Equals, 1)
+	c.Assert(spec.Scenarios[0].SkipDirectives[0].LineNo, Equals, 3)
+	c.Assert(spec.Scenarios[0].Comments[0].Value, Equals, `@skip-if(env.BROWSER == "safari", "not supported on safari")`)
+	c.Assert(len(spec.Scenarios[1].SkipDirectives), Equals, 0)
+}
+
+func (s *MySuite) TestParsingScenarioWithInvalidSkipDirective(c *C) {
+	parser := new(SpecParser)
+	specText := newSpecBuilder().specHeading("Spec heading").
+		scenarioHeading("First scenario").
+		text(`@skip-if(BROWSER == "safari")`).
+		step("step").String()
+
+	_, res := parser.ParseSpecText(specText, "foo.spec")
+
+	c.Assert(res.Ok, Equals, false)
+	c.Assert(len(res.ParseErrors), Equals, 1)
+	c.Assert(res.ParseErrors[0].LineNo, Equals, 3)
+}
@@ -8,6 +8,7 @@ package parser
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 )
 
 const (
@@ -81,7 +83,7 @@ func simpleAcceptor(start rune, end rune, after func(int), inState int) acceptFn
 
 func processStep(parser *SpecParser, token *Token) ([]error, bool) {
 	if len(token.Value) == 0 {
-		return []error{fmt.Errorf("Step should not be blank")}, true
+		return []error{errors.New(i18n.T("Step should not be blank"))}, true
 	}
 
 	stepValue, args, err := processStepText(token.Value)
@@ -196,9 +198,10 @@ func getEscapedRuneIfValid(element rune) rune {
 	return element
 }
 
+var parameterTypePattern = regexp.MustCompile("{(dynamic|static|special)}")
+
 func extractStepValueAndParameterTypes(stepTokenValue string) (string, []string) {
 	argsType := make([]string, 0)
-	r := regexp.MustCompile("{(dynamic|static|special)}")
 	/*
 		enter {dynamic} and {static}
 		returns
@@ -207,7 +210,7 @@ func extractStepValueAndParameterTypes(stepTokenValue string) (string, []string)
 		["{static}","static"]
 		]
 	*/
-	args := r.FindAllStringSubmatch(stepTokenValue, -1)
+	args := parameterTypePattern.FindAllStringSubmatch(stepTokenValue, -1)
 
 	if args == nil {
 		return stepTokenValue, nil
@@ -216,7 +219,7 @@ func extractStepValueAndParameterTypes(stepTokenValue string) (string, []string)
 		//arg[1] extracts the first group
 		argsType = append(argsType, arg[1])
 	}
-	return r.ReplaceAllString(stepTokenValue, gauge.ParameterPlaceholder), argsType
+	return parameterTypePattern.ReplaceAllString(stepTokenValue, gauge.ParameterPlaceholder), argsType
 }
 
 func createStepArg(argValue string, typeOfArg string, token *Token, lookup *gauge.ArgLookup, fileName string) (*gauge.StepArg, *ParseResult) {
@@ -228,7 +231,7 @@ func createStepArg(argValue string, typeOfArg string, token *Token, lookup *gaug
 			case invalidSpecialParamError:
 				return treatArgAsDynamic(argValue, token, lookup, fileName)
 			default:
-				return &gauge.StepArg{ArgType: gauge.Dynamic, Value: argValue, Name: argValue}, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: fmt.Sprintf("Dynamic parameter <%s> could not be resolved", argValue), LineText: token.LineText()}}}
+				return &gauge.StepArg{ArgType: gauge.Dynamic, Value: argValue, Name: argValue}, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: fmt.Sprintf(i18n.T("Dynamic parameter <%s> could not be resolved"), argValue), LineText: token.LineText()}}}
 			}
 		}
 		return resolvedArgValue, nil
@@ -256,7 +259,7 @@ func treatArgAsDynamic(argValue string, token *Token, lookup *gauge.ArgLookup, f
 func validateDynamicArg(argValue string, token *Token, lookup *gauge.ArgLookup, fileName string) (*gauge.StepArg, *ParseResult) {
 	stepArgument := &gauge.StepArg{ArgType: gauge.Dynamic, Value: argValue, Name: argValue}
 	if !isConceptHeader(lookup) && !lookup.ContainsArg(argValue) {
-		return stepArgument, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: fmt.Sprintf("Dynamic parameter <%s> could not be resolved", argValue), LineText: token.LineText()}}}
+		return stepArgument, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: fileName, LineNo: token.LineNo, SpanEnd: token.SpanEnd, Message: fmt.Sprintf(i18n.T("Dynamic parameter <%s> could not be resolved"), argValue), LineText: token.LineText()}}}
 	}
 
 	return stepArgument, nil
@@ -0,0 +1,133 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/plugin/pluginInfo"
+	"github.com/getgauge/gauge/version"
+)
+
+const (
+	commandScope      pluginScope = "command"
+	pluginCommandEnv              = "GAUGE_PLUGIN_COMMAND"
+	pluginSpecDirsEnv             = "GAUGE_SPEC_DIRS"
+)
+
+// Subcommand is a command of the Gauge CLI which is run by a plugin with command scope.
+type Subcommand struct {
+	Name        string
+	PluginID    string
+	Description string
+}
+
+// Subcommands returns the subcommands of the latest installed versions of the plugins with command scope, sorted by
+// name. A name claimed by more than one plugin is given to the plugin whose id comes first.
+func Subcommands() []Subcommand {
+	plugins, err := pluginInfo.GetAllInstalledPluginsWithVersion()
+	if err != nil {
+		return nil
+	}
+	names := make(map[string]bool)
+	var subcommands []Subcommand
+	for _, p := range plugins {
+		pd, err := GetPluginDescriptor(p.Name, p.Version.String())
+		if err != nil || !pd.hasScope(commandScope) {
+			continue
+		}
+		for _, name := range pd.Subcommands {
+			if names[name] {
+				continue
+			}
+			names[name] = true
+			subcommands = append(subcommands, Subcommand{Name: name, PluginID: pd.ID, Description: pd.Description})
+		}
+	}
+	sort.Slice(subcommands, func(i, j int) bool { return subcommands[i].Name < subcommands[j].Name })
+	return subcommands
+}
+
+// RunSubcommand runs the plugin of the subcommand with the args and waits for it to finish, returning its exit code.
+// The plugin runs in the foreground with the project root, spec dirs and the name of the subcommand in its
+// environment. The version locked or allowed by the manifest is run if the plugin is listed in it.
+func RunSubcommand(s Subcommand, args []string, specDirs []string) (int, error) {
+	pd, err := subcommandDescriptor(s.PluginID)
+	if err != nil {
+		return 1, err
+	}
+	if err := version.CheckCompatibility(version.CurrentGaugeVersion, &pd.GaugeVersionSupport); err != nil {
+		return 1, fmt.Errorf("compatible %s plugin version to current Gauge version %s not found", pd.Name, version.CurrentGaugeVersion)
+	}
+	if !pd.hasScope(commandScope) || !hasSubcommand(pd, s.Name) {
+		return 1, fmt.Errorf("plugin %s %s does not provide the command %s", pd.Name, pd.Version, s.Name)
+	}
+	command := pd.platformCommand()
+	if len(command) == 0 {
+		return 1, fmt.Errorf("platform specific command not specified: %s", runtime.GOOS)
+	}
+	var sources []string
+	for _, src := range specDirs {
+		path, _ := filepath.Abs(src)
+		sources = append(sources, path)
+	}
+	env := map[string]string{
+		common.GaugeProjectRootEnv: config.ProjectRoot,
+		pluginSpecDirsEnv:          strings.Join(sources, "||"),
+		pluginCommandEnv:           s.Name,
+	}
+	if m, mErr := manifest.ProjectManifest(); mErr == nil {
+		err = SetEnvForPlugin(commandScope, pd, m, env)
+	} else {
+		env[fmt.Sprintf("%s_action", pd.ID)] = string(commandScope)
+		err = setEnvironmentProperties(env)
+	}
+	if err != nil {
+		return 1, fmt.Errorf("error setting environment for plugin %s %s. %s", pd.Name, pd.Version, err.Error())
+	}
+	command = append(append([]string{}, command...), args...)
+	cmd, cleanup, err := executePlugin(pd, command, os.Stdout, os.Stderr)
+	if err != nil {
+		return 1, fmt.Errorf("error starting plugin %s %s. %s", pd.Name, pd.Version, err.Error())
+	}
+	defer cleanup()
+	if err := cmd.Wait(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return exitErr.ExitCode(), nil
+		}
+		return 1, err
+	}
+	return 0, nil
+}
+
+func hasSubcommand(pd *PluginDescriptor, name string) bool {
+	for _, n := range pd.Subcommands {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func subcommandDescriptor(pluginID string) (*PluginDescriptor, error) {
+	v := ""
+	if m, err := manifest.ProjectManifest(); err == nil && IsPluginAdded(m, &PluginDescriptor{ID: pluginID}) {
+		if v, err = VersionToRun(m, pluginID); err != nil {
+			return nil, err
+		}
+	}
+	return GetPluginDescriptor(pluginID, v)
+}
@@ -0,0 +1,73 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+func TestSubcommandsOfPluginsWithCommandScope(t *testing.T) {
+	path, _ := filepath.Abs("_testdata")
+	os.Setenv(common.GaugeHome, path)
+
+	got := Subcommands()
+
+	want := []Subcommand{
+		{Name: "lint", PluginID: "lint", Description: "Lint the specs of the project"},
+		{Name: "lint-fix", PluginID: "lint", Description: "Lint the specs of the project"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Failed Subcommands.\n\tWant: %v\n\tGot: %v", want, got)
+	}
+}
+
+func TestRunSubcommandPassesArgsAndReturnsExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a shell script")
+	}
+	path, _ := filepath.Abs("_testdata")
+	os.Setenv(common.GaugeHome, path)
+	config.ProjectRoot = filepath.Join(path, "sample")
+	defer func() { config.ProjectRoot = "" }()
+
+	code, err := RunSubcommand(Subcommand{Name: "lint-fix", PluginID: "lint"}, []string{"3"}, []string{"specs"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err.Error())
+	}
+	if code != 3 {
+		t.Errorf("expected exit code 3, got %d", code)
+	}
+	if got := os.Getenv(pluginCommandEnv); got != "lint-fix" {
+		t.Errorf("expected %s to be lint-fix, got %s", pluginCommandEnv, got)
+	}
+	specs, _ := filepath.Abs("specs")
+	if got := os.Getenv(pluginSpecDirsEnv); got != specs {
+		t.Errorf("expected %s to be %s, got %s", pluginSpecDirsEnv, specs, got)
+	}
+	if got := os.Getenv("lint_action"); got != string(commandScope) {
+		t.Errorf("expected lint_action to be %s, got %s", commandScope, got)
+	}
+}
+
+func TestRunSubcommandFailsIfThePluginVersionDoesNotProvideIt(t *testing.T) {
+	path, _ := filepath.Abs("_testdata")
+	os.Setenv(common.GaugeHome, path)
+
+	_, err := RunSubcommand(Subcommand{Name: "lint-all", PluginID: "lint"}, []string{}, []string{"specs"})
+
+	if err == nil || err.Error() != "plugin Spec Lint 1.0.0 does not provide the command lint-all" {
+		t.Errorf("expected the missing command to be reported, got %v", err)
+	}
+}
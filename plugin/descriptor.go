@@ -7,6 +7,7 @@
 package plugin
 
 import (
+	"runtime"
 	"strings"
 
 	"github.com/getgauge/gauge/version"
@@ -29,6 +30,8 @@ type PluginDescriptor struct {
 		Darwin  []string
 	}
 	Scope               []string
+	Subcommands         []string
+	Permissions         *Permissions
 	GaugeVersionSupport version.VersionSupport
 	pluginPath          string
 	Capabilities        []string
@@ -55,3 +58,15 @@ func (pd *PluginDescriptor) hasCapability(capability pluginCapability) bool {
 	}
 	return false
 }
+
+// platformCommand returns the command which starts the plugin on the current platform.
+func (pd *PluginDescriptor) platformCommand() []string {
+	switch runtime.GOOS {
+	case "windows":
+		return pd.Command.Windows
+	case "darwin":
+		return pd.Command.Darwin
+	default:
+		return pd.Command.Linux
+	}
+}
@@ -0,0 +1,289 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/plugin/pluginInfo"
+	"github.com/getgauge/gauge/version"
+)
+
+// Problem is something wrong with an installed plugin, along with how to fix it.
+type Problem struct {
+	Description string
+	Fix         string
+}
+
+// Diagnosis is the result of the checks of an installed version of a plugin or language runner. The version is empty
+// for directories which are not a version of the plugin.
+type Diagnosis struct {
+	ID       string
+	Version  string
+	Path     string
+	Runner   bool
+	Problems []Problem
+}
+
+func (d *Diagnosis) problem(description, fix string) {
+	d.Problems = append(d.Problems, Problem{Description: description, Fix: fix})
+}
+
+// runnerDescriptor is the part of the <language>.json of a language runner which is checked.
+type runnerDescriptor struct {
+	ID      string
+	Version string
+	Run     struct {
+		Windows []string
+		Linux   []string
+		Darwin  []string
+	}
+	GaugeVersionSupport version.VersionSupport
+}
+
+// Diagnose checks all the installed versions of the plugins and language runners: that the descriptor parses, that
+// the version of Gauge is supported and that the executable exists and is runnable. It also finds directories which
+// are not a version of a plugin and versions installed more than once. When connect is set and Gauge is run in a
+// project, the latest version of each plugin with execution or documentation scope is started with the manifest of
+// the project to check that it connects within the plugin connection timeout.
+func Diagnose(connect bool) ([]Diagnosis, error) {
+	prefixes, err := common.GetPluginInstallPrefixes()
+	if err != nil {
+		return nil, err
+	}
+	var m *manifest.Manifest
+	if connect {
+		m = connectionManifest()
+	}
+	var diagnoses []Diagnosis
+	for _, prefix := range prefixes {
+		files, err := ioutil.ReadDir(prefix)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, f := range files {
+			if f.IsDir() {
+				diagnoses = append(diagnoses, diagnosePlugin(filepath.Join(prefix, f.Name()), m)...)
+			}
+		}
+	}
+	return diagnoses, nil
+}
+
+// connectionManifest returns the manifest of the project the plugins are started with to check their connection, or
+// nil when not in a project.
+func connectionManifest() *manifest.Manifest {
+	if config.ProjectRoot == "" {
+		logger.Infof(true, "Skipping the connection check of the plugins as this is not a Gauge project. Run the doctor in a project to check it.")
+		return nil
+	}
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		logger.Infof(true, "Skipping the connection check of the plugins as the manifest of the project could not be read. %s", err.Error())
+		return nil
+	}
+	return m
+}
+
+// diagnosePlugin checks the installed versions of the plugin. When the manifest is set, the latest version is started
+// with it to check the connection.
+func diagnosePlugin(pluginDir string, m *manifest.Manifest) []Diagnosis {
+	id := filepath.Base(pluginDir)
+	installed, err := pluginInfo.GetInstalledPlugins(pluginDir)
+	if err != nil {
+		d := Diagnosis{ID: id, Path: pluginDir}
+		d.problem(fmt.Sprintf("No version of %s is installed in %s.", id, pluginDir), fmt.Sprintf("Remove %s", pluginDir))
+		return []Diagnosis{d}
+	}
+	var diagnoses []Diagnosis
+	versionDirs := make(map[string]bool)
+	// The installed versions are sorted latest first.
+	for i, p := range installed {
+		versionDirs[p.Path] = true
+		d := diagnoseVersion(id, p.Path)
+		if m != nil && i == 0 && len(d.Problems) == 0 && !d.Runner {
+			if err := checkConnection(id, filepath.Base(p.Path), m); err != nil {
+				d.problem(fmt.Sprintf("Failed to connect to %s within %s. %s", id, config.PluginConnectionTimeout(), err.Error()),
+					fmt.Sprintf("Check the logs of %s, or increase the timeout with `gauge config plugin_connection_timeout <milliseconds>`", id))
+			}
+		}
+		diagnoses = append(diagnoses, d)
+	}
+	diagnoses = append(diagnoses, duplicates(id, installed)...)
+	files, _ := ioutil.ReadDir(pluginDir)
+	for _, f := range files {
+		path := filepath.Join(pluginDir, f.Name())
+		if f.IsDir() && !versionDirs[path] {
+			d := Diagnosis{ID: id, Path: path}
+			d.problem(fmt.Sprintf("%s is not a version of %s.", path, id), fmt.Sprintf("Remove %s", path))
+			diagnoses = append(diagnoses, d)
+		}
+	}
+	return diagnoses
+}
+
+// duplicates finds versions of the plugin installed in more than one directory, like nightlies of the same version.
+// Only the latest of them is used.
+func duplicates(id string, installed []pluginInfo.PluginInfo) []Diagnosis {
+	dirs := make(map[string][]string)
+	var versions []string
+	for _, p := range installed {
+		v := p.Version.String()
+		if _, ok := dirs[v]; !ok {
+			versions = append(versions, v)
+		}
+		dirs[v] = append(dirs[v], filepath.Base(p.Path))
+	}
+	var diagnoses []Diagnosis
+	for _, v := range versions {
+		if len(dirs[v]) < 2 {
+			continue
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(dirs[v])))
+		d := Diagnosis{ID: id, Version: v, Path: filepath.Dir(installed[0].Path)}
+		d.problem(fmt.Sprintf("Version %s of %s is installed more than once: %s. Only %s is used.", v, id, strings.Join(dirs[v], ", "), dirs[v][0]),
+			fmt.Sprintf("Remove the others with `gauge uninstall %s --version <version>`", id))
+		diagnoses = append(diagnoses, d)
+	}
+	return diagnoses
+}
+
+func diagnoseVersion(id, path string) Diagnosis {
+	v := filepath.Base(path)
+	d := Diagnosis{ID: id, Version: v, Path: path}
+	reinstall := fmt.Sprintf("Remove %s and reinstall with `gauge install %s --version %s`", path, id, v)
+	runnerJSON := filepath.Join(path, fmt.Sprintf("%s.json", id))
+	var descriptorID string
+	var command []string
+	var support version.VersionSupport
+	switch {
+	case common.FileExists(filepath.Join(path, common.PluginJSONFile)):
+		pd, err := GetPluginDescriptorFromJSON(filepath.Join(path, common.PluginJSONFile))
+		if err != nil {
+			d.problem(fmt.Sprintf("Failed to parse the plugin descriptor. %s", err.Error()), reinstall)
+			return d
+		}
+		descriptorID, command, support = pd.ID, pd.platformCommand(), pd.GaugeVersionSupport
+	case common.FileExists(runnerJSON):
+		d.Runner = true
+		rd, err := readRunnerDescriptor(runnerJSON)
+		if err != nil {
+			d.problem(fmt.Sprintf("Failed to parse the runner descriptor. %s", err.Error()), reinstall)
+			return d
+		}
+		descriptorID, command, support = rd.ID, rd.platformCommand(), rd.GaugeVersionSupport
+	default:
+		d.problem(fmt.Sprintf("Neither %s nor %s.json found in %s.", common.PluginJSONFile, id, path), reinstall)
+		return d
+	}
+	if descriptorID != id {
+		d.problem(fmt.Sprintf("The descriptor is of %s, but it is installed as %s.", descriptorID, id),
+			fmt.Sprintf("Remove %s and install the plugin with `gauge install %s`", path, descriptorID))
+	}
+	if err := version.CheckCompatibility(version.CurrentGaugeVersion, &support); err != nil {
+		d.problem(fmt.Sprintf("Not compatible with Gauge %s: %s", version.CurrentGaugeVersion, err.Error()),
+			fmt.Sprintf("Install a compatible version with `gauge update %s`, and remove this one with `gauge uninstall %s --version %s`", id, id, v))
+	}
+	if p := checkExecutable(path, command); p != nil {
+		if p.Fix == "" {
+			p.Fix = reinstall
+		}
+		d.Problems = append(d.Problems, *p)
+	}
+	return d
+}
+
+func readRunnerDescriptor(path string) (*runnerDescriptor, error) {
+	contents, err := common.ReadFileContents(path)
+	if err != nil {
+		return nil, err
+	}
+	var rd runnerDescriptor
+	if err = json.Unmarshal([]byte(contents), &rd); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err.Error())
+	}
+	return &rd, nil
+}
+
+func (rd *runnerDescriptor) platformCommand() []string {
+	switch runtime.GOOS {
+	case "windows":
+		return rd.Run.Windows
+	case "darwin":
+		return rd.Run.Darwin
+	default:
+		return rd.Run.Linux
+	}
+}
+
+// checkExecutable checks that the executable of the command exists and can be run. Commands are started from the
+// install directory of the plugin, so relative paths are resolved against it. Names without a path separator, like
+// python, are looked up on the PATH.
+func checkExecutable(pluginDir string, command []string) *Problem {
+	if len(command) == 0 {
+		return &Problem{Description: fmt.Sprintf("No command to start it on %s.", runtime.GOOS), Fix: "Install a version which supports " + runtime.GOOS}
+	}
+	executable := command[0]
+	if filepath.Base(executable) == executable {
+		if _, err := exec.LookPath(executable); err != nil {
+			return &Problem{Description: fmt.Sprintf("Executable %s not found on the PATH.", executable), Fix: fmt.Sprintf("Install %s or add it to the PATH", executable)}
+		}
+		return nil
+	}
+	if !filepath.IsAbs(executable) {
+		executable = filepath.Join(pluginDir, executable)
+	}
+	info, err := os.Stat(executable)
+	if err != nil {
+		return &Problem{Description: fmt.Sprintf("Executable %s not found.", executable)}
+	}
+	if info.IsDir() {
+		return &Problem{Description: fmt.Sprintf("Executable %s is a directory.", executable)}
+	}
+	if runtime.GOOS != "windows" && info.Mode()&0111 == 0 {
+		return &Problem{Description: fmt.Sprintf("Executable %s is not executable.", executable), Fix: fmt.Sprintf("Run `chmod +x %s`", executable)}
+	}
+	return nil
+}
+
+// checkConnection starts the plugin, as for an execution or for documentation, and stops it once it connects.
+// Plugins with neither scope are not started.
+func checkConnection(id, v string, m *manifest.Manifest) error {
+	pd, err := GetPluginDescriptor(id, v)
+	if err != nil {
+		return err
+	}
+	var p *plugin
+	switch {
+	case pd.hasScope(executionScope):
+		p, err = startExecutionPlugin(pd, m)
+	case pd.hasScope(docScope) && pd.hasCapability(gRPCSupportCapability):
+		p, err = startPlugin(pd, docScope)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	p.stop()
+	return nil
+}
@@ -0,0 +1,177 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+const doctorTestDescriptor = `{
+    "id": "%s",
+    "version": "1.0.0",
+    "command": {"linux": ["./run.sh"], "darwin": ["./run.sh"]},
+    "scope": ["command"],
+    "gaugeVersionSupport": {"minimum": "%s", "maximum": "%s"}
+}`
+
+func writeDoctorTestFile(t *testing.T, path, contents string, mode os.FileMode) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(contents), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiagnoseInstalledPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugins are shell scripts")
+	}
+	home, err := ioutil.TempDir("", "gauge-doctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv(common.GaugeHome, os.Getenv(common.GaugeHome))
+	os.Setenv(common.GaugeHome, home)
+	plugins := filepath.Join(home, "plugins")
+	addPlugin := func(id, dir, min, max string, mode os.FileMode) {
+		writeDoctorTestFile(t, filepath.Join(plugins, id, dir, common.PluginJSONFile), fmt.Sprintf(doctorTestDescriptor, id, min, max), 0640)
+		writeDoctorTestFile(t, filepath.Join(plugins, id, dir, "run.sh"), "#!/bin/sh\n", mode)
+	}
+	addPlugin("lint", "1.0.0", "0.0.1", "", 0750)
+	addPlugin("lint", "0.9.0.nightly-2020-01-01", "0.0.1", "", 0750)
+	addPlugin("lint", "0.9.0.nightly-2020-02-01", "0.0.1", "", 0750)
+	addPlugin("noexec", "1.0.0", "0.0.1", "", 0640)
+	addPlugin("old", "1.0.0", "0.0.1", "0.0.2", 0750)
+	writeDoctorTestFile(t, filepath.Join(plugins, "lint", "backup", "run.sh"), "", 0640)
+	writeDoctorTestFile(t, filepath.Join(plugins, "broken", "1.0.0", "run.sh"), "", 0750)
+	writeDoctorTestFile(t, filepath.Join(plugins, "java", "0.7.0", "java.json"),
+		`{"id": "java", "run": {"linux": ["bin/gauge-java"], "darwin": ["bin/gauge-java"]}, "gaugeVersionSupport": {"minimum": "0.0.1"}}`, 0640)
+	if err = os.MkdirAll(filepath.Join(plugins, "empty"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	diagnoses, err := Diagnose(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string][]string)
+	for _, d := range diagnoses {
+		key := strings.TrimSpace(fmt.Sprintf("%s %s", d.ID, d.Version))
+		if d.Version == "" {
+			key = filepath.Base(d.Path)
+		}
+		got[key] = append(got[key], "")
+		for _, p := range d.Problems {
+			if p.Fix == "" {
+				t.Errorf("expected a fix for %s: %s", key, p.Description)
+			}
+			got[key] = append(got[key], p.Description)
+		}
+	}
+	want := map[string]string{
+		"lint 1.0.0":                    "",
+		"lint 0.9.0.nightly-2020-02-01": "",
+		"lint 0.9.0":                    "Version 0.9.0 of lint is installed more than once",
+		"backup":                        "is not a version of lint",
+		"noexec 1.0.0":                  "is not executable",
+		"old 1.0.0":                     "Not compatible with Gauge",
+		"broken 1.0.0":                  "Neither plugin.json nor broken.json found",
+		"java 0.7.0":                    "gauge-java not found",
+		"empty":                         "No version of empty is installed",
+	}
+	for key, problem := range want {
+		descriptions, ok := got[key]
+		if !ok {
+			t.Errorf("expected a diagnosis of %s", key)
+			continue
+		}
+		if problem == "" {
+			if len(descriptions) > 1 {
+				t.Errorf("expected no problems with %s, got %v", key, descriptions[1:])
+			}
+			continue
+		}
+		if len(descriptions) != 2 || !strings.Contains(descriptions[1], problem) {
+			t.Errorf("expected %s to have the problem '%s', got %v", key, problem, descriptions[1:])
+		}
+	}
+}
+
+func TestCheckExecutableResolvesCommandAgainstPluginDir(t *testing.T) {
+	path, _ := filepath.Abs(filepath.Join("_testdata", "plugins", "lint", "1.0.0"))
+
+	if p := checkExecutable(path, []string{"./lint.sh"}); runtime.GOOS != "windows" && p != nil {
+		t.Errorf("expected lint.sh to be runnable, got %s", p.Description)
+	}
+	if p := checkExecutable(path, nil); p == nil || !strings.Contains(p.Description, "No command") {
+		t.Errorf("expected a problem with a plugin without a command for %s", runtime.GOOS)
+	}
+}
+
+func TestCheckExecutableLooksUpCommandsWithoutAPathOnThePATH(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test executable is a shell script")
+	}
+	bin, err := ioutil.TempDir("", "gauge-doctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(bin)
+	writeDoctorTestFile(t, filepath.Join(bin, "python"), "#!/bin/sh\n", 0755)
+	oldPath := os.Getenv("PATH")
+	os.Setenv("PATH", bin)
+	defer os.Setenv("PATH", oldPath)
+	pluginDir, _ := filepath.Abs(filepath.Join("_testdata", "plugins", "lint", "1.0.0"))
+
+	if p := checkExecutable(pluginDir, []string{"python", "start.py"}); p != nil {
+		t.Errorf("expected python to be found on the PATH, got %s", p.Description)
+	}
+	if p := checkExecutable(pluginDir, []string{"node", "start.js"}); p == nil || !strings.Contains(p.Description, "node not found on the PATH") {
+		t.Errorf("expected a problem with node not being on the PATH, got %v", p)
+	}
+	if p := checkExecutable(pluginDir, []string{"bin/python"}); p == nil || !strings.Contains(p.Description, filepath.Join(pluginDir, "bin", "python")) {
+		t.Errorf("expected bin/python to be resolved against the plugin dir, got %v", p)
+	}
+}
+
+func TestDiagnoseSkipsConnectionCheckOutsideAProject(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugins are shell scripts")
+	}
+	home, err := ioutil.TempDir("", "gauge-doctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv(common.GaugeHome, os.Getenv(common.GaugeHome))
+	os.Setenv(common.GaugeHome, home)
+	config.ProjectRoot = ""
+	descriptor := strings.Replace(fmt.Sprintf(doctorTestDescriptor, "report", "0.0.1", ""), `"command"]`, `"execution"]`, 1)
+	writeDoctorTestFile(t, filepath.Join(home, "plugins", "report", "1.0.0", common.PluginJSONFile), descriptor, 0640)
+	writeDoctorTestFile(t, filepath.Join(home, "plugins", "report", "1.0.0", "run.sh"), "#!/bin/sh\n", 0750)
+
+	diagnoses, err := Diagnose(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diagnoses) != 1 || len(diagnoses[0].Problems) != 0 {
+		t.Errorf("expected report not to be started outside a project, got %v", diagnoses)
+	}
+}
@@ -7,21 +7,63 @@
 package plugin
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
 )
 
+// maxPluginRestarts is the number of times a plugin which died is restarted in an execution before giving up on it.
+const maxPluginRestarts = 3
+
+const (
+	pluginRunning   = "running"
+	pluginRestarted = "restarted"
+	pluginFailed    = "failed"
+)
+
 // Handler manages plugins listed in project manifest.
 type Handler interface {
-	NotifyPlugins(*gauge_messages.Message)
+	NotifyPlugins(*gauge_messages.Message, gauge.Item)
+	NotifyListeners(*gauge_messages.Message, gauge.Item)
 	GracefullyKillPlugins()
+	Statuses() []Status
+}
+
+// Status is the health of a plugin in an execution, with the last error it failed with.
+type Status struct {
+	ID        string
+	State     string
+	Restarts  int
+	LastError error
+}
+
+func (s Status) String() string {
+	text := s.State
+	if s.Restarts > 0 {
+		text = fmt.Sprintf("%s, restarted %d time(s)", text, s.Restarts)
+	}
+	if s.LastError != nil {
+		text = fmt.Sprintf("%s. Last error: %s", text, s.LastError.Error())
+	}
+	return text
 }
 
-// GaugePlugins holds a reference to all plugins launched. The plugins are listed in project manifest
+// GaugePlugins holds a reference to all plugins launched. The plugins are listed in project manifest.
+// When it can restart plugins, a plugin which is restarted after its process exited or a message could not be sent to
+// it gets the start of the suite and of the specs being executed replayed. The results of everything executed before
+// reach it with the suite result. The listeners registered when the plugins were started get the messages before the
+// plugins.
 type GaugePlugins struct {
+	listeners  []*registeredListener
 	pluginsMap map[string]*plugin
+	statuses   map[string]*Status
+	events     []*gauge_messages.Message
+	restart    func(pd *PluginDescriptor) (*plugin, error)
+	mutex      sync.Mutex
 }
 
 func (gp *GaugePlugins) addPlugin(pluginID string, pluginToAdd *plugin) {
@@ -29,38 +71,165 @@ func (gp *GaugePlugins) addPlugin(pluginID string, pluginToAdd *plugin) {
 		gp.pluginsMap = make(map[string]*plugin)
 	}
 	gp.pluginsMap[pluginID] = pluginToAdd
+	gp.status(pluginID)
 }
 
 func (gp *GaugePlugins) removePlugin(pluginID string) {
 	delete(gp.pluginsMap, pluginID)
 }
 
-// NotifyPlugins passes a message to all plugins listed in the manifest
-func (gp *GaugePlugins) NotifyPlugins(message *gauge_messages.Message) {
-	var handle = func(id string, p *plugin, err error) {
+func (gp *GaugePlugins) status(pluginID string) *Status {
+	if gp.statuses == nil {
+		gp.statuses = make(map[string]*Status)
+	}
+	if _, ok := gp.statuses[pluginID]; !ok {
+		gp.statuses[pluginID] = &Status{ID: pluginID, State: pluginRunning}
+	}
+	return gp.statuses[pluginID]
+}
+
+// NotifyPlugins passes a message to the listeners and all plugins listed in the manifest. The item is the spec,
+// scenario or step the message is about, which is passed on to the ItemListeners.
+func (gp *GaugePlugins) NotifyPlugins(message *gauge_messages.Message, item gauge.Item) {
+	for id, pd := range gp.notify(message, item) {
+		gp.restartPlugin(id, pd, message)
+	}
+}
+
+// NotifyListeners passes a message to the listeners only. It is for the specs and scenarios which are not executed, like
+// those skipped for errors, which the plugins are not told about.
+func (gp *GaugePlugins) NotifyListeners(message *gauge_messages.Message, item gauge.Item) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+	gp.notifyListeners(message, item)
+}
+
+func (gp *GaugePlugins) notifyListeners(message *gauge_messages.Message, item gauge.Item) {
+	for _, l := range gp.listeners {
+		var err error
+		if il, ok := l.listener.(ItemListener); ok {
+			err = il.NotifyItem(message, item)
+		} else {
+			err = l.listener.Notify(message)
+		}
 		if err != nil {
-			logger.Errorf(true, "Unable to connect to plugin %s %s. %s\n", p.descriptor.Name, p.descriptor.Version, err.Error())
-			gp.killPlugin(id)
+			logger.Errorf(true, "Listener %s failed to handle %s. %s", l.name, message.GetMessageType().String(), err.Error())
+		}
+	}
+}
+
+// notify passes the message to the listeners and plugins, and returns the plugins which failed and are to be restarted.
+func (gp *GaugePlugins) notify(message *gauge_messages.Message, item gauge.Item) map[string]*PluginDescriptor {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+	gp.notifyListeners(message, item)
+	stopping := message.GetMessageType() == gauge_messages.Message_KillProcessRequest
+	if gp.restart != nil {
+		gp.buffer(message)
+	}
+	var ids []string
+	for id := range gp.pluginsMap {
+		ids = append(ids, id)
+	}
+	failed := make(map[string]*PluginDescriptor)
+	for _, id := range ids {
+		p := gp.pluginsMap[id]
+		err := p.notify(message)
+		if err == nil {
+			continue
+		}
+		logger.Errorf(true, "Unable to connect to plugin %s %s. %s\n", p.descriptor.Name, p.descriptor.Version, err.Error())
+		gp.status(id).LastError = err
+		gp.killPlugin(id)
+		if gp.restart != nil && !stopping {
+			failed[id] = p.descriptor
+		} else {
+			gp.status(id).State = pluginFailed
 		}
 	}
+	return failed
+}
 
-	for id, plugin := range gp.pluginsMap {
-		handle(id, plugin, plugin.sendMessage(message))
+// buffer keeps the start of the suite and of the specs being executed, to be replayed to restarted plugins.
+func (gp *GaugePlugins) buffer(message *gauge_messages.Message) {
+	switch message.GetMessageType() {
+	case gauge_messages.Message_ExecutionStarting:
+		gp.events = []*gauge_messages.Message{message}
+	case gauge_messages.Message_SpecExecutionStarting:
+		gp.events = append(gp.events, message)
+	case gauge_messages.Message_SpecExecutionEnding:
+		spec := message.GetSpecExecutionEndingRequest().GetCurrentExecutionInfo().GetCurrentSpec().GetFileName()
+		for i, e := range gp.events {
+			if e.GetMessageType() == gauge_messages.Message_SpecExecutionStarting && e.GetSpecExecutionStartingRequest().GetCurrentExecutionInfo().GetCurrentSpec().GetFileName() == spec {
+				gp.events = append(gp.events[:i:i], gp.events[i+1:]...)
+				break
+			}
+		}
 	}
 }
 
+// restartPlugin starts the plugin again, replays the buffered messages to it and sends it the message it failed on, unless
+// that one was replayed already. The plugin is started without holding the lock, so that the other plugins keep getting
+// messages meanwhile.
+func (gp *GaugePlugins) restartPlugin(pluginID string, pd *PluginDescriptor, failed *gauge_messages.Message) {
+	for {
+		gp.mutex.Lock()
+		s := gp.status(pluginID)
+		if s.Restarts >= maxPluginRestarts {
+			s.State = pluginFailed
+			logger.Errorf(true, "Plugin %s %s failed after %d restarts. %s", pd.Name, pd.Version, s.Restarts, s.LastError.Error())
+			gp.mutex.Unlock()
+			return
+		}
+		s.Restarts++
+		gp.mutex.Unlock()
+		logger.Warningf(true, "Restarting plugin %s %s.", pd.Name, pd.Version)
+		p, err := gp.restart(pd)
+		gp.mutex.Lock()
+		if err == nil {
+			if err = gp.replay(p, failed); err != nil {
+				p.stop()
+			}
+		}
+		if err == nil {
+			gp.addPlugin(pluginID, p)
+			s.State = pluginRestarted
+			gp.mutex.Unlock()
+			return
+		}
+		s.LastError = err
+		gp.mutex.Unlock()
+	}
+}
+
+func (gp *GaugePlugins) replay(p *plugin, failed *gauge_messages.Message) error {
+	replayed := false
+	for _, e := range gp.events {
+		if err := p.notify(e); err != nil {
+			return fmt.Errorf("failed to replay messages. %s", err.Error())
+		}
+		replayed = replayed || e == failed
+	}
+	if replayed {
+		return nil
+	}
+	if err := p.notify(failed); err != nil {
+		return fmt.Errorf("failed to send %s. %s", failed.GetMessageType().String(), err.Error())
+	}
+	return nil
+}
+
 func (gp *GaugePlugins) killPlugin(pluginID string) {
 	plugin := gp.pluginsMap[pluginID]
 	logger.Debugf(true, "Killing Plugin %s %s\n", plugin.descriptor.Name, plugin.descriptor.Version)
-	err := plugin.pluginCmd.Process.Kill()
-	if err != nil {
-		logger.Errorf(true, "Failed to kill plugin %s %s. %s\n", plugin.descriptor.Name, plugin.descriptor.Version, err.Error())
-	}
+	plugin.stop()
 	gp.removePlugin(pluginID)
 }
 
 // GracefullyKillPlugins tells the plugins to stop, letting them cleanup whatever they need to
 func (gp *GaugePlugins) GracefullyKillPlugins() {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
 	var wg sync.WaitGroup
 	for _, pl := range gp.pluginsMap {
 		wg.Add(1)
@@ -74,3 +243,15 @@ func (gp *GaugePlugins) GracefullyKillPlugins() {
 	}
 	wg.Wait()
 }
+
+// Statuses returns the status of each plugin started for the execution, sorted by plugin id.
+func (gp *GaugePlugins) Statuses() []Status {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+	var statuses []Status
+	for _, s := range gp.statuses {
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
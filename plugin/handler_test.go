@@ -0,0 +1,218 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"google.golang.org/grpc"
+)
+
+type recordingReporterClient struct {
+	gm.ReporterClient
+	err      error
+	received []gm.Message_MessageType
+}
+
+func (client *recordingReporterClient) record(t gm.Message_MessageType) (*gm.Empty, error) {
+	if client.err != nil {
+		return nil, client.err
+	}
+	client.received = append(client.received, t)
+	return &gm.Empty{}, nil
+}
+
+func (client *recordingReporterClient) NotifyExecutionStarting(c context.Context, r *gm.ExecutionStartingRequest, opts ...grpc.CallOption) (*gm.Empty, error) {
+	return client.record(gm.Message_ExecutionStarting)
+}
+
+func (client *recordingReporterClient) NotifySpecExecutionStarting(c context.Context, r *gm.SpecExecutionStartingRequest, opts ...grpc.CallOption) (*gm.Empty, error) {
+	return client.record(gm.Message_SpecExecutionStarting)
+}
+
+func (client *recordingReporterClient) NotifySuiteResult(c context.Context, r *gm.SuiteExecutionResult, opts ...grpc.CallOption) (*gm.Empty, error) {
+	return client.record(gm.Message_SuiteExecutionResult)
+}
+
+type failingConn struct {
+	net.Conn
+}
+
+func (c *failingConn) Write(b []byte) (int, error) {
+	return 0, fmt.Errorf("broken pipe")
+}
+
+func (c *failingConn) Close() error {
+	return nil
+}
+
+func testPlugin(t *testing.T, client gm.ReporterClient) *plugin {
+	conn, err := grpc.Dial("127.0.0.1:0", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &plugin{
+		gRPCConn:       conn,
+		ReporterClient: client,
+		descriptor:     &PluginDescriptor{ID: "html-report", Name: "Html Report", Version: "1.0.0"},
+		mutex:          &sync.Mutex{},
+	}
+}
+
+var (
+	executionStarting = &gm.Message{MessageType: gm.Message_ExecutionStarting, ExecutionStartingRequest: &gm.ExecutionStartingRequest{}}
+	specStarting      = &gm.Message{MessageType: gm.Message_SpecExecutionStarting, SpecExecutionStartingRequest: &gm.SpecExecutionStartingRequest{}}
+	suiteResult       = &gm.Message{MessageType: gm.Message_SuiteExecutionResult, SuiteExecutionResult: &gm.SuiteExecutionResult{}}
+)
+
+func TestNotifyPluginsRestartsFailedPluginAndReplaysMessages(t *testing.T) {
+	failing := &recordingReporterClient{}
+	restarted := &recordingReporterClient{}
+	gp := &GaugePlugins{restart: func(pd *PluginDescriptor) (*plugin, error) {
+		return testPlugin(t, restarted), nil
+	}}
+	gp.addPlugin("html-report", testPlugin(t, failing))
+
+	gp.NotifyPlugins(executionStarting, nil)
+	failing.err = fmt.Errorf("connection reset")
+	gp.NotifyPlugins(specStarting, nil)
+	gp.NotifyPlugins(suiteResult, nil)
+
+	want := []gm.Message_MessageType{gm.Message_ExecutionStarting, gm.Message_SpecExecutionStarting, gm.Message_SuiteExecutionResult}
+	if !reflect.DeepEqual(restarted.received, want) {
+		t.Errorf("expected restarted plugin to receive %v, got %v", want, restarted.received)
+	}
+	statuses := gp.Statuses()
+	if len(statuses) != 1 || statuses[0].State != pluginRestarted || statuses[0].Restarts != 1 {
+		t.Fatalf("expected html-report to be restarted once, got %v", statuses)
+	}
+	if statuses[0].LastError.Error() != "connection reset" {
+		t.Errorf("expected last error to be 'connection reset', got %s", statuses[0].LastError)
+	}
+}
+
+func TestNotifyPluginsResendsTheMessageTheRestartedPluginFailedOn(t *testing.T) {
+	failing := &recordingReporterClient{}
+	restarted := &recordingReporterClient{}
+	gp := &GaugePlugins{restart: func(pd *PluginDescriptor) (*plugin, error) {
+		return testPlugin(t, restarted), nil
+	}}
+	gp.addPlugin("html-report", testPlugin(t, failing))
+
+	gp.NotifyPlugins(executionStarting, nil)
+	gp.NotifyPlugins(specStarting, nil)
+	failing.err = fmt.Errorf("connection reset")
+	gp.NotifyPlugins(suiteResult, nil)
+
+	want := []gm.Message_MessageType{gm.Message_ExecutionStarting, gm.Message_SpecExecutionStarting, gm.Message_SuiteExecutionResult}
+	if !reflect.DeepEqual(restarted.received, want) {
+		t.Errorf("expected restarted plugin to receive %v, got %v", want, restarted.received)
+	}
+	if s := gp.Statuses()[0]; s.State != pluginRestarted {
+		t.Errorf("expected plugin to be restarted, got %s", s)
+	}
+}
+
+func TestNotifyPluginsGivesUpAfterMaxRestarts(t *testing.T) {
+	starts := 0
+	gp := &GaugePlugins{restart: func(pd *PluginDescriptor) (*plugin, error) {
+		starts++
+		return nil, fmt.Errorf("timed out connecting to html-report")
+	}}
+	gp.addPlugin("html-report", testPlugin(t, &recordingReporterClient{err: fmt.Errorf("connection reset")}))
+
+	gp.NotifyPlugins(executionStarting, nil)
+	gp.NotifyPlugins(specStarting, nil)
+
+	if starts != maxPluginRestarts {
+		t.Errorf("expected %d restarts, got %d", maxPluginRestarts, starts)
+	}
+	got := gp.Statuses()[0].String()
+	want := "failed, restarted 3 time(s). Last error: timed out connecting to html-report"
+	if got != want {
+		t.Errorf("expected status %s, got %s", want, got)
+	}
+	if len(gp.pluginsMap) != 0 {
+		t.Errorf("expected failed plugin to be removed")
+	}
+}
+
+func TestNotifyPluginsDoesNotRestartPluginsWhenStopping(t *testing.T) {
+	gp := &GaugePlugins{restart: func(pd *PluginDescriptor) (*plugin, error) {
+		t.Error("expected plugin not to be restarted")
+		return nil, nil
+	}}
+	gp.addPlugin("html-report", &plugin{
+		connection: &failingConn{},
+		descriptor: &PluginDescriptor{ID: "html-report", Name: "Html Report", Version: "1.0.0"},
+		mutex:      &sync.Mutex{},
+	})
+
+	gp.NotifyPlugins(&gm.Message{MessageType: gm.Message_KillProcessRequest, KillProcessRequest: &gm.KillProcessRequest{}}, nil)
+
+	if s := gp.Statuses()[0]; s.State != pluginFailed {
+		t.Errorf("expected plugin to have failed, got %s", s)
+	}
+	if len(gp.events) != 0 {
+		t.Errorf("expected kill request not to be buffered")
+	}
+}
+
+func specMessage(t gm.Message_MessageType, file string) *gm.Message {
+	info := &gm.ExecutionInfo{CurrentSpec: &gm.SpecInfo{FileName: file}}
+	if t == gm.Message_SpecExecutionStarting {
+		return &gm.Message{MessageType: t, SpecExecutionStartingRequest: &gm.SpecExecutionStartingRequest{CurrentExecutionInfo: info}}
+	}
+	return &gm.Message{MessageType: t, SpecExecutionEndingRequest: &gm.SpecExecutionEndingRequest{CurrentExecutionInfo: info}}
+}
+
+func TestNotifyPluginsBuffersOnlyTheStartOfSuiteAndSpecsBeingExecuted(t *testing.T) {
+	gp := &GaugePlugins{restart: func(pd *PluginDescriptor) (*plugin, error) { return nil, nil }}
+	login := specMessage(gm.Message_SpecExecutionStarting, "login.spec")
+
+	gp.NotifyPlugins(executionStarting, nil)
+	gp.NotifyPlugins(specMessage(gm.Message_SpecExecutionStarting, "search.spec"), nil)
+	gp.NotifyPlugins(login, nil)
+	gp.NotifyPlugins(&gm.Message{MessageType: gm.Message_StepExecutionEnding, StepExecutionEndingRequest: &gm.StepExecutionEndingRequest{}}, nil)
+	gp.NotifyPlugins(specMessage(gm.Message_SpecExecutionEnding, "search.spec"), nil)
+
+	want := []*gm.Message{executionStarting, login}
+	if !reflect.DeepEqual(gp.events, want) {
+		t.Errorf("expected buffered messages %v, got %v", want, gp.events)
+	}
+}
+
+func TestNotifyPluginsRestartsPluginWithoutHoldingTheLock(t *testing.T) {
+	done := make(chan bool)
+	var gp *GaugePlugins
+	gp = &GaugePlugins{restart: func(pd *PluginDescriptor) (*plugin, error) {
+		gp.Statuses()
+		return testPlugin(t, &recordingReporterClient{}), nil
+	}}
+	gp.addPlugin("html-report", testPlugin(t, &recordingReporterClient{err: fmt.Errorf("connection reset")}))
+
+	go func() {
+		gp.NotifyPlugins(executionStarting, nil)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected plugin to be restarted without holding the lock")
+	}
+	if s := gp.Statuses()[0]; s.State != pluginRestarted {
+		t.Errorf("expected plugin to be restarted, got %s", s)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -19,7 +20,6 @@ import (
 	"strings"
 
 	"github.com/getgauge/common"
-	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
 	"github.com/getgauge/gauge/plugin"
@@ -33,12 +33,15 @@ const (
 	pluginJSON = "plugin.json"
 	jsonExt    = ".json"
 	x86        = "386"
+	// archiveChecksumFile records, in the install directory of a plugin, the checksum of the archive it was installed from.
+	archiveChecksumFile = ".archive-checksum"
 )
 
 type installDescription struct {
 	Name        string
 	Description string
 	Versions    []versionInstallDescription
+	repository  repository
 }
 
 type versionInstallDescription struct {
@@ -46,6 +49,8 @@ type versionInstallDescription struct {
 	GaugeVersionSupport version.VersionSupport
 	Install             platformSpecificCommand
 	DownloadUrls        downloadUrls
+	Checksums           downloadUrls
+	SignatureUrls       downloadUrls
 }
 
 type downloadUrls struct {
@@ -53,6 +58,11 @@ type downloadUrls struct {
 	X64 platformSpecificURL
 }
 
+// all returns the values for each platform.
+func (d *downloadUrls) all() []*string {
+	return []*string{&d.X86.Windows, &d.X86.Linux, &d.X86.Darwin, &d.X64.Windows, &d.X64.Linux, &d.X64.Darwin}
+}
+
 type platformSpecificCommand struct {
 	Windows []string
 	Linux   []string
@@ -67,12 +77,13 @@ type platformSpecificURL struct {
 
 // InstallResult represents the result of plugin installation
 type InstallResult struct {
-	Error   error
-	Warning string
-	Info    string
-	Success bool
-	Skipped bool
-	Version string
+	Error    error
+	Warning  string
+	Info     string
+	Success  bool
+	Skipped  bool
+	Version  string
+	Checksum string
 }
 
 func (installResult *InstallResult) getMessage() string {
@@ -95,6 +106,7 @@ type GaugePlugin struct {
 	ID          string
 	Version     string
 	Description string
+	Permissions *plugin.Permissions
 	PreInstall  struct {
 		Windows []string
 		Linux   []string
@@ -166,6 +178,9 @@ func InstallPluginFromZipFile(zipFile string, pluginName string) InstallResult {
 		err := fmt.Errorf("provided zip file is not a valid plugin of %s", pluginName)
 		return installError(err)
 	}
+	if gp.Permissions != nil {
+		logger.Infof(true, "Permissions requested by plugin %s %s: %s", gp.ID, gp.Version, gp.Permissions)
+	}
 	if err = runPlatformCommands(gp.PreInstall, unzippedPluginDir); err != nil {
 		return installError(err)
 	}
@@ -184,11 +199,33 @@ func InstallPluginFromZipFile(zipFile string, pluginName string) InstallResult {
 	if _, err = common.MirrorDir(unzippedPluginDir, pluginInstallDir); err != nil {
 		return installError(err)
 	}
+	checksum, err := fileChecksum(zipFile)
+	if err != nil {
+		return installError(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(pluginInstallDir, archiveChecksumFile), []byte(checksum), common.NewFilePermissions); err != nil {
+		logger.Debugf(true, "Failed to record the checksum of plugin %s %s. %s", gp.ID, gp.Version, err.Error())
+	}
 	installResult := installSuccess("")
 	installResult.Version = gp.Version
+	installResult.Checksum = checksum
 	return installResult
 }
 
+// installedChecksum returns the checksum of the archive the version of the plugin was installed from, which is empty
+// when it was installed before the checksums were recorded.
+func installedChecksum(pluginName, v string) string {
+	dir, err := plugin.GetInstallDir(pluginName, v)
+	if err != nil {
+		return ""
+	}
+	checksum, err := ioutil.ReadFile(filepath.Join(dir, archiveChecksumFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(checksum))
+}
+
 func getPluginInstallDir(pluginID, pluginDirName string) (string, error) {
 	pluginsDir, err := common.GetPrimaryPluginsInstallDir()
 	if err != nil {
@@ -231,6 +268,36 @@ func Plugin(pluginName, version string, silent bool) InstallResult {
 	return installPluginWithDescription(installDescription, version, silent)
 }
 
+// lockedPlugin downloads and installs the version of the plugin locked for the project, verifying the checksum of
+// its archive when the lock has one.
+func lockedPlugin(pluginName string, locked manifest.LockedPlugin, silent bool) InstallResult {
+	installDescription, result := getInstallDescription(pluginName, false)
+	defer util.RemoveTempDir()
+	if !result.Success {
+		return result
+	}
+	versionInstallDescription, err := installDescription.getVersion(locked.Version)
+	if err != nil {
+		return installError(err)
+	}
+	return installPluginVersion(installDescription, versionInstallDescription, locked.Checksum, silent)
+}
+
+// pluginMatching downloads and installs the latest version of the plugin which satisfies the constraint and is
+// compatible with the current gauge.
+func pluginMatching(pluginName string, constraint *version.Constraint, silent bool) InstallResult {
+	installDescription, result := getInstallDescription(pluginName, false)
+	defer util.RemoveTempDir()
+	if !result.Success {
+		return result
+	}
+	versionInstallDescription, err := installDescription.getLatestCompatibleVersionMatching(version.CurrentGaugeVersion, constraint)
+	if err != nil {
+		return installError(fmt.Errorf("Could not find compatible version for plugin %s. : %s", installDescription.Name, err))
+	}
+	return installPluginVersion(installDescription, versionInstallDescription, "", silent)
+}
+
 func installPluginWithDescription(installDescription *installDescription, currentVersion string, silent bool) InstallResult {
 	var versionInstallDescription *versionInstallDescription
 	var err error
@@ -248,12 +315,15 @@ func installPluginWithDescription(installDescription *installDescription, curren
 			return installError(fmt.Errorf("Could not find compatible version for plugin %s. : %s", installDescription.Name, err))
 		}
 	}
-	return installPluginVersion(installDescription, versionInstallDescription, silent)
+	return installPluginVersion(installDescription, versionInstallDescription, "", silent)
 }
 
-func installPluginVersion(installDesc *installDescription, versionInstallDescription *versionInstallDescription, silent bool) InstallResult {
+func installPluginVersion(installDesc *installDescription, versionInstallDescription *versionInstallDescription, checksum string, silent bool) InstallResult {
 	if common.IsPluginInstalled(installDesc.Name, versionInstallDescription.Version) {
-		return installSkipped("", fmt.Sprintf("Plugin %s %s is already installed.", installDesc.Name, versionInstallDescription.Version))
+		res := installSkipped("", fmt.Sprintf("Plugin %s %s is already installed.", installDesc.Name, versionInstallDescription.Version))
+		res.Version = versionInstallDescription.Version
+		res.Checksum = installedChecksum(installDesc.Name, versionInstallDescription.Version)
+		return res
 	}
 
 	downloadLink, err := getDownloadLink(versionInstallDescription.DownloadUrls)
@@ -268,10 +338,20 @@ func installPluginVersion(installDesc *installDescription, versionInstallDescrip
 			logger.Errorf(false, "unable to remove temp directory: %s", err.Error())
 		}
 	}()
-	pluginZip, err := util.Download(downloadLink, tempDir, "", silent)
+	pluginZip, err := fetch(installDesc.repository.resolve(downloadLink), tempDir, "", silent)
 	if err != nil {
 		return installError(fmt.Errorf("Failed to download the plugin. %s", err.Error()))
 	}
+	archiveChecksum, err := fileChecksum(pluginZip)
+	if err != nil {
+		return installError(err)
+	}
+	if checksum != "" && checksum != archiveChecksum {
+		return installError(fmt.Errorf("Checksum of %s does not match the one in %s. Expected %s but got %s", filepath.Base(pluginZip), manifest.LockFile, checksum, archiveChecksum))
+	}
+	if err := verifyArchive(installDesc.repository, pluginZip, archiveChecksum, versionInstallDescription); err != nil {
+		return installError(err)
+	}
 	res := InstallPluginFromZipFile(pluginZip, installDesc.Name)
 	res.Version = versionInstallDescription.Version
 	return res
@@ -367,33 +447,35 @@ func uninstallVersionOfPlugin(pluginDir, pluginName, uninstallVersion string) er
 }
 
 func getDownloadLink(downloadUrls downloadUrls) (string, error) {
-	var platformLinks *platformSpecificURL
-	if strings.Contains(runtime.GOARCH, "64") {
-		platformLinks = &downloadUrls.X64
-	} else {
-		platformLinks = &downloadUrls.X86
+	downloadLink := platformValue(downloadUrls)
+	if downloadLink == "" {
+		return "", fmt.Errorf("Platform not supported for %s. Download URL not specified.", runtime.GOOS)
 	}
+	return downloadLink, nil
+}
 
-	var downloadLink string
+// platformValue returns the value for the current OS and architecture.
+func platformValue(values downloadUrls) string {
+	platformValues := &values.X86
+	if strings.Contains(runtime.GOARCH, "64") {
+		platformValues = &values.X64
+	}
 	switch runtime.GOOS {
 	case "windows":
-		downloadLink = platformLinks.Windows
+		return platformValues.Windows
 	case "darwin":
-		downloadLink = platformLinks.Darwin
+		return platformValues.Darwin
 	default:
-		downloadLink = platformLinks.Linux
+		return platformValues.Linux
 	}
-	if downloadLink == "" {
-		return "", fmt.Errorf("Platform not supported for %s. Download URL not specified.", runtime.GOOS)
-	}
-	return downloadLink, nil
 }
 
+// getInstallDescription fetches the install description of the plugin from the first repository which has it.
 func getInstallDescription(plugin string, silent bool) (*installDescription, InstallResult) {
-	versionInstallDescriptionJSONFile := plugin + "-install.json"
-	versionInstallDescriptionJSONUrl, result := constructPluginInstallJSONURL(plugin)
-	if !result.Success {
-		return nil, installError(fmt.Errorf("Could not construct plugin install json file URL. %s", result.Error))
+	versionInstallDescriptionJSONFile := installJSONFile(plugin)
+	repos := repositories()
+	if len(repos) == 0 {
+		return nil, installError(fmt.Errorf("Could not find gauge repository url from configuration."))
 	}
 	tempDir := common.GetTempDir()
 	defer func() {
@@ -403,13 +485,21 @@ func getInstallDescription(plugin string, silent bool) (*installDescription, Ins
 		}
 	}()
 
-	downloadedFile, downloadErr := util.Download(versionInstallDescriptionJSONUrl, tempDir, versionInstallDescriptionJSONFile, silent)
-	if downloadErr != nil {
-		logger.Debugf(true, "Failed to download %s file: %s", versionInstallDescriptionJSONFile, downloadErr)
-		return nil, installError(fmt.Errorf("Invalid plugin name or there's a network issue while fetching plugin details."))
+	for _, r := range repos {
+		downloadedFile, downloadErr := fetch(r.installJSONLocation(plugin), tempDir, versionInstallDescriptionJSONFile, silent)
+		if downloadErr != nil {
+			logger.Debugf(true, "Failed to download %s file from %s: %s", versionInstallDescriptionJSONFile, r, downloadErr)
+			continue
+		}
+		installDescription, result := getInstallDescriptionFromJSON(downloadedFile)
+		if !result.Success {
+			logger.Debugf(true, "Failed to read %s file from %s: %s", versionInstallDescriptionJSONFile, r, result.Error)
+			continue
+		}
+		installDescription.repository = r
+		return installDescription, result
 	}
-
-	return getInstallDescriptionFromJSON(downloadedFile)
+	return nil, installError(fmt.Errorf("Invalid plugin name or there's a network issue while fetching plugin details."))
 }
 
 func getInstallDescriptionFromJSON(installJSON string) (*installDescription, InstallResult) {
@@ -424,18 +514,6 @@ func getInstallDescriptionFromJSON(installJSON string) (*installDescription, Ins
 	return installDescription, installSuccess("")
 }
 
-func constructPluginInstallJSONURL(p string) (string, InstallResult) {
-	repoURL := config.GaugeRepositoryUrl()
-	if repoURL == "" {
-		return "", installError(fmt.Errorf("Could not find gauge repository url from configuration."))
-	}
-	jsonURL := fmt.Sprintf("%s/%s", repoURL, p)
-	if qp := plugin.QueryParams(); qp != "" {
-		jsonURL += qp
-	}
-	return jsonURL, installSuccess("")
-}
-
 func (installDesc *installDescription) getVersion(version string) (*versionInstallDescription, error) {
 	for _, versionInstallDescription := range installDesc.Versions {
 		if versionInstallDescription.Version == version {
@@ -455,6 +533,20 @@ func (installDesc *installDescription) getLatestCompatibleVersionTo(currentVersi
 	return nil, fmt.Errorf("Compatible version to %s not found", currentVersion)
 }
 
+func (installDesc *installDescription) getLatestCompatibleVersionMatching(currentVersion *version.Version, constraint *version.Constraint) (*versionInstallDescription, error) {
+	installDesc.sortVersionInstallDescriptions()
+	for _, versionInstallDesc := range installDesc.Versions {
+		v, err := version.ParseVersion(versionInstallDesc.Version)
+		if err != nil || !constraint.Allows(v) {
+			continue
+		}
+		if err := version.CheckCompatibility(currentVersion, &versionInstallDesc.GaugeVersionSupport); err == nil {
+			return &versionInstallDesc, nil
+		}
+	}
+	return nil, fmt.Errorf("Version matching %s compatible to %s not found", constraint, currentVersion)
+}
+
 func (installDesc *installDescription) sortVersionInstallDescriptions() {
 	sort.Sort(byDecreasingVersion(installDesc.Versions))
 }
@@ -490,7 +582,8 @@ func getRunnerJSONContents(file string) (*runner.RunnerInfo, error) {
 	return &r, nil
 }
 
-// AllPlugins install the latest version of all plugins specified in Gauge project manifest file
+// AllPlugins installs the plugins specified in Gauge project manifest file. Plugins locked in gauge.lock are installed
+// at the locked version, others at the latest version allowed by the manifest, which is then locked.
 func AllPlugins(silent, languageOnly bool) {
 	manifest, err := manifest.ProjectManifest()
 	if err != nil {
@@ -574,23 +667,81 @@ func HandleUpdateResult(result InstallResult, pluginName string, exitIfFailure b
 	return true
 }
 
-func installPluginsFromManifest(manifest *manifest.Manifest, silent, languageOnly bool) {
-	pluginsMap := make(map[string]bool)
-	pluginsMap[manifest.Language] = true
+func installPluginsFromManifest(m *manifest.Manifest, silent, languageOnly bool) {
+	l, err := manifest.ProjectLock()
+	if err != nil {
+		logger.Fatalf(true, err.Error())
+	}
+	installManifestPlugin(m, l, m.Language, true, silent)
 	if !languageOnly {
-		for _, plugin := range manifest.Plugins {
-			pluginsMap[plugin] = false
+		for _, pluginName := range m.Plugins {
+			installManifestPlugin(m, l, pluginName, false, silent)
 		}
+		l.Retain(append([]string{m.Language}, m.Plugins...))
+	}
+	if err := l.Save(); err != nil {
+		logger.Errorf(true, "Failed to write %s. %s", manifest.LockFile, err.Error())
+	}
+	if err := manifest.RecordProject(); err != nil {
+		logger.Debugf(true, "Failed to record the project in %s. %s", manifest.ProjectsFile, err.Error())
 	}
+}
 
-	for pluginName, isRunner := range pluginsMap {
-		if !IsCompatiblePluginInstalled(pluginName, isRunner) {
-			logger.Infof(true, "Compatible version of plugin %s not found. Installing plugin %s...", pluginName, pluginName)
-			HandleInstallResult(Plugin(pluginName, "", silent), pluginName, false)
-		} else {
+func installManifestPlugin(m *manifest.Manifest, l *manifest.Lock, pluginName string, isRunner, silent bool) {
+	if locked, ok := l.Plugins[pluginName]; ok && locked.Version != "" {
+		if err := m.CheckLockedVersion(pluginName, locked.Version); err == nil {
+			if common.IsPluginInstalled(pluginName, locked.Version) {
+				logger.Debugf(true, "Plugin %s %s is already installed.", pluginName, locked.Version)
+				return
+			}
+			logger.Infof(true, "Installing plugin %s %s locked in %s...", pluginName, locked.Version, manifest.LockFile)
+			HandleInstallResult(lockedPlugin(pluginName, locked, silent), pluginName, false)
+			return
+		}
+		logger.Infof(true, "Version %s of plugin %s locked in %s does not match %s, resolving it again.", locked.Version, pluginName, manifest.LockFile, common.ManifestFile)
+		delete(l.Plugins, pluginName)
+	}
+	constraint, err := m.VersionConstraint(pluginName)
+	if err != nil {
+		logger.Errorf(true, err.Error())
+		return
+	}
+	if v, err := plugin.VersionUsedBy(l, m, pluginName); err == nil {
+		if v, ok := compatibleInstalledVersion(pluginName, v, isRunner); ok {
 			logger.Debugf(true, "Plugin %s is already installed.", pluginName)
+			l.Plugins[pluginName] = manifest.LockedPlugin{Version: v, Checksum: installedChecksum(pluginName, v)}
+			return
+		}
+	}
+	logger.Infof(true, "Compatible version of plugin %s not found. Installing plugin %s...", pluginName, pluginName)
+	res := pluginMatching(pluginName, constraint, silent)
+	if HandleInstallResult(res, pluginName, false) && res.Version != "" {
+		l.Plugins[pluginName] = manifest.LockedPlugin{Version: res.Version, Checksum: res.Checksum}
+	}
+}
+
+// compatibleInstalledVersion returns the installed version of the plugin, or the latest installed one when v is empty,
+// if it is compatible with the current gauge.
+func compatibleInstalledVersion(pluginName, v string, isRunner bool) (string, bool) {
+	dir, err := plugin.GetInstallDir(pluginName, v)
+	if err != nil {
+		return "", false
+	}
+	var support version.VersionSupport
+	if isRunner {
+		r, err := getRunnerJSONContents(filepath.Join(dir, pluginName+jsonExt))
+		if err != nil {
+			return "", false
+		}
+		support = r.GaugeVersionSupport
+	} else {
+		pd, err := plugin.GetPluginDescriptorFromJSON(filepath.Join(dir, common.PluginJSONFile))
+		if err != nil {
+			return "", false
 		}
+		support = pd.GaugeVersionSupport
 	}
+	return filepath.Base(dir), version.CheckCompatibility(version.CurrentGaugeVersion, &support) == nil
 }
 
 // IsCompatiblePluginInstalled checks if a plugin compatible to gauge is installed
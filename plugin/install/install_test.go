@@ -8,10 +8,15 @@ package install
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
 
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/manifest"
 	"github.com/getgauge/gauge/util"
 	"github.com/getgauge/gauge/version"
 	. "gopkg.in/check.v1"
@@ -69,6 +74,26 @@ func (s *MySuite) TestFindingLatestCompatibleVersionFailing(c *C) {
 	c.Assert(err, NotNil)
 }
 
+func (s *MySuite) TestFindingLatestCompatibleVersionMatchingConstraint(c *C) {
+	installDescription := createInstallDescriptionWithVersions("5.0.0", "4.1.0", "4.3.1", "4.2.0")
+	addVersionSupportToInstallDescription(installDescription,
+		&version.VersionSupport{Minimum: "0.0.2"},
+		&version.VersionSupport{Minimum: "0.0.2"},
+		&version.VersionSupport{Minimum: "1.2.0"},
+		&version.VersionSupport{Minimum: "0.0.2"})
+	constraint, err := version.ParseConstraint("^4.1")
+	c.Assert(err, Equals, nil)
+
+	versionInstallDesc, err := installDescription.getLatestCompatibleVersionMatching(&version.Version{Major: 1, Minor: 0, Patch: 0}, constraint)
+	c.Assert(err, Equals, nil)
+	c.Assert(versionInstallDesc.Version, Equals, "4.2.0")
+
+	constraint, err = version.ParseConstraint("^3.0")
+	c.Assert(err, Equals, nil)
+	_, err = installDescription.getLatestCompatibleVersionMatching(&version.Version{Major: 1, Minor: 0, Patch: 0}, constraint)
+	c.Assert(err, NotNil)
+}
+
 func createInstallDescriptionWithVersions(versionNumbers ...string) *installDescription {
 	var versionInstallDescriptions []versionInstallDescription
 	for _, version := range versionNumbers {
@@ -171,3 +196,48 @@ func (s *MySuite) TestIsPlatformIndependentZipFile(c *C) {
 	c.Assert(isPlatformIndependent(javaNightly), Equals, false)
 	c.Assert(isPlatformIndependent(csharpNightly), Equals, true)
 }
+
+func (s *MySuite) TestInstallingFromManifestLocksInstalledPluginsAndRunner(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	c.Assert(Plugin("testplugin", "1.0.0", true).Error, IsNil)
+	runnerDir := filepath.Join(r.gaugeHome, "plugins", "java", "0.7.0")
+	c.Assert(os.MkdirAll(runnerDir, common.NewDirectoryPermissions), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(runnerDir, "java.json"), []byte(`{"id": "java", "version": "0.7.0", "gaugeVersionSupport": {"minimum": "0.0.1"}}`), common.NewFilePermissions), IsNil)
+	project, err := ioutil.TempDir("", "project")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(project)
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = project
+	defer func() { config.ProjectRoot = oldRoot }()
+
+	installPluginsFromManifest(&manifest.Manifest{Language: "java", Plugins: []string{"testplugin"}}, true, false)
+
+	l, err := manifest.ProjectLock()
+	c.Assert(err, IsNil)
+	c.Assert(l.Plugins, DeepEquals, map[string]manifest.LockedPlugin{
+		"java":       {Version: "0.7.0"},
+		"testplugin": {Version: "1.0.0", Checksum: "sha256:" + r.checksum},
+	})
+}
+
+func (s *MySuite) TestInstallingFromManifestResolvesAgainALockedVersionWhichDoesNotMatchTheManifest(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	runnerDir := filepath.Join(r.gaugeHome, "plugins", "java", "0.7.0")
+	c.Assert(os.MkdirAll(runnerDir, common.NewDirectoryPermissions), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(runnerDir, "java.json"), []byte(`{"id": "java", "version": "0.7.0", "gaugeVersionSupport": {"minimum": "0.0.1"}}`), common.NewFilePermissions), IsNil)
+	project, err := ioutil.TempDir("", "project")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(project)
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = project
+	defer func() { config.ProjectRoot = oldRoot }()
+	c.Assert(ioutil.WriteFile(filepath.Join(project, manifest.LockFile), []byte(`{"plugins": {"java": {"version": "0.7.0"}, "testplugin": {"version": "0.9.0"}}}`), common.NewFilePermissions), IsNil)
+
+	installPluginsFromManifest(&manifest.Manifest{Language: "java", Plugins: []string{"testplugin"}, Versions: map[string]string{"testplugin": "^1.0"}}, true, false)
+
+	l, err := manifest.ProjectLock()
+	c.Assert(err, IsNil)
+	c.Assert(l.Plugins["testplugin"], DeepEquals, manifest.LockedPlugin{Version: "1.0.0", Checksum: "sha256:" + r.checksum})
+}
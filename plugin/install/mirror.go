@@ -0,0 +1,140 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/version"
+)
+
+// Mirror downloads the language runner and plugins of the project, at their locked or latest allowed versions, into
+// the directory for all platforms. The directory gets an install description for each plugin pointing to the
+// downloaded archives, so that it can be used with install --from-dir or listed in plugin_repositories.
+func Mirror(dir string, silent bool) error {
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		return err
+	}
+	l, err := manifest.ProjectLock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		return err
+	}
+	for _, pluginName := range append([]string{m.Language}, m.Plugins...) {
+		if err := mirrorPlugin(dir, m, l, pluginName, silent); err != nil {
+			return fmt.Errorf("Failed to mirror plugin %s. %s", pluginName, err.Error())
+		}
+	}
+	return nil
+}
+
+func mirrorPlugin(dir string, m *manifest.Manifest, l *manifest.Lock, pluginName string, silent bool) error {
+	desc, result := getInstallDescription(pluginName, silent)
+	if !result.Success {
+		return result.Error
+	}
+	versionInstallDescription, err := versionToMirror(desc, m, l, pluginName)
+	if err != nil {
+		return err
+	}
+	mirrored, err := mirrorVersion(dir, desc.repository, versionInstallDescription, silent)
+	if err != nil {
+		return err
+	}
+
+	jsonFile := filepath.Join(dir, installJSONFile(pluginName))
+	mirror := &installDescription{Name: desc.Name, Description: desc.Description}
+	if common.FileExists(jsonFile) {
+		existing, result := getInstallDescriptionFromJSON(jsonFile)
+		if !result.Success {
+			return result.Error
+		}
+		for _, v := range existing.Versions {
+			if v.Version != mirrored.Version {
+				mirror.Versions = append(mirror.Versions, v)
+			}
+		}
+	}
+	mirror.Versions = append(mirror.Versions, *mirrored)
+	mirror.sortVersionInstallDescriptions()
+	b, err := json.MarshalIndent(mirror, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(jsonFile, b, common.NewFilePermissions); err != nil {
+		return err
+	}
+	logger.Infof(true, "Mirrored plugin %s %s", pluginName, mirrored.Version)
+	return nil
+}
+
+func versionToMirror(desc *installDescription, m *manifest.Manifest, l *manifest.Lock, pluginName string) (*versionInstallDescription, error) {
+	if v, ok := l.Version(pluginName); ok {
+		if err := m.CheckLockedVersion(pluginName, v); err != nil {
+			return nil, err
+		}
+		return desc.getVersion(v)
+	}
+	constraint, err := m.VersionConstraint(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	return desc.getLatestCompatibleVersionMatching(version.CurrentGaugeVersion, constraint)
+}
+
+// mirrorVersion downloads the archives and signatures of the version for all platforms into the directory, verifying
+// the checksums which are given and filling in the ones which are not, and returns its install description with the
+// downloaded file names as URLs.
+func mirrorVersion(dir string, repo repository, versionInstallDescription *versionInstallDescription, silent bool) (*versionInstallDescription, error) {
+	mirrored := *versionInstallDescription
+	links, checksums, signatures := mirrored.DownloadUrls.all(), mirrored.Checksums.all(), mirrored.SignatureUrls.all()
+	type download struct{ file, checksum string }
+	downloaded := make(map[string]download)
+	for i, link := range links {
+		if *link == "" {
+			continue
+		}
+		d, ok := downloaded[*link]
+		if !ok {
+			archive, err := fetch(repo.resolve(*link), dir, "", silent)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to download %s. %s", *link, err.Error())
+			}
+			archiveChecksum, err := fileChecksum(archive)
+			if err != nil {
+				return nil, err
+			}
+			d = download{file: filepath.Base(archive), checksum: archiveChecksum}
+			downloaded[*link] = d
+		}
+		if *checksums[i] == "" {
+			*checksums[i] = d.checksum
+		} else if err := verifyChecksum(d.file, *checksums[i], d.checksum); err != nil {
+			os.Remove(filepath.Join(dir, d.file))
+			return nil, err
+		}
+		*link = d.file
+		if *signatures[i] != "" {
+			signature := *link + ".sig"
+			if _, err := fetch(repo.resolve(*signatures[i]), dir, signature, silent); err != nil {
+				return nil, fmt.Errorf("Failed to download %s. %s", *signatures[i], err.Error())
+			}
+			*signatures[i] = signature
+		}
+	}
+	return &mirrored, nil
+}
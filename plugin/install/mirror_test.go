@@ -0,0 +1,92 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func mirrorProject(c *C, r *testRepository) string {
+	project, err := ioutil.TempDir("", "project")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(project)
+	err = ioutil.WriteFile(filepath.Join(project, common.ManifestFile), []byte(`{"Language": "testplugin", "Plugins": []}`), common.NewFilePermissions)
+	c.Assert(err, IsNil)
+	oldRoot := config.ProjectRoot
+	config.ProjectRoot = project
+	defer func() { config.ProjectRoot = oldRoot }()
+
+	mirror, err := ioutil.TempDir("", "mirror")
+	c.Assert(err, IsNil)
+	c.Assert(Mirror(mirror, true), IsNil)
+	return mirror
+}
+
+func (s *MySuite) TestMirroringPluginsOfProject(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+
+	mirror := mirrorProject(c, r)
+	defer os.RemoveAll(mirror)
+
+	c.Assert(common.FileExists(filepath.Join(mirror, "testplugin-1.0.0.zip")), Equals, true)
+	desc, result := getInstallDescriptionFromJSON(filepath.Join(mirror, "testplugin-install.json"))
+	c.Assert(result.Error, IsNil)
+	c.Assert(desc.Versions, HasLen, 1)
+	c.Assert(desc.Versions[0].Version, Equals, "1.0.0")
+	c.Assert(desc.Versions[0].DownloadUrls.X64.Linux, Equals, "testplugin-1.0.0.zip")
+	c.Assert(desc.Versions[0].Checksums.X64.Linux, Equals, r.checksum)
+}
+
+func (s *MySuite) TestMirroringPluginWithoutChecksumRecordsIt(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	checksum := "sha256:" + r.checksum
+	r.checksum = ""
+
+	mirror := mirrorProject(c, r)
+	defer os.RemoveAll(mirror)
+
+	desc, result := getInstallDescriptionFromJSON(filepath.Join(mirror, "testplugin-install.json"))
+	c.Assert(result.Error, IsNil)
+	c.Assert(desc.Versions[0].Checksums.X64.Linux, Equals, checksum)
+	c.Assert(desc.Versions[0].Checksums.X86.Windows, Equals, checksum)
+}
+
+func (s *MySuite) TestInstallingPluginFromMirrorDir(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	mirror := mirrorProject(c, r)
+	defer os.RemoveAll(mirror)
+	r.server.Close()
+
+	FromDir = mirror
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, IsNil)
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, true)
+}
+
+func (s *MySuite) TestInstallingPluginFromRepositoriesInOrder(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	mirror := mirrorProject(c, r)
+	defer os.RemoveAll(mirror)
+	r.server.Close()
+	r.configure(c, "plugin_repositories = "+filepath.Join(mirror, "missing")+", "+mirror)
+
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, IsNil)
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, true)
+}
@@ -0,0 +1,86 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/util"
+)
+
+// FromDir is a local mirror of plugins to install from instead of the configured plugin repositories.
+var FromDir string
+
+// repository is a source of plugin install descriptions and archives. It is either the base URL of an HTTP repository
+// or a local directory with a <plugin>-install.json file for each plugin, like the ones created by Mirror.
+// Download URLs in the install descriptions which are not absolute are relative to the repository.
+type repository string
+
+func repositories() []repository {
+	if FromDir != "" {
+		return []repository{repository(FromDir)}
+	}
+	var repos []repository
+	for _, r := range config.PluginRepositories() {
+		repos = append(repos, repository(r))
+	}
+	return repos
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+func installJSONFile(pluginName string) string {
+	return pluginName + "-install.json"
+}
+
+func (r repository) isLocal() bool {
+	return !isURL(string(r))
+}
+
+func (r repository) installJSONLocation(pluginName string) string {
+	if r.isLocal() {
+		return filepath.Join(string(r), installJSONFile(pluginName))
+	}
+	jsonURL := fmt.Sprintf("%s/%s", strings.TrimSuffix(string(r), "/"), pluginName)
+	if qp := plugin.QueryParams(); qp != "" {
+		jsonURL += qp
+	}
+	return jsonURL
+}
+
+// resolve returns the location of a file listed in an install description of the repository.
+func (r repository) resolve(location string) string {
+	if isURL(location) || filepath.IsAbs(location) {
+		return location
+	}
+	if r.isLocal() {
+		return filepath.Join(string(r), filepath.FromSlash(location))
+	}
+	return strings.TrimSuffix(string(r), "/") + "/" + location
+}
+
+// fetch downloads the file at the URL, or copies the local file, into the target directory.
+func fetch(location, targetDir, fileName string, silent bool) (string, error) {
+	if isURL(location) {
+		return util.Download(location, targetDir, fileName, silent)
+	}
+	if fileName == "" {
+		fileName = filepath.Base(location)
+	}
+	target := filepath.Join(targetDir, fileName)
+	if err := common.CopyFile(location, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
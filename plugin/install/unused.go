@@ -0,0 +1,150 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/plugin/pluginInfo"
+	"github.com/getgauge/gauge/version"
+)
+
+// UninstallUnused uninstalls the versions of the plugins which are neither used by a known project nor the newest
+// version compatible with Gauge. The versions are only listed unless force is set. Nothing is uninstalled if the
+// plugins used by any known project cannot be found.
+func UninstallUnused(force bool) {
+	used, projects, err := versionsUsedByKnownProjects()
+	if err != nil {
+		logger.Fatalf(true, "Failed to find the plugins used by known projects. %s", err.Error())
+	}
+	logger.Infof(true, "Keeping the versions of plugins used by %d known project(s). Projects are known once they have run or installed their plugins, versions used only by other projects are taken to be unused.", projects)
+	unused, err := unusedVersions(used)
+	if err != nil {
+		logger.Fatalf(true, "Failed to find unused plugins. %s", err.Error())
+	}
+	if len(unused) == 0 {
+		logger.Infof(true, "No unused plugins found.")
+		return
+	}
+	var failed bool
+	var freed int64
+	for _, p := range unused {
+		v := filepath.Base(p.Path)
+		size := dirSize(p.Path)
+		if !force {
+			logger.Infof(true, "Would uninstall plugin %s %s (%s).", p.Name, v, formatSize(size))
+			freed += size
+			continue
+		}
+		if err := uninstallVersionOfPlugin(p.Path, p.Name, v); err != nil {
+			logger.Errorf(true, "Failed to uninstall plugin %s %s. %s", p.Name, v, err.Error())
+			failed = true
+			continue
+		}
+		freed += size
+	}
+	if !force {
+		logger.Infof(true, "Uninstalling them would free %s. Run `gauge uninstall --unused --force` to uninstall them.", formatSize(freed))
+	} else {
+		logger.Infof(true, "Freed %s.", formatSize(freed))
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// unusedVersions returns the installed versions of the plugins which are neither in the used versions, keyed by plugin
+// id and version directory, nor the newest version compatible with Gauge.
+func unusedVersions(used map[string]map[string]bool) ([]pluginInfo.PluginInfo, error) {
+	pluginsDir, err := common.GetPrimaryPluginsInstallDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(pluginsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var unused []pluginInfo.PluginInfo
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+		installed, err := pluginInfo.GetInstalledPlugins(filepath.Join(pluginsDir, f.Name()))
+		if err != nil {
+			continue
+		}
+		keep := newestCompatible(f.Name(), installed)
+		for _, p := range installed {
+			if p.Path != keep && !used[f.Name()][filepath.Base(p.Path)] {
+				unused = append(unused, p)
+			}
+		}
+	}
+	return unused, nil
+}
+
+// newestCompatible returns the path of the newest installed version of the plugin which supports the current version
+// of Gauge, or else of the newest installed version.
+func newestCompatible(pluginID string, installed []pluginInfo.PluginInfo) string {
+	for _, p := range installed {
+		gp, err := parsePluginJSON(p.Path, pluginID)
+		if err == nil && version.CheckCompatibility(version.CurrentGaugeVersion, &gp.GaugeVersionSupport) == nil {
+			return p.Path
+		}
+	}
+	return installed[0].Path
+}
+
+// versionsUsedByKnownProjects returns the version directories of the language runner and plugins used by each known
+// project, keyed by plugin id, along with the number of known projects. It fails if the known projects, or the
+// manifest or lock file of any of them, cannot be read.
+func versionsUsedByKnownProjects() (map[string]map[string]bool, int, error) {
+	used := make(map[string]map[string]bool)
+	projects, err := manifest.KnownProjects()
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, root := range projects {
+		m, err := manifest.ManifestAt(root)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read the manifest of %s. %s", root, err.Error())
+		}
+		l, err := manifest.LockAt(root)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read the lock file of %s. %s", root, err.Error())
+		}
+		for _, id := range append([]string{m.Language}, m.Plugins...) {
+			v, err := plugin.VersionUsedBy(l, m, id)
+			if err != nil {
+				logger.Debugf(true, "No installed version of plugin %s used by %s. %s", id, root, err.Error())
+				continue
+			}
+			if v == "" {
+				dir, err := plugin.GetInstallDir(id, "")
+				if err != nil {
+					continue
+				}
+				v = filepath.Base(dir)
+			}
+			if used[id] == nil {
+				used[id] = make(map[string]bool)
+			}
+			used[id][v] = true
+		}
+	}
+	return used, len(projects), nil
+}
@@ -0,0 +1,114 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/manifest"
+	. "gopkg.in/check.v1"
+)
+
+// setUpPluginsHome creates a Gauge home with versions of html-report, one of which is not compatible with Gauge, and
+// of the java runner, along with a known project which locks html-report 4.0.0.
+func setUpPluginsHome(c *C) (string, func()) {
+	home, err := ioutil.TempDir("", "gauge-home")
+	c.Assert(err, IsNil)
+	oldHome, oldRoot := os.Getenv(common.GaugeHome), config.ProjectRoot
+	os.Setenv(common.GaugeHome, home)
+	write := func(path, contents string) {
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0750), IsNil)
+		c.Assert(ioutil.WriteFile(path, []byte(contents), 0640), IsNil)
+	}
+	for v, min := range map[string]string{"3.0.0": "0.0.1", "4.0.0": "0.0.1", "4.1.0": "0.0.1", "5.0.0": "99.0.0"} {
+		write(filepath.Join(home, "plugins", "html-report", v, pluginJSON),
+			fmt.Sprintf(`{"id": "html-report", "version": "%s", "gaugeVersionSupport": {"minimum": "%s"}}`, v, min))
+	}
+	for _, v := range []string{"0.6.0", "0.7.0"} {
+		write(filepath.Join(home, "plugins", "java", v, "java.json"),
+			fmt.Sprintf(`{"id": "java", "version": "%s", "gaugeVersionSupport": {"minimum": "0.0.1"}}`, v))
+	}
+	project := filepath.Join(home, "shop")
+	write(filepath.Join(project, common.ManifestFile), `{"Language": "java", "Plugins": ["html-report"]}`)
+	write(filepath.Join(project, manifest.LockFile), `{"plugins": {"html-report": {"version": "4.0.0"}}}`)
+	config.ProjectRoot = project
+	c.Assert(manifest.RecordProject(), IsNil)
+	return home, func() {
+		os.RemoveAll(home)
+		os.Setenv(common.GaugeHome, oldHome)
+		config.ProjectRoot = oldRoot
+	}
+}
+
+func (s *MySuite) TestUnusedVersionsKeepVersionsOfKnownProjectsAndNewestCompatible(c *C) {
+	_, tearDown := setUpPluginsHome(c)
+	defer tearDown()
+
+	used, projects, err := versionsUsedByKnownProjects()
+	c.Assert(err, IsNil)
+	unused, err := unusedVersions(used)
+
+	c.Assert(err, IsNil)
+	c.Assert(projects, Equals, 1)
+	var got []string
+	for _, p := range unused {
+		got = append(got, fmt.Sprintf("%s %s", p.Name, filepath.Base(p.Path)))
+	}
+	sort.Strings(got)
+	c.Assert(got, DeepEquals, []string{"html-report 3.0.0", "html-report 5.0.0", "java 0.6.0"})
+}
+
+func (s *MySuite) TestVersionsUsedByKnownProjectsFailIfALockFileCannotBeRead(c *C) {
+	home, tearDown := setUpPluginsHome(c)
+	defer tearDown()
+	c.Assert(ioutil.WriteFile(filepath.Join(home, "shop", manifest.LockFile), []byte("{"), 0640), IsNil)
+
+	_, _, err := versionsUsedByKnownProjects()
+
+	c.Assert(err, ErrorMatches, "failed to read the lock file of .*shop.*")
+}
+
+func (s *MySuite) TestVersionsUsedByKnownProjectsFailIfTheKnownProjectsCannotBeRead(c *C) {
+	_, tearDown := setUpPluginsHome(c)
+	defer tearDown()
+	dir, err := common.GetConfigurationDir()
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, manifest.ProjectsFile), []byte("["), 0640), IsNil)
+
+	_, _, err = versionsUsedByKnownProjects()
+
+	c.Assert(err, ErrorMatches, "Failed to read .*")
+}
+
+func (s *MySuite) TestDiskUsageOfEachVersionLatestFirst(c *C) {
+	home, tearDown := setUpPluginsHome(c)
+	defer tearDown()
+	c.Assert(os.MkdirAll(filepath.Join(home, "plugins", "java", "backup"), 0750), IsNil)
+
+	usages, err := DiskUsage()
+
+	c.Assert(err, IsNil)
+	var got []string
+	for _, u := range usages {
+		c.Assert(u.Size > 0 || u.Version == "backup", Equals, true)
+		got = append(got, fmt.Sprintf("%s %s", u.Plugin, u.Version))
+	}
+	c.Assert(got, DeepEquals, []string{"html-report 5.0.0", "html-report 4.1.0", "html-report 4.0.0", "html-report 3.0.0",
+		"java 0.7.0", "java 0.6.0", "java backup"})
+}
+
+func (s *MySuite) TestFormatSize(c *C) {
+	c.Assert(formatSize(512), Equals, "512 B")
+	c.Assert(formatSize(1536), Equals, "1.5 KB")
+	c.Assert(formatSize(12*1024*1024+512*1024), Equals, "12.5 MB")
+}
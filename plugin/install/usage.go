@@ -0,0 +1,111 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin/pluginInfo"
+)
+
+// Usage is the disk space used by an installed version of a plugin, in bytes.
+type Usage struct {
+	Plugin  string
+	Version string
+	Size    int64
+}
+
+// DiskUsage returns the disk space used by each directory in the plugins install directory, by plugin and then by
+// latest version first. Directories which are not a version of the plugin are included.
+func DiskUsage() ([]Usage, error) {
+	pluginsDir, err := common.GetPrimaryPluginsInstallDir()
+	if err != nil {
+		return nil, err
+	}
+	plugins, err := ioutil.ReadDir(pluginsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var usages []Usage
+	for _, p := range plugins {
+		if !p.IsDir() {
+			continue
+		}
+		pluginDir := filepath.Join(pluginsDir, p.Name())
+		listed := make(map[string]bool)
+		installed, _ := pluginInfo.GetInstalledPlugins(pluginDir)
+		for _, i := range installed {
+			listed[i.Path] = true
+			usages = append(usages, Usage{Plugin: p.Name(), Version: filepath.Base(i.Path), Size: dirSize(i.Path)})
+		}
+		dirs, err := ioutil.ReadDir(pluginDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dirs {
+			if path := filepath.Join(pluginDir, d.Name()); d.IsDir() && !listed[path] {
+				usages = append(usages, Usage{Plugin: p.Name(), Version: d.Name(), Size: dirSize(path)})
+			}
+		}
+	}
+	return usages, nil
+}
+
+// PrintDiskUsage prints the disk space used by each version of the plugins, with the total of each plugin.
+func PrintDiskUsage() {
+	usages, err := DiskUsage()
+	if err != nil {
+		logger.Fatalf(true, "Failed to find the disk usage of plugins. %s", err.Error())
+	}
+	var total, pluginTotal int64
+	for i, u := range usages {
+		if i == 0 || usages[i-1].Plugin != u.Plugin {
+			logger.Infof(true, "%s", u.Plugin)
+			pluginTotal = 0
+		}
+		logger.Infof(true, "  %-30s %10s", u.Version, formatSize(u.Size))
+		pluginTotal += u.Size
+		total += u.Size
+		if i == len(usages)-1 || usages[i+1].Plugin != u.Plugin {
+			logger.Infof(true, "  %-30s %10s", "total", formatSize(pluginTotal))
+		}
+	}
+	logger.Infof(true, "%-32s %10s", "Total", formatSize(total))
+}
+
+// dirSize returns the total size of the files in the directory. Files which cannot be read are not counted.
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,130 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+)
+
+const checksumPrefix = "sha256:"
+
+// AllowUnverified allows installing plugin archives which have no checksum in the install description when
+// require_plugin_checksums is set, which have no signature while keys are trusted, or whose signature cannot be checked
+// because no keys are trusted. Archives which fail a check are never installed.
+var AllowUnverified bool
+
+// verifyArchive checks the downloaded archive against the checksum in the install description of its version and,
+// if the description has a detached signature, against the keys trusted in gauge.properties. An archive without a
+// checksum is installed with a warning, unless require_plugin_checksums is set in gauge.properties. Once keys are
+// trusted, an archive without a signature is not installed.
+func verifyArchive(repo repository, archive, archiveChecksum string, versionInstallDescription *versionInstallDescription) error {
+	name := filepath.Base(archive)
+	expected := platformValue(versionInstallDescription.Checksums)
+	if expected == "" {
+		if config.RequirePluginChecksums() && !AllowUnverified {
+			return fmt.Errorf("No checksum found for %s in the plugin install description. Use --allow-unverified to install it anyway", name)
+		}
+		logger.Warningf(true, "Installing %s without verifying its checksum.", name)
+	} else if err := verifyChecksum(name, expected, archiveChecksum); err != nil {
+		return err
+	}
+	signatureURL := platformValue(versionInstallDescription.SignatureUrls)
+	keys := config.TrustedPluginKeys()
+	if signatureURL == "" {
+		if len(keys) == 0 {
+			return nil
+		}
+		if !AllowUnverified {
+			return fmt.Errorf("No signature found for %s in the plugin install description, while trusted_plugin_keys is set. Use --allow-unverified to install it anyway", name)
+		}
+		logger.Warningf(true, "Installing %s without verifying its signature.", name)
+		return nil
+	}
+	if len(keys) == 0 {
+		if !AllowUnverified {
+			return fmt.Errorf("Unable to verify the signature of %s, no keys are trusted. Add the signing key with 'gauge config trusted_plugin_keys <key>' or use --allow-unverified", name)
+		}
+		logger.Warningf(true, "Installing %s without verifying its signature.", name)
+		return nil
+	}
+	signatureFile, err := fetch(repo.resolve(signatureURL), filepath.Dir(archive), name+".sig", true)
+	if err != nil {
+		return fmt.Errorf("Failed to download the signature of %s. %s", name, err.Error())
+	}
+	return verifySignature(archive, signatureFile, keys)
+}
+
+// verifyChecksum compares the checksum of an archive with the expected one, which is a sha256 checksum in hex with an
+// optional sha256: prefix.
+func verifyChecksum(name, expected, archiveChecksum string) error {
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	if strings.Contains(expected, ":") && !strings.HasPrefix(expected, checksumPrefix) {
+		return fmt.Errorf("Unsupported checksum %s for %s. Only sha256 checksums are supported", expected, name)
+	}
+	if !strings.HasPrefix(expected, checksumPrefix) {
+		expected = checksumPrefix + expected
+	}
+	if expected != archiveChecksum {
+		return fmt.Errorf("Checksum mismatch for %s. Expected %s but got %s", name, expected, archiveChecksum)
+	}
+	return nil
+}
+
+// verifySignature checks that the base64 encoded ed25519 signature in the signature file is of the archive and by one
+// of the keys.
+func verifySignature(archive, signatureFile string, keys []string) error {
+	name := filepath.Base(archive)
+	contents, err := common.ReadFileContents(signatureFile)
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(contents))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("Invalid signature for %s", name)
+	}
+	data, err := ioutil.ReadFile(archive)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		key, err := base64.StdEncoding.DecodeString(k)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			logger.Warningf(true, "Ignoring invalid key '%s' in trusted_plugin_keys.", k)
+			continue
+		}
+		if ed25519.Verify(ed25519.PublicKey(key), data, signature) {
+			return nil
+		}
+	}
+	return fmt.Errorf("Signature verification failed for %s. It is not signed by a trusted key", name)
+}
+
+func fileChecksum(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return checksumPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,212 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package install
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	. "gopkg.in/check.v1"
+)
+
+// testRepository stands in for the plugin repository, serving the install description, archive and signature of
+// version 1.0.0 of testplugin.
+type testRepository struct {
+	server    *httptest.Server
+	archive   []byte
+	signature []byte
+	checksum  string
+	gaugeHome string
+	oldHome   string
+}
+
+func newTestRepository(c *C) *testRepository {
+	r := &testRepository{archive: pluginArchive(c)}
+	sum := sha256.Sum256(r.archive)
+	r.checksum = hex.EncodeToString(sum[:])
+	mux := http.NewServeMux()
+	mux.HandleFunc("/testplugin-1.0.0.zip", func(w http.ResponseWriter, _ *http.Request) { w.Write(r.archive) })
+	mux.HandleFunc("/testplugin-1.0.0.zip.sig", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(base64.StdEncoding.EncodeToString(r.signature)))
+	})
+	mux.HandleFunc("/testplugin", func(w http.ResponseWriter, _ *http.Request) {
+		url := r.server.URL + "/testplugin-1.0.0.zip"
+		v := map[string]interface{}{
+			"Version":             "1.0.0",
+			"GaugeVersionSupport": map[string]string{"Minimum": "0.0.1"},
+			"DownloadUrls":        allPlatforms(url),
+			"Checksums":           allPlatforms(r.checksum),
+		}
+		if r.signature != nil {
+			v["SignatureUrls"] = allPlatforms(url + ".sig")
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"Name": "testplugin", "Versions": []interface{}{v}})
+	})
+	r.server = httptest.NewServer(mux)
+
+	var err error
+	r.gaugeHome, err = ioutil.TempDir("", "gauge_home")
+	c.Assert(err, IsNil)
+	r.oldHome = os.Getenv(common.GaugeHome)
+	os.Setenv(common.GaugeHome, r.gaugeHome)
+	r.configure(c, "")
+	return r
+}
+
+func (r *testRepository) configure(c *C, properties string) {
+	configDir := filepath.Join(r.gaugeHome, "config")
+	c.Assert(os.MkdirAll(configDir, common.NewDirectoryPermissions), IsNil)
+	properties = fmt.Sprintf("gauge_repository_url = %s\n%s\n", r.server.URL, properties)
+	c.Assert(ioutil.WriteFile(filepath.Join(configDir, common.GaugePropertiesFile), []byte(properties), common.NewFilePermissions), IsNil)
+}
+
+func (r *testRepository) close() {
+	r.server.Close()
+	os.Setenv(common.GaugeHome, r.oldHome)
+	os.RemoveAll(r.gaugeHome)
+	AllowUnverified = false
+	FromDir = ""
+}
+
+func allPlatforms(value string) map[string]map[string]string {
+	p := map[string]string{"windows": value, "linux": value, "darwin": value}
+	return map[string]map[string]string{"x86": p, "x64": p}
+}
+
+func pluginArchive(c *C) []byte {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	f, err := w.Create("plugin.json")
+	c.Assert(err, IsNil)
+	_, err = f.Write([]byte(`{"id": "testplugin", "version": "1.0.0"}`))
+	c.Assert(err, IsNil)
+	c.Assert(w.Close(), IsNil)
+	return b.Bytes()
+}
+
+func (s *MySuite) TestInstallingPluginWithMatchingChecksum(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, IsNil)
+	c.Assert(res.Success, Equals, true)
+	c.Assert(res.Checksum, Equals, "sha256:"+r.checksum)
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, true)
+}
+
+func (s *MySuite) TestInstallingPluginWithMismatchingChecksumFails(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	r.checksum = "sha256:" + r.checksum[1:] + "0"
+	AllowUnverified = true
+
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, ErrorMatches, "Checksum mismatch for testplugin-1.0.0.zip. Expected sha256:.* but got sha256:.*")
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, false)
+}
+
+func (s *MySuite) TestInstallingPluginWithoutChecksum(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	r.checksum = ""
+
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, IsNil)
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, true)
+}
+
+func (s *MySuite) TestInstallingPluginWithoutChecksumNeedsAllowUnverifiedWhenChecksumsAreRequired(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	r.checksum = ""
+	r.configure(c, "require_plugin_checksums = true")
+
+	res := Plugin("testplugin", "1.0.0", true)
+	c.Assert(res.Error, ErrorMatches, "No checksum found for testplugin-1.0.0.zip.*--allow-unverified.*")
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, false)
+
+	AllowUnverified = true
+	res = Plugin("testplugin", "1.0.0", true)
+	c.Assert(res.Error, IsNil)
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, true)
+}
+
+func (s *MySuite) TestInstallingPluginSignedByTrustedKey(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	public, private, err := ed25519.GenerateKey(nil)
+	c.Assert(err, IsNil)
+	r.signature = ed25519.Sign(private, r.archive)
+	r.configure(c, "trusted_plugin_keys = "+base64.StdEncoding.EncodeToString(public))
+
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, IsNil)
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, true)
+}
+
+func (s *MySuite) TestInstallingPluginSignedByUntrustedKeyFails(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	public, _, err := ed25519.GenerateKey(nil)
+	c.Assert(err, IsNil)
+	_, other, err := ed25519.GenerateKey(nil)
+	c.Assert(err, IsNil)
+	r.signature = ed25519.Sign(other, r.archive)
+	r.configure(c, "trusted_plugin_keys = "+base64.StdEncoding.EncodeToString(public))
+	AllowUnverified = true
+
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, ErrorMatches, "Signature verification failed for testplugin-1.0.0.zip. It is not signed by a trusted key")
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, false)
+}
+
+func (s *MySuite) TestInstallingSignedPluginWithoutTrustedKeys(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	_, private, err := ed25519.GenerateKey(nil)
+	c.Assert(err, IsNil)
+	r.signature = ed25519.Sign(private, r.archive)
+
+	res := Plugin("testplugin", "1.0.0", true)
+
+	c.Assert(res.Error, ErrorMatches, "Unable to verify the signature of testplugin-1.0.0.zip, no keys are trusted.*")
+}
+
+func (s *MySuite) TestInstallingUnsignedPluginNeedsAllowUnverifiedWhenKeysAreTrusted(c *C) {
+	r := newTestRepository(c)
+	defer r.close()
+	public, _, err := ed25519.GenerateKey(nil)
+	c.Assert(err, IsNil)
+	r.configure(c, "trusted_plugin_keys = "+base64.StdEncoding.EncodeToString(public))
+
+	res := Plugin("testplugin", "1.0.0", true)
+	c.Assert(res.Error, ErrorMatches, "No signature found for testplugin-1.0.0.zip.*--allow-unverified.*")
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, false)
+
+	AllowUnverified = true
+	res = Plugin("testplugin", "1.0.0", true)
+	c.Assert(res.Error, IsNil)
+	c.Assert(common.IsPluginInstalled("testplugin", "1.0.0"), Equals, true)
+}
@@ -0,0 +1,82 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/gauge"
+)
+
+// Listener is an extension compiled into Gauge which receives the messages of an execution in process.
+// It gets the same messages as the plugins with execution scope, without starting a process or opening a connection.
+// Listeners are notified one message at a time and must not block, as the execution waits for them.
+type Listener interface {
+	Notify(*gauge_messages.Message) error
+}
+
+// ItemListener is a Listener which also gets the spec, scenario or step a message is about, for what the messages do
+// not carry, like line numbers, the metadata of specs and the rows of data tables. The item is nil for the messages
+// about the suite. ItemListeners are notified with NotifyItem instead of Notify.
+type ItemListener interface {
+	Listener
+	NotifyItem(*gauge_messages.Message, gauge.Item) error
+}
+
+// ListenerFunc adapts a function to a Listener.
+type ListenerFunc func(*gauge_messages.Message) error
+
+// Notify calls the function with the message.
+func (f ListenerFunc) Notify(m *gauge_messages.Message) error {
+	return f(m)
+}
+
+type registeredListener struct {
+	name     string
+	priority int
+	listener Listener
+}
+
+var listeners []*registeredListener
+var listenersMutex sync.Mutex
+
+// RegisterListener registers a listener by name for the executions started after it. Listeners are notified before
+// the plugins, in the increasing order of their priority and in the order they were registered for the same priority.
+// Registering a listener with the name of a registered one replaces it.
+func RegisterListener(name string, priority int, l Listener) {
+	listenersMutex.Lock()
+	defer listenersMutex.Unlock()
+	listeners = append(withoutListener(name), &registeredListener{name: name, priority: priority, listener: l})
+}
+
+// UnregisterListener removes the listener with the name. Executions which have already started keep notifying it.
+func UnregisterListener(name string) {
+	listenersMutex.Lock()
+	defer listenersMutex.Unlock()
+	listeners = withoutListener(name)
+}
+
+func withoutListener(name string) []*registeredListener {
+	var rest []*registeredListener
+	for _, l := range listeners {
+		if l.name != name {
+			rest = append(rest, l)
+		}
+	}
+	return rest
+}
+
+// registeredListeners returns the listeners in the order they are notified.
+func registeredListeners() []*registeredListener {
+	listenersMutex.Lock()
+	defer listenersMutex.Unlock()
+	ordered := append([]*registeredListener{}, listeners...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].priority < ordered[j].priority })
+	return ordered
+}
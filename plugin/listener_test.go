@@ -0,0 +1,137 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
+	"github.com/getgauge/gauge/gauge"
+)
+
+func recordingListener(name string, received *[]string) Listener {
+	return ListenerFunc(func(m *gm.Message) error {
+		*received = append(*received, fmt.Sprintf("%s:%s", name, m.GetMessageType().String()))
+		return nil
+	})
+}
+
+func resetListeners() {
+	listeners = nil
+}
+
+func TestListenersAreOrderedByPriorityAndRegistration(t *testing.T) {
+	defer resetListeners()
+	var received []string
+	RegisterListener("console", 10, recordingListener("console", &received))
+	RegisterListener("rerun", 0, recordingListener("rerun", &received))
+	RegisterListener("metrics", 10, recordingListener("metrics", &received))
+	gp := &GaugePlugins{listeners: registeredListeners()}
+
+	gp.NotifyPlugins(executionStarting, nil)
+
+	want := []string{"rerun:ExecutionStarting", "console:ExecutionStarting", "metrics:ExecutionStarting"}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("expected listeners to be notified in order %v, got %v", want, received)
+	}
+}
+
+func TestRegisterListenerReplacesListenerWithSameName(t *testing.T) {
+	defer resetListeners()
+	var received []string
+	RegisterListener("rerun", 0, recordingListener("old", &received))
+	RegisterListener("rerun", 0, recordingListener("new", &received))
+	gp := &GaugePlugins{listeners: registeredListeners()}
+
+	gp.NotifyPlugins(specStarting, nil)
+
+	want := []string{"new:SpecExecutionStarting"}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("expected only the replacing listener to be notified, got %v", received)
+	}
+}
+
+func TestUnregisterListenerAppliesToLaterExecutions(t *testing.T) {
+	defer resetListeners()
+	var received []string
+	RegisterListener("rerun", 0, recordingListener("rerun", &received))
+	started := &GaugePlugins{listeners: registeredListeners()}
+
+	UnregisterListener("rerun")
+	started.NotifyPlugins(executionStarting, nil)
+	(&GaugePlugins{listeners: registeredListeners()}).NotifyPlugins(executionStarting, nil)
+
+	want := []string{"rerun:ExecutionStarting"}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("expected only the started execution to notify the listener, got %v", received)
+	}
+}
+
+func TestFailingListenerDoesNotStopOtherListenersOrPlugins(t *testing.T) {
+	defer resetListeners()
+	var received []string
+	RegisterListener("broken", 0, ListenerFunc(func(m *gm.Message) error { return fmt.Errorf("disk full") }))
+	RegisterListener("rerun", 1, recordingListener("rerun", &received))
+	client := &recordingReporterClient{}
+	gp := &GaugePlugins{listeners: registeredListeners()}
+	gp.addPlugin("html-report", testPlugin(t, client))
+
+	gp.NotifyPlugins(suiteResult, nil)
+
+	if !reflect.DeepEqual(received, []string{"rerun:SuiteExecutionResult"}) {
+		t.Errorf("expected rerun listener to be notified, got %v", received)
+	}
+	if !reflect.DeepEqual(client.received, []gm.Message_MessageType{gm.Message_SuiteExecutionResult}) {
+		t.Errorf("expected plugin to be notified, got %v", client.received)
+	}
+}
+
+type itemListener struct {
+	items []gauge.Item
+}
+
+func (l *itemListener) Notify(m *gm.Message) error {
+	return fmt.Errorf("expected item listener to be notified with its item")
+}
+
+func (l *itemListener) NotifyItem(m *gm.Message, item gauge.Item) error {
+	l.items = append(l.items, item)
+	return nil
+}
+
+func TestItemListenersGetTheItemOfTheMessage(t *testing.T) {
+	defer resetListeners()
+	l := &itemListener{}
+	RegisterListener("console", 0, l)
+	spec := &gauge.Specification{FileName: "login.spec"}
+
+	(&GaugePlugins{listeners: registeredListeners()}).NotifyPlugins(specStarting, spec)
+
+	if len(l.items) != 1 || l.items[0] != spec {
+		t.Errorf("expected listener to get the spec, got %v", l.items)
+	}
+}
+
+func TestNotifyListenersDoesNotNotifyPlugins(t *testing.T) {
+	defer resetListeners()
+	var received []string
+	RegisterListener("rerun", 0, recordingListener("rerun", &received))
+	client := &recordingReporterClient{}
+	gp := &GaugePlugins{listeners: registeredListeners()}
+	gp.addPlugin("html-report", testPlugin(t, client))
+
+	gp.NotifyListeners(specStarting, &gauge.Specification{})
+
+	if !reflect.DeepEqual(received, []string{"rerun:SpecExecutionStarting"}) {
+		t.Errorf("expected rerun listener to be notified, got %v", received)
+	}
+	if len(client.received) != 0 {
+		t.Errorf("expected plugin not to be notified, got %v", client.received)
+	}
+}
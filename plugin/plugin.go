@@ -177,7 +177,7 @@ func getPluginJSONPath(pluginName, pluginVersion string) (string, error) {
 		return "", fmt.Errorf("Plugin %s is not installed", plugin)
 	}
 
-	pluginInstallDir, err := GetInstallDir(pluginName, "")
+	pluginInstallDir, err := GetInstallDir(pluginName, pluginVersion)
 	if err != nil {
 		return "", err
 	}
@@ -208,15 +208,7 @@ func GetPluginDescriptorFromJSON(pluginJSON string) (*PluginDescriptor, error) {
 }
 
 func startPlugin(pd *PluginDescriptor, action pluginScope) (*plugin, error) {
-	var command []string
-	switch runtime.GOOS {
-	case "windows":
-		command = pd.Command.Windows
-	case "darwin":
-		command = pd.Command.Darwin
-	default:
-		command = pd.Command.Linux
-	}
+	command := pd.platformCommand()
 	if len(command) == 0 {
 		return nil, fmt.Errorf("Platform specific command not specified: %s.", runtime.GOOS)
 	}
@@ -232,16 +224,16 @@ func startGRPCPlugin(pd *PluginDescriptor, command []string) (*plugin, error) {
 		Stderr: logger.NewCustomWriter(portChan, os.Stderr, pd.ID, true),
 		Stdout: logger.NewCustomWriter(portChan, os.Stdout, pd.ID, false),
 	}
-	cmd, err := common.ExecuteCommand(command, pd.pluginPath, writer.Stdout, writer.Stderr)
+	cmd, cleanup, err := executePlugin(pd, command, writer.Stdout, writer.Stderr)
+	if err != nil {
+		return nil, err
+	}
 	go func() {
-		err = cmd.Wait()
-		if err != nil {
+		defer cleanup()
+		if err := cmd.Wait(); err != nil {
 			logger.Errorf(true, "Error occurred while waiting for plugin process to finish.\nError : %s", err.Error())
 		}
 	}()
-	if err != nil {
-		return nil, err
-	}
 
 	var port string
 	select {
@@ -276,13 +268,14 @@ func startGRPCPlugin(pd *PluginDescriptor, command []string) (*plugin, error) {
 
 func startLegacyPlugin(pd *PluginDescriptor, command []string) (*plugin, error) {
 	writer := logger.NewLogWriter(pd.ID, true, 0)
-	cmd, err := common.ExecuteCommand(command, pd.pluginPath, writer.Stdout, writer.Stderr)
+	cmd, cleanup, err := executePlugin(pd, command, writer.Stdout, writer.Stderr)
 
 	if err != nil {
 		return nil, err
 	}
 	var mutex = &sync.Mutex{}
 	go func() {
+		defer cleanup()
 		pState, _ := cmd.Process.Wait()
 		mutex.Lock()
 		cmd.ProcessState = pState
@@ -318,11 +311,17 @@ func IsPluginAdded(m *manifest.Manifest, descriptor *PluginDescriptor) bool {
 
 func startPluginsForExecution(m *manifest.Manifest) (Handler, []string) {
 	var warnings []string
-	handler := &GaugePlugins{}
-	envProperties := make(map[string]string)
+	handler := &GaugePlugins{listeners: registeredListeners(), restart: func(pd *PluginDescriptor) (*plugin, error) {
+		return startExecutionPlugin(pd, m)
+	}}
 
 	for _, pluginID := range m.Plugins {
-		pd, err := GetPluginDescriptor(pluginID, "")
+		v, err := VersionToRun(m, pluginID)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Unable to start plugin %s. %s. To install, run `gauge install`.", pluginID, err.Error()))
+			continue
+		}
+		pd, err := GetPluginDescriptor(pluginID, v)
 		if err != nil {
 			warnings = append(warnings, fmt.Sprintf("Unable to start plugin %s. %s. To install, run `gauge install %s`.", pluginID, err.Error(), pluginID))
 			continue
@@ -333,44 +332,11 @@ func startPluginsForExecution(m *manifest.Manifest) (Handler, []string) {
 			continue
 		}
 		if pd.hasScope(executionScope) {
-			gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(0, nil)
+			plugin, err := startExecutionPlugin(pd, m)
 			if err != nil {
 				warnings = append(warnings, err.Error())
 				continue
 			}
-			envProperties[pluginConnectionPortEnv] = strconv.Itoa(gaugeConnectionHandler.ConnectionPortNumber())
-			prop, err := common.GetGaugeConfigurationFor(common.GaugePropertiesFile)
-			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("Unable to read Gauge configuration. %s", err.Error()))
-				continue
-			}
-			envProperties["plugin_kill_timeout"] = prop["plugin_kill_timeout"]
-			err = SetEnvForPlugin(executionScope, pd, m, envProperties)
-			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("Error setting environment for plugin %s %s. %s", pd.Name, pd.Version, err.Error()))
-				continue
-			}
-			logger.Debugf(true, "Starting %s plugin", pd.Name)
-			plugin, err := startPlugin(pd, executionScope)
-			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("Error starting plugin %s %s. %s", pd.Name, pd.Version, err.Error()))
-				continue
-			}
-			if plugin.gRPCConn != nil {
-				handler.addPlugin(pluginID, plugin)
-				continue
-			}
-			pluginConnection, err := gaugeConnectionHandler.AcceptConnection(config.PluginConnectionTimeout(), make(chan error))
-			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("Error starting plugin %s %s. Failed to connect to plugin. %s", pd.Name, pd.Version, err.Error()))
-				err := plugin.pluginCmd.Process.Kill()
-				if err != nil {
-					logger.Errorf(false, "unable to kill plugin %s: %s", plugin.descriptor.Name, err.Error())
-				}
-				continue
-			}
-			logger.Debugf(true, "Established connection to %s plugin", pd.Name)
-			plugin.connection = pluginConnection
 			handler.addPlugin(pluginID, plugin)
 		}
 
@@ -378,6 +344,85 @@ func startPluginsForExecution(m *manifest.Manifest) (Handler, []string) {
 	return handler, warnings
 }
 
+// startExecutionPlugin starts the plugin for an execution and connects to it.
+func startExecutionPlugin(pd *PluginDescriptor, m *manifest.Manifest) (*plugin, error) {
+	envProperties := make(map[string]string)
+	gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	envProperties[pluginConnectionPortEnv] = strconv.Itoa(gaugeConnectionHandler.ConnectionPortNumber())
+	prop, err := common.GetGaugeConfigurationFor(common.GaugePropertiesFile)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read Gauge configuration. %s", err.Error())
+	}
+	envProperties["plugin_kill_timeout"] = prop["plugin_kill_timeout"]
+	err = SetEnvForPlugin(executionScope, pd, m, envProperties)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting environment for plugin %s %s. %s", pd.Name, pd.Version, err.Error())
+	}
+	logger.Debugf(true, "Starting %s plugin", pd.Name)
+	plugin, err := startPlugin(pd, executionScope)
+	if err != nil {
+		return nil, fmt.Errorf("Error starting plugin %s %s. %s", pd.Name, pd.Version, err.Error())
+	}
+	if plugin.gRPCConn != nil {
+		return plugin, nil
+	}
+	pluginConnection, err := gaugeConnectionHandler.AcceptConnection(config.PluginConnectionTimeout(), make(chan error))
+	if err != nil {
+		err = fmt.Errorf("Error starting plugin %s %s. Failed to connect to plugin. %s", pd.Name, pd.Version, err.Error())
+		if killErr := plugin.pluginCmd.Process.Kill(); killErr != nil {
+			logger.Errorf(false, "unable to kill plugin %s: %s", plugin.descriptor.Name, killErr.Error())
+		}
+		return nil, err
+	}
+	logger.Debugf(true, "Established connection to %s plugin", pd.Name)
+	plugin.connection = pluginConnection
+	return plugin, nil
+}
+
+// VersionToRun returns the version of the plugin locked for the project, or else the latest installed version allowed by
+// the manifest. An empty version is the latest installed one.
+func VersionToRun(m *manifest.Manifest, pluginID string) (string, error) {
+	l, err := manifest.ProjectLock()
+	if err != nil {
+		return "", err
+	}
+	return VersionUsedBy(l, m, pluginID)
+}
+
+// VersionUsedBy returns the version of the plugin used by the project with the lock and manifest, like VersionToRun.
+func VersionUsedBy(l *manifest.Lock, m *manifest.Manifest, pluginID string) (string, error) {
+	if v, ok := l.Version(pluginID); ok {
+		if err := m.CheckLockedVersion(pluginID, v); err != nil {
+			return "", err
+		}
+		return v, nil
+	}
+	if m.Versions[pluginID] == "" {
+		return "", nil
+	}
+	c, err := m.VersionConstraint(pluginID)
+	if err != nil {
+		return "", err
+	}
+	pluginsDir, err := common.GetPluginsInstallDir(pluginID)
+	if err != nil {
+		return "", err
+	}
+	installed, err := pluginInfo.GetInstalledPlugins(filepath.Join(pluginsDir, pluginID))
+	if err != nil {
+		return "", err
+	}
+	for _, p := range installed {
+		if c.Allows(p.Version) {
+			return filepath.Base(p.Path), nil
+		}
+	}
+	return "", fmt.Errorf("no installed version of plugin %s matches %s", pluginID, c)
+}
+
 func GenerateDoc(pluginName string, specDirs []string, startAPIFunc func([]string) int) {
 	pd, err := GetPluginDescriptor(pluginName, "")
 	if err != nil {
@@ -450,6 +495,30 @@ func (p *plugin) invokeService(m *gauge_messages.Message) error {
 	return err
 }
 
+// notify sends the message to the plugin, failing if the plugin process has exited.
+func (p *plugin) notify(message *gauge_messages.Message) error {
+	if p.pluginCmd != nil && !isProcessRunning(p) {
+		return fmt.Errorf("plugin process has exited")
+	}
+	return p.sendMessage(message)
+}
+
+// stop closes the connection to the plugin and kills its process if it is still running.
+func (p *plugin) stop() {
+	if p.gRPCConn != nil {
+		p.gRPCConn.Close()
+	}
+	if p.connection != nil {
+		p.connection.Close()
+	}
+	if p.pluginCmd == nil || p.pluginCmd.Process == nil || !isProcessRunning(p) {
+		return
+	}
+	if err := p.pluginCmd.Process.Kill(); err != nil {
+		logger.Errorf(true, "Failed to kill plugin %s %s. %s\n", p.descriptor.Name, p.descriptor.Version, err.Error())
+	}
+}
+
 func (p *plugin) sendMessage(message *gauge_messages.Message) error {
 	if p.gRPCConn != nil {
 		return p.invokeService(message)
@@ -505,7 +574,21 @@ func GetInstallDir(pluginName, v string) (string, error) {
 }
 
 func GetLanguageJSONFilePath(language string) (string, error) {
-	languageInstallDir, err := GetInstallDir(language, "")
+	return languageJSONFilePath(language, "")
+}
+
+// LanguageJSONFilePathToRun returns the json file of the version of the language runner to run for the project, which
+// is the one locked for it, or else the latest installed one.
+func LanguageJSONFilePathToRun(m *manifest.Manifest) (string, error) {
+	v, err := VersionToRun(m, m.Language)
+	if err != nil {
+		return "", err
+	}
+	return languageJSONFilePath(m.Language, v)
+}
+
+func languageJSONFilePath(language, v string) (string, error) {
+	languageInstallDir, err := GetInstallDir(language, v)
 	if err != nil {
 		return "", err
 	}
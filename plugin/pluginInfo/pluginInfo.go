@@ -80,6 +80,40 @@ var GetAllInstalledPluginsWithVersion = func() ([]PluginInfo, error) {
 }
 
 func GetLatestInstalledPlugin(pluginDir string) (*PluginInfo, error) {
+	versionToPlugins, err := installedVersions(pluginDir)
+	if err != nil {
+		return nil, err
+	}
+	var availableVersions []*version.Version
+	for k := range versionToPlugins {
+		vp, _ := version.ParseVersion(k)
+		availableVersions = append(availableVersions, vp)
+	}
+	latestVersion := version.GetLatestVersion(availableVersions)
+	latestBuild := getLatestOf(versionToPlugins[latestVersion.String()], latestVersion)
+	return &latestBuild, nil
+}
+
+// GetInstalledPlugins returns the installed versions of the plugin in the directory, latest first.
+func GetInstalledPlugins(pluginDir string) ([]PluginInfo, error) {
+	versionToPlugins, err := installedVersions(pluginDir)
+	if err != nil {
+		return nil, err
+	}
+	var plugins []PluginInfo
+	for _, p := range versionToPlugins {
+		plugins = append(plugins, p...)
+	}
+	sort.SliceStable(plugins, func(i, j int) bool {
+		if plugins[i].Version.IsEqualTo(plugins[j].Version) {
+			return plugins[i].Path > plugins[j].Path
+		}
+		return plugins[i].Version.IsGreaterThan(plugins[j].Version)
+	})
+	return plugins, nil
+}
+
+func installedVersions(pluginDir string) (map[string][]PluginInfo, error) {
 	files, err := ioutil.ReadDir(pluginDir)
 	if err != nil {
 		return nil, fmt.Errorf("Error listing files in plugin directory %s: %s", pluginDir, err.Error())
@@ -104,15 +138,7 @@ func GetLatestInstalledPlugin(pluginDir string) (*PluginInfo, error) {
 	if len(versionToPlugins) < 1 {
 		return nil, fmt.Errorf("No valid versions of plugin %s found in %s", pluginName, pluginDir)
 	}
-
-	var availableVersions []*version.Version
-	for k := range versionToPlugins {
-		vp, _ := version.ParseVersion(k)
-		availableVersions = append(availableVersions, vp)
-	}
-	latestVersion := version.GetLatestVersion(availableVersions)
-	latestBuild := getLatestOf(versionToPlugins[latestVersion.String()], latestVersion)
-	return &latestBuild, nil
+	return versionToPlugins, nil
 }
 
 func getLatestOf(plugins []PluginInfo, latestVersion *version.Version) PluginInfo {
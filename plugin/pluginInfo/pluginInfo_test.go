@@ -8,6 +8,8 @@ package pluginInfo
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -164,3 +166,21 @@ func (s *MySuite) TestLatestVersionWithOnlyNightlyVersion(c *C) {
 	c.Assert(latestBuild.Version, Equals, v)
 	c.Assert(latestBuild.Version, Equals, v)
 }
+
+func (s *MySuite) TestGetInstalledPluginsReturnsLatestFirst(c *C) {
+	dir, err := ioutil.TempDir("", "java")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+	for _, v := range []string{"0.9.0", "1.10.0", "1.2.0.nightly-2020-01-01", "1.2.0", "1.2.0.nightly-2020-02-01", "1.9.0"} {
+		c.Assert(os.MkdirAll(filepath.Join(dir, v), 0750), IsNil)
+	}
+
+	plugins, err := GetInstalledPlugins(dir)
+
+	c.Assert(err, IsNil)
+	var dirs []string
+	for _, p := range plugins {
+		dirs = append(dirs, filepath.Base(p.Path))
+	}
+	c.Assert(dirs, DeepEquals, []string{"1.10.0", "1.9.0", "1.2.0.nightly-2020-02-01", "1.2.0.nightly-2020-01-01", "1.2.0", "0.9.0"})
+}
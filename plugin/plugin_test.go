@@ -9,6 +9,7 @@ package plugin
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/manifest"
 	"github.com/getgauge/gauge/plugin/pluginInfo"
 	"github.com/getgauge/gauge/version"
 	"google.golang.org/grpc"
@@ -203,3 +205,53 @@ func TestSendMessageShouldUseGRPCConnectionIfAvailable(t *testing.T) {
 		t.Errorf("Expected grpc client to be invoked")
 	}
 }
+
+func TestLockedOlderVersionsOfPluginAndRunnerAreTheOnesToRun(t *testing.T) {
+	home, err := ioutil.TempDir("", "gauge-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv(common.GaugeHome, os.Getenv(common.GaugeHome))
+	os.Setenv(common.GaugeHome, home)
+	defer func(root string) { config.ProjectRoot = root }(config.ProjectRoot)
+	config.ProjectRoot = home
+	plugins := filepath.Join(home, "plugins")
+	for _, v := range []string{"1.0.0", "2.0.0"} {
+		writeDoctorTestFile(t, filepath.Join(plugins, "html-report", v, common.PluginJSONFile), fmt.Sprintf(`{"id": "html-report", "version": "%s"}`, v), 0640)
+		writeDoctorTestFile(t, filepath.Join(plugins, "java", v, "java.json"), fmt.Sprintf(`{"id": "java", "version": "%s"}`, v), 0640)
+	}
+	writeDoctorTestFile(t, filepath.Join(home, manifest.LockFile), `{"plugins": {"html-report": {"version": "1.0.0"}, "java": {"version": "1.0.0"}}}`, 0640)
+	m := &manifest.Manifest{Language: "java", Plugins: []string{"html-report"}}
+
+	v, err := VersionToRun(m, "html-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd, err := GetPluginDescriptor("html-report", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pd.Version != "1.0.0" {
+		t.Errorf("Expected the locked version 1.0.0 of the plugin to start, got %s", pd.Version)
+	}
+	runnerJSON, err := LanguageJSONFilePathToRun(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(plugins, "java", "1.0.0", "java.json"); runnerJSON != want {
+		t.Errorf("Expected the locked version of the runner to start from %s, got %s", want, runnerJSON)
+	}
+}
+
+func TestLockedVersionWhichDoesNotMatchTheManifestIsNotRun(t *testing.T) {
+	l := &manifest.Lock{Plugins: map[string]manifest.LockedPlugin{"html-report": {Version: "1.0.0"}}}
+	m := &manifest.Manifest{Language: "java", Plugins: []string{"html-report"}, Versions: map[string]string{"html-report": "^2.0"}}
+
+	_, err := VersionUsedBy(l, m, "html-report")
+
+	want := "gauge.lock is out of date: plugin html-report is locked at 1.0.0, which does not match ^2.0 in manifest.json. Run `gauge install` to update it"
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected error %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,170 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/logger"
+)
+
+// Permissions are the env properties and paths of the project a plugin declares it needs. Env properties ending with
+// * match all the properties with the prefix, so a plugin which needs the full environment declares *. All plugins
+// only get the env properties of the platform and of Gauge along with the declared ones. A plugin which declares its
+// permissions is also sandboxed: it runs in a working directory of its own with a scratch temp dir, which is removed
+// once the plugin exits. The paths are shown to the user on install and are not enforced.
+type Permissions struct {
+	Env   []string
+	Paths []string
+}
+
+func (p *Permissions) String() string {
+	if p == nil {
+		return "platform and Gauge environment only (no permissions declared)"
+	}
+	list := func(values []string) string {
+		if len(values) == 0 {
+			return "none"
+		}
+		for _, v := range values {
+			if v == "*" {
+				return "* (full environment)"
+			}
+		}
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprintf("env: %s; paths: %s", list(p.Env), list(p.Paths))
+}
+
+// platformEnv are the env vars of the platform which all plugins get.
+var platformEnv = []string{
+	"PATH", "HOME", "USER", "USERNAME", "LOGNAME", "SHELL", "TERM", "LANG", "LC_*", "TZ",
+	"USERPROFILE", "SYSTEMROOT", "SYSTEMDRIVE", "WINDIR", "COMSPEC", "PATHEXT", "APPDATA", "LOCALAPPDATA",
+	"PROGRAMDATA", "PROGRAMFILES", "NUMBER_OF_PROCESSORS", "PROCESSOR_ARCHITECTURE",
+}
+
+// gaugeEnv are the env vars and properties of Gauge which all plugins get.
+var gaugeEnv = []string{
+	"GAUGE_*", "gauge_*", pluginConnectionPortEnv, "plugin_kill_timeout", "test_language",
+	env.SpecsDir, env.GaugeReportsDir, env.GaugeEnvironment, env.LogsDirectory, env.OverwriteReports,
+	env.ScreenshotOnFailure, env.CsvDelimiter, env.GaugeScreenshotsDir,
+}
+
+var tempDirEnv = []string{"TMPDIR", "TMP", "TEMP"}
+
+// executePlugin starts the command of the plugin with the env it is allowed to get, in a sandbox if the plugin declares
+// its permissions. The returned func cleans up after the plugin and is called once its process exits.
+func executePlugin(pd *PluginDescriptor, command []string, stdout, stderr io.Writer) (*exec.Cmd, func(), error) {
+	if pd.Permissions == nil {
+		cmd, err := common.ExecuteCommandWithEnv(command, pd.pluginPath, stdout, stderr, pd.sandboxedEnv(os.Environ(), ""))
+		return cmd, func() {}, err
+	}
+	workingDir, tempDir, err := pd.sandboxDirs()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the working directory of plugin %s. %s", pd.ID, err.Error())
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			logger.Warningf(true, "Failed to remove the temp dir %s of plugin %s. %s", tempDir, pd.ID, err.Error())
+		}
+	}
+	cmd, err := common.ExecuteCommandWithEnv(pd.sandboxedCommand(command), workingDir, stdout, stderr, pd.sandboxedEnv(os.Environ(), tempDir))
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return cmd, cleanup, nil
+}
+
+// sandboxDirs creates the working directory of the plugin in the project and a new scratch temp dir in it, so that
+// plugins started by different Gauge processes in the project do not share one. Outside a project, like when checking
+// the plugins, the working directory is in the temp dir.
+func (pd *PluginDescriptor) sandboxDirs() (string, string, error) {
+	root := config.ProjectRoot
+	if root == "" {
+		root = os.TempDir()
+	}
+	workingDir := filepath.Join(root, common.DotGauge, "plugins", pd.ID)
+	if err := os.MkdirAll(workingDir, common.NewDirectoryPermissions); err != nil {
+		return "", "", err
+	}
+	tempDir, err := ioutil.TempDir(workingDir, "tmp")
+	if err != nil {
+		return "", "", err
+	}
+	return workingDir, tempDir, nil
+}
+
+// sandboxedCommand makes the parts of the command which are files of the plugin absolute, as the plugin does not run
+// in its install directory.
+func (pd *PluginDescriptor) sandboxedCommand(command []string) []string {
+	var resolved []string
+	for _, part := range command {
+		if !filepath.IsAbs(part) && common.FileExists(filepath.Join(pd.pluginPath, part)) {
+			part = filepath.Join(pd.pluginPath, part)
+		}
+		resolved = append(resolved, part)
+	}
+	return resolved
+}
+
+// sandboxedEnv returns the env vars the plugin is allowed to get, with the temp dir set to the scratch dir. Without a
+// scratch dir, the plugin gets the temp dir of the platform.
+func (pd *PluginDescriptor) sandboxedEnv(environ []string, tempDir string) []string {
+	allowed := append(append(append([]string{}, platformEnv...), gaugeEnv...), fmt.Sprintf("%s_action", pd.ID))
+	if pd.Permissions != nil {
+		allowed = append(allowed, pd.Permissions.Env...)
+	}
+	if tempDir == "" {
+		allowed = append(allowed, tempDirEnv...)
+	}
+	var filtered []string
+	for _, e := range environ {
+		name := strings.SplitN(e, "=", 2)[0]
+		if matchesAnyEnv(allowed, name) && (tempDir == "" || !matchesAnyEnv(tempDirEnv, name)) {
+			filtered = append(filtered, e)
+		}
+	}
+	if tempDir == "" {
+		return filtered
+	}
+	for _, name := range tempDirEnv {
+		filtered = append(filtered, fmt.Sprintf("%s=%s", name, tempDir))
+	}
+	return filtered
+}
+
+func matchesAnyEnv(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if matchesEnv(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEnv checks if the env var matches the name, or the prefix of a name ending with *. Env vars are case
+// insensitive on Windows.
+func matchesEnv(pattern, name string) bool {
+	if runtime.GOOS == "windows" {
+		pattern, name = strings.ToUpper(pattern), strings.ToUpper(name)
+	}
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(name, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == name
+}
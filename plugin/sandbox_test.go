@@ -0,0 +1,211 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+func TestSandboxedEnvKeepsOnlyAllowedEnv(t *testing.T) {
+	pd := &PluginDescriptor{ID: "slack-report", Permissions: &Permissions{Env: []string{"slack_channel", "SLACK_*"}}}
+	environ := []string{
+		"PATH=/usr/bin",
+		"GAUGE_PROJECT_ROOT=/work/shop",
+		"gauge_reports_dir=reports",
+		"slack-report_action=execution",
+		"html-report_action=execution",
+		"slack_channel=#qa",
+		"SLACK_TOKEN=xoxb",
+		"db_password=secret",
+		"TMPDIR=/tmp",
+	}
+
+	got := pd.sandboxedEnv(environ, "/work/shop/.gauge/plugins/slack-report/tmp")
+
+	want := []string{
+		"PATH=/usr/bin",
+		"GAUGE_PROJECT_ROOT=/work/shop",
+		"gauge_reports_dir=reports",
+		"slack-report_action=execution",
+		"slack_channel=#qa",
+		"SLACK_TOKEN=xoxb",
+		"TMPDIR=/work/shop/.gauge/plugins/slack-report/tmp",
+		"TMP=/work/shop/.gauge/plugins/slack-report/tmp",
+		"TEMP=/work/shop/.gauge/plugins/slack-report/tmp",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Failed sandboxedEnv.\n\tWant: %v\n\tGot: %v", want, got)
+	}
+}
+
+func TestSandboxedCommandMakesPluginFilesAbsolute(t *testing.T) {
+	path, _ := filepath.Abs(filepath.Join("_testdata", "plugins", "lint", "1.0.0"))
+	pd := &PluginDescriptor{ID: "lint", pluginPath: path}
+
+	got := pd.sandboxedCommand([]string{"./lint.sh", "--strict", "plugin.json"})
+
+	want := []string{filepath.Join(path, "lint.sh"), "--strict", filepath.Join(path, "plugin.json")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Failed sandboxedCommand.\n\tWant: %v\n\tGot: %v", want, got)
+	}
+}
+
+func TestPermissionsString(t *testing.T) {
+	var undeclared *Permissions
+	if got := undeclared.String(); got != "platform and Gauge environment only (no permissions declared)" {
+		t.Errorf("unexpected permissions for a plugin which declares none: %s", got)
+	}
+	p := &Permissions{Env: []string{"SLACK_*"}}
+	if got := p.String(); got != "env: SLACK_*; paths: none" {
+		t.Errorf("unexpected permissions: %s", got)
+	}
+	full := &Permissions{Env: []string{"*"}, Paths: []string{"reports"}}
+	if got := full.String(); got != "env: * (full environment); paths: reports" {
+		t.Errorf("unexpected permissions for a plugin which needs the full environment: %s", got)
+	}
+}
+
+func TestSandboxedEnvOfPluginWithoutPermissionsKeepsPlatformAndGaugeEnv(t *testing.T) {
+	pd := &PluginDescriptor{ID: "html-report"}
+	environ := []string{
+		"PATH=/usr/bin",
+		"GAUGE_PROJECT_ROOT=/work/shop",
+		"gauge_reports_dir=reports",
+		"html-report_action=execution",
+		"db_password=secret",
+		"TMPDIR=/tmp",
+	}
+
+	got := pd.sandboxedEnv(environ, "")
+
+	want := []string{
+		"PATH=/usr/bin",
+		"GAUGE_PROJECT_ROOT=/work/shop",
+		"gauge_reports_dir=reports",
+		"html-report_action=execution",
+		"TMPDIR=/tmp",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Failed sandboxedEnv.\n\tWant: %v\n\tGot: %v", want, got)
+	}
+}
+
+func TestSandboxedEnvOfPluginWhichNeedsTheFullEnv(t *testing.T) {
+	pd := &PluginDescriptor{ID: "env-report", Permissions: &Permissions{Env: []string{"*"}}}
+	environ := []string{"PATH=/usr/bin", "db_password=secret", "TMPDIR=/tmp"}
+
+	got := pd.sandboxedEnv(environ, "/work/tmp")
+
+	want := []string{"PATH=/usr/bin", "db_password=secret", "TMPDIR=/work/tmp", "TMP=/work/tmp", "TEMP=/work/tmp"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Failed sandboxedEnv.\n\tWant: %v\n\tGot: %v", want, got)
+	}
+}
+
+func TestExecutePluginRunsSandboxedPluginInItsWorkingDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a shell script")
+	}
+	project, err := ioutil.TempDir("", "gauge-sandbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(project)
+	config.ProjectRoot = project
+	defer func() { config.ProjectRoot = "" }()
+	pluginPath := filepath.Join(project, "plugin")
+	if err = os.MkdirAll(pluginPath, 0750); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\npwd > env.txt\nenv >> env.txt\n"
+	if err = ioutil.WriteFile(filepath.Join(pluginPath, "env.sh"), []byte(script), 0750); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("db_password", "secret")
+	defer os.Unsetenv("db_password")
+	pd := &PluginDescriptor{ID: "env", pluginPath: pluginPath, Permissions: &Permissions{}}
+
+	cmd, cleanup, err := executePlugin(pd, []string{"./env.sh"}, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cmd.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	workingDir := filepath.Join(project, ".gauge", "plugins", "env")
+	out, err := ioutil.ReadFile(filepath.Join(workingDir, "env.txt"))
+	if err != nil {
+		t.Fatalf("expected plugin to write to its working dir. %s", err.Error())
+	}
+	lines := strings.Split(string(out), "\n")
+	if resolved, _ := filepath.EvalSymlinks(workingDir); lines[0] != workingDir && lines[0] != resolved {
+		t.Errorf("expected plugin to run in %s, got %s", workingDir, lines[0])
+	}
+	if strings.Contains(string(out), "db_password") {
+		t.Errorf("expected env not declared by the plugin to be filtered out")
+	}
+	tempDir := ""
+	for _, line := range lines {
+		if strings.HasPrefix(line, "TMPDIR=") {
+			tempDir = strings.TrimPrefix(line, "TMPDIR=")
+		}
+	}
+	if filepath.Dir(tempDir) != workingDir || !strings.HasPrefix(filepath.Base(tempDir), "tmp") || !common.DirExists(tempDir) {
+		t.Errorf("expected plugin to get a scratch temp dir in %s, got %s", workingDir, tempDir)
+	}
+	cleanup()
+	if common.DirExists(tempDir) {
+		t.Errorf("expected the scratch temp dir %s to be removed once the plugin exits", tempDir)
+	}
+}
+
+func TestExecutePluginFiltersTheEnvOfPluginWithoutPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a shell script")
+	}
+	pluginPath, err := ioutil.TempDir("", "gauge-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(pluginPath)
+	if err = ioutil.WriteFile(filepath.Join(pluginPath, "env.sh"), []byte("#!/bin/sh\nenv > env.txt\n"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("db_password", "secret")
+	defer os.Unsetenv("db_password")
+	pd := &PluginDescriptor{ID: "env", pluginPath: pluginPath}
+
+	cmd, cleanup, err := executePlugin(pd, []string{"./env.sh"}, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	if err = cmd.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := ioutil.ReadFile(filepath.Join(pluginPath, "env.txt"))
+	if err != nil {
+		t.Fatalf("expected plugin to run in its install dir. %s", err.Error())
+	}
+	if strings.Contains(string(out), "db_password") {
+		t.Errorf("expected env not declared by the plugin to be filtered out")
+	}
+	if !strings.Contains(string(out), "PATH=") {
+		t.Errorf("expected plugin to get the env of the platform")
+	}
+}
@@ -11,14 +11,11 @@ import (
 	"fmt"
 	"io"
 	"strconv"
-	"strings"
 	"sync"
 
 	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
-	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/formatter"
 	"github.com/getgauge/gauge/gauge"
-	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/util"
 )
 
@@ -37,11 +34,13 @@ const (
 	skip          status    = "skip"
 )
 
+// jsonConsole writes the events of an execution as lines of JSON, for machine readable executions. It is a
+// plugin.ItemListener, as it needs the line numbers and data table rows of the specs and scenarios.
 type jsonConsole struct {
 	*sync.Mutex
 	writer     io.Writer
 	isParallel bool
-	stream     int
+	started    bool
 	stepCache  map[*gm.ScenarioInfo][]*stepInfo
 }
 
@@ -51,14 +50,15 @@ type stepInfo struct {
 }
 
 type executionEvent struct {
-	EventType eventType        `json:"type"`
-	ID        string           `json:"id,omitempty"`
-	ParentID  string           `json:"parentId,omitempty"`
-	Name      string           `json:"name,omitempty"`
-	Filename  string           `json:"filename,omitempty"`
-	Line      int              `json:"line,omitempty"`
-	Stream    int              `json:"stream,omitempty"`
-	Res       *executionResult `json:"result,omitempty"`
+	EventType eventType         `json:"type"`
+	ID        string            `json:"id,omitempty"`
+	ParentID  string            `json:"parentId,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Filename  string            `json:"filename,omitempty"`
+	Line      int               `json:"line,omitempty"`
+	Stream    int               `json:"stream,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Res       *executionResult  `json:"result,omitempty"`
 }
 
 type executionResult struct {
@@ -84,34 +84,74 @@ type executionError struct {
 	StackTrace string `json:"stackTrace"`
 }
 
-func newJSONConsole(out io.Writer, isParallel bool, stream int) *jsonConsole {
-	return &jsonConsole{Mutex: &sync.Mutex{}, writer: out, isParallel: isParallel, stream: stream, stepCache: make(map[*gm.ScenarioInfo][]*stepInfo)}
+func newJSONConsole(out io.Writer, isParallel bool) *jsonConsole {
+	return &jsonConsole{Mutex: &sync.Mutex{}, writer: out, isParallel: isParallel, stepCache: make(map[*gm.ScenarioInfo][]*stepInfo)}
 }
 
-func (c *jsonConsole) SuiteStart() {
-	c.Lock()
-	defer c.Unlock()
-	c.write(executionEvent{EventType: suiteStart, Stream: c.stream})
+// Notify writes the event of a message without its item, which are those about the suite.
+func (c *jsonConsole) Notify(m *gm.Message) error {
+	return c.NotifyItem(m, nil)
 }
 
-func (c *jsonConsole) SuiteEnd(res result.Result) {
+// NotifyItem writes the event of a message. The start of the suite is written before the first message, as the suite
+// can fail before the plugins are told it started. The start of specs and scenarios is written for the message sent
+// before their hooks run.
+func (c *jsonConsole) NotifyItem(m *gm.Message, item gauge.Item) error {
 	c.Lock()
 	defer c.Unlock()
-	sRes := res.(*result.SuiteResult)
+	if m.GetMessageType() == gm.Message_KillProcessRequest {
+		return nil
+	}
+	if !c.started {
+		c.started = true
+		c.write(executionEvent{EventType: suiteStart})
+	}
+	switch m.GetMessageType() {
+	case gm.Message_SpecExecutionStarting:
+		r := m.GetSpecExecutionStartingRequest()
+		if spec, ok := item.(*gauge.Specification); ok && r.GetSpecResult() == nil {
+			c.specStart(spec, int(r.GetStream()))
+		}
+	case gm.Message_SpecExecutionEnding:
+		r := m.GetSpecExecutionEndingRequest()
+		if spec, ok := item.(*gauge.Specification); ok {
+			c.specEnd(spec, r.GetSpecResult(), int(r.GetStream()))
+		}
+	case gm.Message_ScenarioExecutionStarting:
+		r := m.GetScenarioExecutionStartingRequest()
+		if scenario, ok := item.(*gauge.Scenario); ok && r.GetScenarioResult() == nil {
+			c.scenarioStart(scenario, r.GetCurrentExecutionInfo(), int(r.GetStream()))
+		}
+	case gm.Message_ScenarioExecutionEnding:
+		r := m.GetScenarioExecutionEndingRequest()
+		if scenario, ok := item.(*gauge.Scenario); ok {
+			c.scenarioEnd(scenario, r.GetScenarioResult().GetProtoItem().GetScenario(), r.GetCurrentExecutionInfo(), int(r.GetStream()))
+		}
+	case gm.Message_StepExecutionEnding:
+		r := m.GetStepExecutionEndingRequest()
+		if step, ok := item.(*gauge.Step); ok {
+			scenario := r.GetCurrentExecutionInfo().GetCurrentScenario()
+			c.stepCache[scenario] = append(c.stepCache[scenario], &stepInfo{step: step, protoStep: r.GetStepResult().GetProtoItem().GetStep()})
+		}
+	case gm.Message_SuiteExecutionResult:
+		c.suiteEnd(m.GetSuiteExecutionResult().GetSuiteResult())
+		c.started = false
+	}
+	return nil
+}
+
+func (c *jsonConsole) suiteEnd(res *gm.ProtoSuiteResult) {
 	c.write(executionEvent{
 		EventType: suiteEnd,
-		Stream:    c.stream,
 		Res: &executionResult{
-			Status:            getStatus(sRes.IsFailed, false),
-			BeforeHookFailure: getHookFailure(res.GetPreHook(), "Before Suite"),
-			AfterHookFailure:  getHookFailure(res.GetPostHook(), "After Suite"),
+			Status:            getStatus(res.GetFailed(), false),
+			BeforeHookFailure: getHookFailure(hookFailures(res.GetPreHookFailure()), "Before Suite"),
+			AfterHookFailure:  getHookFailure(hookFailures(res.GetPostHookFailure()), "After Suite"),
 		},
 	})
 }
 
-func (c *jsonConsole) SpecStart(spec *gauge.Specification, res result.Result) {
-	c.Lock()
-	defer c.Unlock()
+func (c *jsonConsole) specStart(spec *gauge.Specification, stream int) {
 	addRow := c.isParallel && spec.DataTable.IsInitialized()
 	c.write(executionEvent{
 		EventType: specStart,
@@ -119,116 +159,72 @@ func (c *jsonConsole) SpecStart(spec *gauge.Specification, res result.Result) {
 		Name:      spec.Heading.Value,
 		Filename:  spec.FileName,
 		Line:      spec.Heading.LineNo,
-		Stream:    c.stream,
+		Stream:    stream,
+		Metadata:  spec.Metadata,
 	})
 }
 
-func (c *jsonConsole) SpecEnd(spec *gauge.Specification, res result.Result) {
-	c.Lock()
-	defer c.Unlock()
-	protoSpec := res.Item().(*gm.ProtoSpec)
-	sRes := res.(*result.SpecResult)
+func (c *jsonConsole) specEnd(spec *gauge.Specification, res *gm.ProtoSpecResult, stream int) {
+	protoSpec := res.GetProtoSpec()
 	addRow := c.isParallel && spec.DataTable.IsInitialized()
-	e := executionEvent{
+	c.write(executionEvent{
 		EventType: specEnd,
 		ID:        getIDWithRow(spec.FileName, spec.Scenarios, addRow),
 		Name:      protoSpec.GetSpecHeading(),
 		Filename:  spec.FileName,
 		Line:      spec.Heading.LineNo,
-		Stream:    c.stream,
+		Stream:    stream,
+		Metadata:  spec.Metadata,
 		Res: &executionResult{
-			Status:            getStatus(sRes.GetFailed(), sRes.Skipped),
-			BeforeHookFailure: getHookFailure(res.GetPreHook(), "Before Specification"),
-			AfterHookFailure:  getHookFailure(res.GetPostHook(), "After Specification"),
+			Status:            getStatus(res.GetFailed(), res.GetSkipped()),
+			BeforeHookFailure: getHookFailure(protoSpec.GetPreHookFailures(), "Before Specification"),
+			AfterHookFailure:  getHookFailure(protoSpec.GetPostHookFailures(), "After Specification"),
 		},
-	}
-	c.write(e)
+	})
 }
 
-func (c *jsonConsole) ScenarioStart(scenario *gauge.Scenario, i *gm.ExecutionInfo, res result.Result) {
-	c.Lock()
-	defer c.Unlock()
+func (c *jsonConsole) scenarioStart(scenario *gauge.Scenario, i *gm.ExecutionInfo, stream int) {
 	addRow := c.isParallel && scenario.SpecDataTableRow.IsInitialized()
-	parentID := getIDWithRow(i.CurrentSpec.FileName, []*gauge.Scenario{scenario}, addRow)
-	e := executionEvent{
+	parentID := getIDWithRow(i.GetCurrentSpec().GetFileName(), []*gauge.Scenario{scenario}, addRow)
+	c.write(executionEvent{
 		EventType: scenarioStart,
 		ID:        parentID + ":" + strconv.Itoa(scenario.Span.Start),
 		ParentID:  parentID,
-		Filename:  i.CurrentSpec.FileName,
+		Filename:  i.GetCurrentSpec().GetFileName(),
 		Line:      scenario.Heading.LineNo,
 		Name:      scenario.Heading.Value,
-		Stream:    c.stream,
+		Stream:    stream,
 		Res:       &executionResult{Table: getTable(scenario)},
-	}
-	c.write(e)
+	})
 }
 
-func (c *jsonConsole) ScenarioEnd(scenario *gauge.Scenario, res result.Result, i *gm.ExecutionInfo) {
-	c.Lock()
-	defer c.Unlock()
+func (c *jsonConsole) scenarioEnd(scenario *gauge.Scenario, protoScenario *gm.ProtoScenario, i *gm.ExecutionInfo, stream int) {
 	addRow := c.isParallel && scenario.SpecDataTableRow.IsInitialized()
-	parentID := getIDWithRow(i.CurrentSpec.FileName, []*gauge.Scenario{scenario}, addRow)
-	e := executionEvent{
+	parentID := getIDWithRow(i.GetCurrentSpec().GetFileName(), []*gauge.Scenario{scenario}, addRow)
+	c.write(executionEvent{
 		EventType: scenarioEnd,
 		ID:        parentID + ":" + strconv.Itoa(scenario.Span.Start),
 		ParentID:  parentID,
-		Filename:  i.CurrentSpec.FileName,
+		Filename:  i.GetCurrentSpec().GetFileName(),
 		Line:      scenario.Heading.LineNo,
 		Name:      scenario.Heading.Value,
-		Stream:    c.stream,
+		Stream:    stream,
 		Res: &executionResult{
-			Status:            getScenarioStatus(res.(*result.ScenarioResult)),
-			Time:              res.ExecTime(),
-			Errors:            getErrors(c.stepCache, getAllStepsFromScenario(res.(*result.ScenarioResult).ProtoScenario), i.CurrentSpec.FileName, i),
-			BeforeHookFailure: getHookFailure(res.GetPreHook(), "Before Scenario"),
-			AfterHookFailure:  getHookFailure(res.GetPostHook(), "After Scenario"),
+			Status:            getScenarioStatus(protoScenario),
+			Time:              protoScenario.GetExecutionTime(),
+			Errors:            getErrors(c.stepCache, getAllStepsFromScenario(protoScenario), i.GetCurrentSpec().GetFileName(), i),
+			BeforeHookFailure: getHookFailure(hookFailures(protoScenario.GetPreHookFailure()), "Before Scenario"),
+			AfterHookFailure:  getHookFailure(hookFailures(protoScenario.GetPostHookFailure()), "After Scenario"),
 			Table:             getTable(scenario),
 		},
-	}
-	c.write(e)
+	})
+	delete(c.stepCache, i.GetCurrentScenario())
 }
 
 func getAllStepsFromScenario(scenario *gm.ProtoScenario) []*gm.ProtoItem {
 	return append(scenario.GetContexts(), append(scenario.GetScenarioItems(), scenario.GetTearDownSteps()...)...)
 }
 
-func (c *jsonConsole) StepStart(stepText string) {
-}
-
-func (c *jsonConsole) StepEnd(step gauge.Step, res result.Result, execInfo *gm.ExecutionInfo) {
-	si := &stepInfo{step: &step, protoStep: res.(*result.StepResult).Item().(*gm.ProtoStep)}
-	c.stepCache[execInfo.CurrentScenario] = append(c.stepCache[execInfo.CurrentScenario], si)
-}
-
-func (c *jsonConsole) ConceptStart(conceptHeading string) {
-}
-
-func (c *jsonConsole) ConceptEnd(res result.Result) {
-}
-
-func (c *jsonConsole) DataTable(table string) {
-}
-
-func (c *jsonConsole) Errorf(err string, args ...interface{}) {
-	c.Lock()
-	defer c.Unlock()
-}
-
-func (c *jsonConsole) Write(b []byte) (int, error) {
-	c.Lock()
-	defer c.Unlock()
-	s := strings.Split(string(b), "\n")
-	for _, m := range s {
-		outMessage := &logger.OutMessage{MessageType: "out", Message: strings.Trim(m, "\n ")}
-		t, err := outMessage.ToJSON()
-		if err != nil {
-			return 0, err
-		}
-		fmt.Fprintf(c.writer, "%s\n", string(t))
-	}
-	return len(b), nil
-}
-
 func (c *jsonConsole) write(e executionEvent) {
 	b, _ := json.Marshal(e)
 	fmt.Fprint(c.writer, string(b)+newline)
@@ -241,9 +237,9 @@ func getIDWithRow(name string, scenarios []*gauge.Scenario, isDataTable bool) st
 	return name + ":" + strconv.Itoa(scenarios[0].SpecDataTableRowIndex)
 }
 
-func getScenarioStatus(result *result.ScenarioResult) status {
-	return getStatus(result.ProtoScenario.GetExecutionStatus() == gm.ExecutionStatus_FAILED,
-		result.ProtoScenario.GetExecutionStatus() == gm.ExecutionStatus_SKIPPED)
+func getScenarioStatus(scenario *gm.ProtoScenario) status {
+	return getStatus(scenario.GetExecutionStatus() == gm.ExecutionStatus_FAILED,
+		scenario.GetExecutionStatus() == gm.ExecutionStatus_SKIPPED)
 }
 
 func getStatus(failed, skipped bool) status {
@@ -320,6 +316,13 @@ func getTable(scenario *gauge.Scenario) *tableInfo {
 	return nil
 }
 
+func hookFailures(f *gm.ProtoHookFailure) []*gm.ProtoHookFailure {
+	if f == nil {
+		return nil
+	}
+	return []*gm.ProtoHookFailure{f}
+}
+
 func getHookFailure(hookFailure []*gm.ProtoHookFailure, text string) *executionError {
 	if len(hookFailure) > 0 {
 		return &executionError{
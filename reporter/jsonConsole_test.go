@@ -8,52 +8,39 @@ package reporter
 
 import (
 	"github.com/getgauge/gauge-proto/go/gauge_messages"
-	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/gauge"
 	. "gopkg.in/check.v1"
 )
 
 func setupJSONConsole() (*dummyWriter, *jsonConsole) {
 	dw := newDummyWriter()
-	console := newJSONConsole(dw, false, 0)
+	console := newJSONConsole(dw, false)
+	console.started = true
 	return dw, console
 }
 
-func (s *MySuite) TestSpecStart_JSONConsole(c *C) {
-	dw, jc := setupJSONConsole()
-	protoSpec := &gauge_messages.ProtoSpec{
-		FileName:    "file",
-		SpecHeading: "Specification",
-	}
-	scenarios := []*gauge.Scenario{
-		{
-			Heading: &gauge.Heading{
-				Value:       "Scenario",
-				LineNo:      2,
-				HeadingType: 1,
-			},
-		},
-	}
-	spec := &gauge.Specification{
+func jsonConsoleSpec() *gauge.Specification {
+	return &gauge.Specification{
 		FileName: "file",
 		Heading: &gauge.Heading{
 			Value:       "Specification",
 			LineNo:      1,
 			HeadingType: 0,
 		},
-		Scenarios: scenarios,
+		Scenarios: []*gauge.Scenario{
+			{
+				Heading: &gauge.Heading{
+					Value:       "Scenario",
+					LineNo:      2,
+					HeadingType: 1,
+				},
+			},
+		},
 	}
-
-	expected := `{"type":"specStart","id":"file","name":"Specification","filename":"file","line":1}
-`
-	jc.SpecStart(spec, &result.SpecResult{Skipped: false, ProtoSpec: protoSpec})
-	c.Assert(dw.output, Equals, expected)
 }
 
-func (s *MySuite) TestScenarioStart_JSONConsole(c *C) {
-	dw, jc := setupJSONConsole()
-
-	scenario := &gauge.Scenario{
+func jsonConsoleScenario() *gauge.Scenario {
+	return &gauge.Scenario{
 		Heading: &gauge.Heading{
 			Value:       "Scenario",
 			LineNo:      2,
@@ -64,22 +51,139 @@ func (s *MySuite) TestScenarioStart_JSONConsole(c *C) {
 			End:   3,
 		},
 	}
+}
 
-	info := &gauge_messages.ExecutionInfo{
+func jsonConsoleExecutionInfo(failed bool) *gauge_messages.ExecutionInfo {
+	return &gauge_messages.ExecutionInfo{
 		CurrentSpec: &gauge_messages.SpecInfo{
 			Name:     "Specification",
 			FileName: "file",
-			IsFailed: false,
+			IsFailed: failed,
 		},
 		CurrentScenario: &gauge_messages.ScenarioInfo{
 			Name:     "Scenario",
-			IsFailed: false,
+			IsFailed: failed,
+		},
+	}
+}
+
+func specStartingMessage() *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionStarting,
+		SpecExecutionStartingRequest: &gauge_messages.SpecExecutionStartingRequest{}}
+}
+
+func specEndingMessage(res *gauge_messages.ProtoSpecResult) *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_SpecExecutionEnding,
+		SpecExecutionEndingRequest: &gauge_messages.SpecExecutionEndingRequest{SpecResult: res}}
+}
+
+func scenarioEndingMessage(info *gauge_messages.ExecutionInfo, scenario *gauge_messages.ProtoScenario) *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionEnding,
+		ScenarioExecutionEndingRequest: &gauge_messages.ScenarioExecutionEndingRequest{
+			CurrentExecutionInfo: info,
+			ScenarioResult: &gauge_messages.ProtoScenarioResult{
+				ProtoItem: &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Scenario, Scenario: scenario},
+			},
+		}}
+}
+
+func stepEndingMessage(info *gauge_messages.ExecutionInfo, step *gauge_messages.ProtoStep) *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_StepExecutionEnding,
+		StepExecutionEndingRequest: &gauge_messages.StepExecutionEndingRequest{
+			CurrentExecutionInfo: info,
+			StepResult: &gauge_messages.ProtoStepResult{
+				ProtoItem: &gauge_messages.ProtoItem{ItemType: gauge_messages.ProtoItem_Step, Step: step},
+			},
+		}}
+}
+
+func suiteResultMessage(res *gauge_messages.ProtoSuiteResult) *gauge_messages.Message {
+	return &gauge_messages.Message{MessageType: gauge_messages.Message_SuiteExecutionResult,
+		SuiteExecutionResult: &gauge_messages.SuiteExecutionResult{SuiteResult: res}}
+}
+
+func (s *MySuite) TestSuiteStartIsWrittenBeforeTheFirstMessage_JSONConsole(c *C) {
+	dw := newDummyWriter()
+	jc := newJSONConsole(dw, false)
+
+	jc.NotifyItem(&gauge_messages.Message{MessageType: gauge_messages.Message_ExecutionStarting,
+		ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{}}, nil)
+	jc.NotifyItem(&gauge_messages.Message{MessageType: gauge_messages.Message_ExecutionStarting,
+		ExecutionStartingRequest: &gauge_messages.ExecutionStartingRequest{SuiteResult: &gauge_messages.ProtoSuiteResult{}}}, nil)
+
+	c.Assert(dw.output, Equals, "{\"type\":\"suiteStart\"}\n")
+}
+
+func (s *MySuite) TestSuiteStartIsWrittenIfTheSuiteEndsBeforeItStarts_JSONConsole(c *C) {
+	dw := newDummyWriter()
+	jc := newJSONConsole(dw, false)
+
+	jc.Notify(suiteResultMessage(&gauge_messages.ProtoSuiteResult{}))
+	jc.Notify(&gauge_messages.Message{MessageType: gauge_messages.Message_KillProcessRequest, KillProcessRequest: &gauge_messages.KillProcessRequest{}})
+
+	c.Assert(dw.output, Equals, "{\"type\":\"suiteStart\"}\n{\"type\":\"suiteEnd\",\"result\":{\"status\":\"pass\",\"time\":0}}\n")
+}
+
+func (s *MySuite) TestSpecStart_JSONConsole(c *C) {
+	dw, jc := setupJSONConsole()
+
+	expected := `{"type":"specStart","id":"file","name":"Specification","filename":"file","line":1}
+`
+	jc.NotifyItem(specStartingMessage(), jsonConsoleSpec())
+	c.Assert(dw.output, Equals, expected)
+}
+
+func (s *MySuite) TestSpecStartIsWrittenOnlyBeforeTheHook_JSONConsole(c *C) {
+	dw, jc := setupJSONConsole()
+	m := specStartingMessage()
+	m.SpecExecutionStartingRequest.SpecResult = &gauge_messages.ProtoSpecResult{}
+
+	jc.NotifyItem(m, jsonConsoleSpec())
+	c.Assert(dw.output, Equals, "")
+}
+
+func (s *MySuite) TestSpecStartWithMetadata_JSONConsole(c *C) {
+	dw, jc := setupJSONConsole()
+	spec := &gauge.Specification{
+		FileName: "file",
+		Heading: &gauge.Heading{
+			Value:       "Specification",
+			LineNo:      4,
+			HeadingType: 0,
 		},
+		Metadata: map[string]string{"owner": "payments"},
 	}
 
+	expected := `{"type":"specStart","id":"file","name":"Specification","filename":"file","line":4,"metadata":{"owner":"payments"}}
+`
+	jc.NotifyItem(specStartingMessage(), spec)
+	c.Assert(dw.output, Equals, expected)
+}
+
+func (s *MySuite) TestSpecStartInParallelStream_JSONConsole(c *C) {
+	dw := newDummyWriter()
+	jc := newJSONConsole(dw, true)
+	jc.started = true
+	spec := jsonConsoleSpec()
+	spec.DataTable = gauge.DataTable{Table: gauge.NewTable([]string{"id"}, [][]gauge.TableCell{{{Value: "1", CellType: gauge.Static}}}, 1)}
+	spec.Scenarios[0].SpecDataTableRowIndex = 3
+	m := specStartingMessage()
+	m.SpecExecutionStartingRequest.Stream = 2
+
+	expected := `{"type":"specStart","id":"file:3","name":"Specification","filename":"file","line":1,"stream":2}
+`
+	jc.NotifyItem(m, spec)
+	c.Assert(dw.output, Equals, expected)
+}
+
+func (s *MySuite) TestScenarioStart_JSONConsole(c *C) {
+	dw, jc := setupJSONConsole()
+	m := &gauge_messages.Message{MessageType: gauge_messages.Message_ScenarioExecutionStarting,
+		ScenarioExecutionStartingRequest: &gauge_messages.ScenarioExecutionStartingRequest{CurrentExecutionInfo: jsonConsoleExecutionInfo(false)}}
+
 	expected := `{"type":"scenarioStart","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"time":0}}
 `
-	jc.ScenarioStart(scenario, info, &result.ScenarioResult{})
+	jc.NotifyItem(m, jsonConsoleScenario())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -89,36 +193,30 @@ func (s *MySuite) TestScenarioEnd_JSONConsole(c *C) {
 	protoScenario := &gauge_messages.ProtoScenario{
 		ScenarioHeading: "Scenario",
 		Failed:          false,
+		ExecutionTime:   12,
 	}
 
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
+	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"pass","time":12}}
+`
 
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: false,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: false,
-		},
+	jc.NotifyItem(scenarioEndingMessage(jsonConsoleExecutionInfo(false), protoScenario), jsonConsoleScenario())
+	c.Assert(dw.output, Equals, expected)
+}
+
+func (s *MySuite) TestScenarioEndWithSkipErrors_JSONConsole(c *C) {
+	dw, jc := setupJSONConsole()
+
+	protoScenario := &gauge_messages.ProtoScenario{
+		ScenarioHeading: "Scenario",
+		Skipped:         true,
+		SkipErrors:      []string{"Step implementation not found"},
+		ExecutionStatus: gauge_messages.ExecutionStatus_SKIPPED,
 	}
 
-	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"pass","time":0}}
+	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"skip","time":0}}
 `
 
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(scenarioEndingMessage(jsonConsoleExecutionInfo(false), protoScenario), jsonConsoleScenario())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -135,34 +233,10 @@ func (s *MySuite) TestScenarioEndWithPreHookFailure_JSONConsole(c *C) {
 		ExecutionStatus: gauge_messages.ExecutionStatus_FAILED,
 	}
 
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
-
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: true,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: true,
-		},
-	}
-
 	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"fail","time":0,"beforeHookFailure":{"text":"Before Scenario","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}}}
 `
 
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(scenarioEndingMessage(jsonConsoleExecutionInfo(true), protoScenario), jsonConsoleScenario())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -179,34 +253,10 @@ func (s *MySuite) TestScenarioEndWithPostHookFailure_JSONConsole(c *C) {
 		ExecutionStatus: gauge_messages.ExecutionStatus_PASSED,
 	}
 
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
-
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: true,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: true,
-		},
-	}
-
 	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"pass","time":0,"afterHookFailure":{"text":"After Scenario","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}}}
 `
 
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(scenarioEndingMessage(jsonConsoleExecutionInfo(true), protoScenario), jsonConsoleScenario())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -227,34 +277,10 @@ func (s *MySuite) TestScenarioEndWithPreAndPostHookFailure_JSONConsole(c *C) {
 		ExecutionStatus: gauge_messages.ExecutionStatus_FAILED,
 	}
 
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
-
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: true,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: true,
-		},
-	}
-
 	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"fail","time":0,"beforeHookFailure":{"text":"Before Scenario","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"},"afterHookFailure":{"text":"After Scenario","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}}}
 `
 
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(scenarioEndingMessage(jsonConsoleExecutionInfo(true), protoScenario), jsonConsoleScenario())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -282,41 +308,15 @@ func (s *MySuite) TestScenarioEndWithBeforeStepHookFailure_JSONConsole(c *C) {
 		ExecutionStatus: gauge_messages.ExecutionStatus_FAILED,
 	}
 
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
-
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: true,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: true,
-		},
-	}
-
 	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"fail","time":0,"errors":[{"text":"BeforeStep hook for step: Step","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}]}}
 `
 
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(scenarioEndingMessage(jsonConsoleExecutionInfo(true), protoScenario), jsonConsoleScenario())
 	c.Assert(dw.output, Equals, expected)
 }
 
-func (s *MySuite) TestScenarioEndWithStepFailure_JSONConsole(c *C) {
-	dw, jc := setupJSONConsole()
-
-	protoStep := &gauge_messages.ProtoStep{
+func failedProtoStep() *gauge_messages.ProtoStep {
+	return &gauge_messages.ProtoStep{
 		ActualText: "Step",
 		ParsedText: "Step",
 		StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{
@@ -327,53 +327,27 @@ func (s *MySuite) TestScenarioEndWithStepFailure_JSONConsole(c *C) {
 			},
 		},
 	}
+}
 
-	protoScenario := &gauge_messages.ProtoScenario{
+func failedProtoScenario(step *gauge_messages.ProtoStep) *gauge_messages.ProtoScenario {
+	return &gauge_messages.ProtoScenario{
 		ScenarioHeading: "Scenario",
 		Failed:          true,
 		ScenarioItems: []*gauge_messages.ProtoItem{
 			{
 				ItemType: gauge_messages.ProtoItem_Step,
-				Step:     protoStep,
+				Step:     step,
 			},
 		},
 		ExecutionStatus: gauge_messages.ExecutionStatus_FAILED,
 	}
+}
 
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
-
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: false,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: false,
-		},
-		CurrentStep: &gauge_messages.StepInfo{
-			Step: &gauge_messages.ExecuteStepRequest{
-				ActualStepText:  "Step",
-				ParsedStepText:  "Step",
-				ScenarioFailing: false,
-			},
-			IsFailed:   false,
-			StackTrace: "stacktrace",
-		},
-	}
-
-	step := gauge.Step{
+func (s *MySuite) TestScenarioEndWithStepFailure_JSONConsole(c *C) {
+	dw, jc := setupJSONConsole()
+	protoStep := failedProtoStep()
+	info := jsonConsoleExecutionInfo(false)
+	step := &gauge.Step{
 		Value:     "Step",
 		LineNo:    4,
 		LineText:  "Step",
@@ -381,10 +355,8 @@ func (s *MySuite) TestScenarioEndWithStepFailure_JSONConsole(c *C) {
 		Parent:    nil,
 	}
 
-	res := result.NewStepResult(protoStep)
-	res.StepFailed = false
-	jc.StepEnd(step, res, info)
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(stepEndingMessage(info, protoStep), step)
+	jc.NotifyItem(scenarioEndingMessage(info, failedProtoScenario(protoStep)), jsonConsoleScenario())
 
 	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"fail","time":0,"errors":[{"text":"Step","filename":"","message":"message","lineNo":"4","stackTrace":"stacktrace"}]}}
 `
@@ -394,86 +366,26 @@ func (s *MySuite) TestScenarioEndWithStepFailure_JSONConsole(c *C) {
 
 func (s *MySuite) TestScenarioEndWithConceptFailure_JSONConsole(c *C) {
 	dw, jc := setupJSONConsole()
-
-	protoStep := &gauge_messages.ProtoStep{
-		ActualText: "Step",
-		ParsedText: "Step",
-		StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{
-			ExecutionResult: &gauge_messages.ProtoExecutionResult{
-				Failed:       true,
-				ErrorMessage: "message",
-				StackTrace:   "stacktrace",
-			},
-		},
-	}
-
-	protoScenario := &gauge_messages.ProtoScenario{
-		ScenarioHeading: "Scenario",
-		Failed:          true,
-		ScenarioItems: []*gauge_messages.ProtoItem{
-			{
-				ItemType: gauge_messages.ProtoItem_Step,
-				Step:     protoStep,
-			},
-		},
-		ExecutionStatus: gauge_messages.ExecutionStatus_FAILED,
-	}
-
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
-
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: false,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: false,
-		},
-		CurrentStep: &gauge_messages.StepInfo{
-			Step: &gauge_messages.ExecuteStepRequest{
-				ActualStepText:  "Step",
-				ParsedStepText:  "Step",
-				ScenarioFailing: false,
-			},
-			IsFailed:   false,
-			StackTrace: "stacktrace",
-		},
-	}
-
-	step := gauge.Step{
+	protoStep := failedProtoStep()
+	info := jsonConsoleExecutionInfo(false)
+	step := &gauge.Step{
 		Value:     "Step",
 		LineNo:    4,
 		LineText:  "Step",
 		IsConcept: true,
 		Parent:    nil,
 	}
-
-	step2 := gauge.Step{
+	step2 := &gauge.Step{
 		Value:     "Step 2",
 		LineNo:    2,
 		LineText:  "Step 2",
 		IsConcept: false,
-		Parent:    &step,
+		Parent:    step,
 	}
+	step.ConceptSteps = []*gauge.Step{step2}
 
-	step.ConceptSteps = []*gauge.Step{&step2}
-
-	res := result.NewStepResult(protoStep)
-	res.StepFailed = false
-	jc.StepEnd(step2, res, info)
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(stepEndingMessage(info, protoStep), step2)
+	jc.NotifyItem(scenarioEndingMessage(info, failedProtoScenario(protoStep)), jsonConsoleScenario())
 
 	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"fail","time":0,"errors":[{"text":"Step","filename":"","message":"message","lineNo":"2","stackTrace":"stacktrace"}]}}
 `
@@ -481,6 +393,17 @@ func (s *MySuite) TestScenarioEndWithConceptFailure_JSONConsole(c *C) {
 	c.Assert(dw.output, Equals, expected)
 }
 
+func (s *MySuite) TestScenarioEndForgetsTheStepsOfTheScenario_JSONConsole(c *C) {
+	_, jc := setupJSONConsole()
+	protoStep := failedProtoStep()
+	info := jsonConsoleExecutionInfo(false)
+
+	jc.NotifyItem(stepEndingMessage(info, protoStep), &gauge.Step{Value: "Step", LineNo: 4})
+	jc.NotifyItem(scenarioEndingMessage(info, failedProtoScenario(protoStep)), jsonConsoleScenario())
+
+	c.Assert(jc.stepCache, HasLen, 0)
+}
+
 func (s *MySuite) TestScenarioEndWithAfterStepHookFailure_JSONConsole(c *C) {
 	dw, jc := setupJSONConsole()
 
@@ -505,34 +428,10 @@ func (s *MySuite) TestScenarioEndWithAfterStepHookFailure_JSONConsole(c *C) {
 		ExecutionStatus: gauge_messages.ExecutionStatus_FAILED,
 	}
 
-	scenario := &gauge.Scenario{
-		Heading: &gauge.Heading{
-			Value:       "Scenario",
-			LineNo:      2,
-			HeadingType: 1,
-		},
-		Span: &gauge.Span{
-			Start: 2,
-			End:   3,
-		},
-	}
-
-	info := &gauge_messages.ExecutionInfo{
-		CurrentSpec: &gauge_messages.SpecInfo{
-			Name:     "Specification",
-			FileName: "file",
-			IsFailed: true,
-		},
-		CurrentScenario: &gauge_messages.ScenarioInfo{
-			Name:     "Scenario",
-			IsFailed: true,
-		},
-	}
-
 	expected := `{"type":"scenarioEnd","id":"file:2","parentId":"file","name":"Scenario","filename":"file","line":2,"result":{"status":"fail","time":0,"errors":[{"text":"AfterStep hook for step: Step","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}]}}
 `
 
-	jc.ScenarioEnd(scenario, &result.ScenarioResult{ProtoScenario: protoScenario}, info)
+	jc.NotifyItem(scenarioEndingMessage(jsonConsoleExecutionInfo(true), protoScenario), jsonConsoleScenario())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -542,27 +441,9 @@ func (s *MySuite) TestSpecEnd_JSONConsole(c *C) {
 		FileName:    "file",
 		SpecHeading: "Specification",
 	}
-	scenarios := []*gauge.Scenario{
-		{
-			Heading: &gauge.Heading{
-				Value:       "Scenario",
-				LineNo:      2,
-				HeadingType: 1,
-			},
-		},
-	}
-	spec := &gauge.Specification{
-		FileName: "file",
-		Heading: &gauge.Heading{
-			Value:       "Specification",
-			LineNo:      1,
-			HeadingType: 0,
-		},
-		Scenarios: scenarios,
-	}
 	expected := `{"type":"specEnd","id":"file","name":"Specification","filename":"file","line":1,"result":{"status":"pass","time":0}}
 `
-	jc.SpecEnd(spec, &result.SpecResult{ProtoSpec: protoSpec})
+	jc.NotifyItem(specEndingMessage(&gauge_messages.ProtoSpecResult{ProtoSpec: protoSpec}), jsonConsoleSpec())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -578,32 +459,14 @@ func (s *MySuite) TestSpecEndWithPreHookFailure_JSONConsole(c *C) {
 			},
 		},
 	}
-	scenarios := []*gauge.Scenario{
-		{
-			Heading: &gauge.Heading{
-				Value:       "Scenario",
-				LineNo:      2,
-				HeadingType: 1,
-			},
-		},
-	}
-	spec := &gauge.Specification{
-		FileName: "file",
-		Heading: &gauge.Heading{
-			Value:       "Specification",
-			LineNo:      1,
-			HeadingType: 0,
-		},
-		Scenarios: scenarios,
-	}
 	expected := `{"type":"specEnd","id":"file","name":"Specification","filename":"file","line":1,"result":{"status":"fail","time":0,"beforeHookFailure":{"text":"Before Specification","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}}}
 `
-	res := &result.SpecResult{
+	res := &gauge_messages.ProtoSpecResult{
 		ProtoSpec: protoSpec,
-		IsFailed:  true,
+		Failed:    true,
 	}
 
-	jc.SpecEnd(spec, res)
+	jc.NotifyItem(specEndingMessage(res), jsonConsoleSpec())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -619,32 +482,14 @@ func (s *MySuite) TestSpecEndWithPostHookFailure_JSONConsole(c *C) {
 			},
 		},
 	}
-	scenarios := []*gauge.Scenario{
-		{
-			Heading: &gauge.Heading{
-				Value:       "Scenario",
-				LineNo:      2,
-				HeadingType: 1,
-			},
-		},
-	}
-	spec := &gauge.Specification{
-		FileName: "file",
-		Heading: &gauge.Heading{
-			Value:       "Specification",
-			LineNo:      1,
-			HeadingType: 0,
-		},
-		Scenarios: scenarios,
-	}
 	expected := `{"type":"specEnd","id":"file","name":"Specification","filename":"file","line":1,"result":{"status":"fail","time":0,"afterHookFailure":{"text":"After Specification","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}}}
 `
-	res := &result.SpecResult{
+	res := &gauge_messages.ProtoSpecResult{
 		ProtoSpec: protoSpec,
-		IsFailed:  true,
+		Failed:    true,
 	}
 
-	jc.SpecEnd(spec, res)
+	jc.NotifyItem(specEndingMessage(res), jsonConsoleSpec())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -666,32 +511,14 @@ func (s *MySuite) TestSpecEndWithPreAndPostHookFailure_JSONConsole(c *C) {
 			},
 		},
 	}
-	scenarios := []*gauge.Scenario{
-		{
-			Heading: &gauge.Heading{
-				Value:       "Scenario",
-				LineNo:      2,
-				HeadingType: 1,
-			},
-		},
-	}
-	spec := &gauge.Specification{
-		FileName: "file",
-		Heading: &gauge.Heading{
-			Value:       "Specification",
-			LineNo:      1,
-			HeadingType: 0,
-		},
-		Scenarios: scenarios,
-	}
 	expected := `{"type":"specEnd","id":"file","name":"Specification","filename":"file","line":1,"result":{"status":"fail","time":0,"beforeHookFailure":{"text":"Before Specification","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"},"afterHookFailure":{"text":"After Specification","filename":"","message":"message","lineNo":"","stackTrace":"stacktrace"}}}
 `
-	res := &result.SpecResult{
+	res := &gauge_messages.ProtoSpecResult{
 		ProtoSpec: protoSpec,
-		IsFailed:  true,
+		Failed:    true,
 	}
 
-	jc.SpecEnd(spec, res)
+	jc.NotifyItem(specEndingMessage(res), jsonConsoleSpec())
 	c.Assert(dw.output, Equals, expected)
 }
 
@@ -701,84 +528,75 @@ func (s *MySuite) TestSpecEndWithNoScenariosInSpec_JSONConsole(c *C) {
 		FileName:    "file",
 		SpecHeading: "Specification",
 	}
+	spec := jsonConsoleSpec()
+	spec.Scenarios = []*gauge.Scenario{}
 
-	scenarios := []*gauge.Scenario{}
-
-	spec := &gauge.Specification{
-		FileName: "file",
-		Heading: &gauge.Heading{
-			Value:       "Specification",
-			LineNo:      1,
-			HeadingType: 0,
-		},
-		Scenarios: scenarios,
-	}
 	expected := `{"type":"specEnd","id":"file","name":"Specification","filename":"file","line":1,"result":{"status":"skip","time":0}}
 `
-	res := &result.SpecResult{
+	res := &gauge_messages.ProtoSpecResult{
 		ProtoSpec: protoSpec,
 		Skipped:   true,
 	}
 
-	jc.SpecEnd(spec, res)
+	jc.NotifyItem(specEndingMessage(res), spec)
 	c.Assert(dw.output, Equals, expected)
 }
 
 func (s *MySuite) TestSuiteEnd_JSONConsole(c *C) {
 	dw, jc := setupJSONConsole()
 
-	jc.SuiteEnd(&result.SuiteResult{})
+	jc.Notify(suiteResultMessage(&gauge_messages.ProtoSuiteResult{}))
 	c.Assert(dw.output, Equals, "{\"type\":\"suiteEnd\",\"result\":{\"status\":\"pass\",\"time\":0}}\n")
 }
 
 func (s *MySuite) TestSuiteEndWithBeforeHookFailure_JSONConsole(c *C) {
 	dw, jc := setupJSONConsole()
 
-	res := &result.SuiteResult{
-		PreSuite: &gauge_messages.ProtoHookFailure{
+	res := &gauge_messages.ProtoSuiteResult{
+		PreHookFailure: &gauge_messages.ProtoHookFailure{
 			StackTrace:   "stack trace",
 			ErrorMessage: "message",
 		},
-		IsFailed: true,
+		Failed: true,
 	}
 	expected := `{"type":"suiteEnd","result":{"status":"fail","time":0,"beforeHookFailure":{"text":"Before Suite","filename":"","message":"message","lineNo":"","stackTrace":"stack trace"}}}
 `
-	jc.SuiteEnd(res)
+	jc.Notify(suiteResultMessage(res))
 	c.Assert(dw.output, Equals, expected)
 }
 
 func (s *MySuite) TestSuiteEndWithAfterHookFailure_JSONConsole(c *C) {
 	dw, jc := setupJSONConsole()
 
-	res := &result.SuiteResult{
-		PostSuite: &gauge_messages.ProtoHookFailure{
+	res := &gauge_messages.ProtoSuiteResult{
+		PostHookFailure: &gauge_messages.ProtoHookFailure{
 			StackTrace:   "stack trace",
 			ErrorMessage: "message",
 		},
-		PreSuite: &gauge_messages.ProtoHookFailure{
+		PreHookFailure: &gauge_messages.ProtoHookFailure{
 			StackTrace:   "stack trace",
 			ErrorMessage: "message",
 		},
-		IsFailed: true,
+		Failed: true,
 	}
 	expected := `{"type":"suiteEnd","result":{"status":"fail","time":0,"beforeHookFailure":{"text":"Before Suite","filename":"","message":"message","lineNo":"","stackTrace":"stack trace"},"afterHookFailure":{"text":"After Suite","filename":"","message":"message","lineNo":"","stackTrace":"stack trace"}}}
 `
-	jc.SuiteEnd(res)
+	jc.Notify(suiteResultMessage(res))
 	c.Assert(dw.output, Equals, expected)
 }
 
 func (s *MySuite) TestSuiteEndWithBeforeAndAfterHookFailure_JSONConsole(c *C) {
 	dw, jc := setupJSONConsole()
 
-	res := &result.SuiteResult{
-		PostSuite: &gauge_messages.ProtoHookFailure{
+	res := &gauge_messages.ProtoSuiteResult{
+		PostHookFailure: &gauge_messages.ProtoHookFailure{
 			StackTrace:   "stack trace",
 			ErrorMessage: "message",
 		},
-		IsFailed: true,
+		Failed: true,
 	}
 	expected := `{"type":"suiteEnd","result":{"status":"fail","time":0,"afterHookFailure":{"text":"After Suite","filename":"","message":"message","lineNo":"","stackTrace":"stack trace"}}}
 `
-	jc.SuiteEnd(res)
+	jc.Notify(suiteResultMessage(res))
 	c.Assert(dw.output, Equals, expected)
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/getgauge/gauge/formatter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/plugin"
 )
 
 // IsParallel represents console reporting format based on simple/parallel execution
@@ -37,7 +38,10 @@ var Verbose bool
 // MachineReadable represents if output should be in JSON format.
 var MachineReadable bool
 
-const newline = "\n"
+const (
+	newline                 = "\n"
+	jsonConsoleListenerName = "json-console"
+)
 
 // Reporter reports the progress of spec execution. It reports
 // 1. Which spec / scenarion / step (if verbose) is currently executing.
@@ -72,9 +76,7 @@ func reporter(e event.ExecutionEvent) Reporter {
 // Current returns the current instance of Reporter, if present. Else, it returns a new Reporter.
 func Current() Reporter {
 	if currentReporter == nil {
-		if MachineReadable {
-			currentReporter = newJSONConsole(os.Stdout, IsParallel, 0)
-		} else if SimpleConsoleOutput {
+		if SimpleConsoleOutput {
 			currentReporter = newSimpleConsole(os.Stdout)
 		} else if Verbose {
 			currentReporter = newVerboseColoredConsole(os.Stdout)
@@ -106,17 +108,19 @@ var parallelReporters map[int]Reporter
 func initParallelReporters() {
 	parallelReporters = make(map[int]Reporter, NumberOfExecutionStreams)
 	for i := 1; i <= NumberOfExecutionStreams; i++ {
-		if MachineReadable {
-			parallelReporters[i] = newJSONConsole(os.Stdout, true, i)
-		} else {
-			writer := &parallelReportWriter{nRunner: i}
-			parallelReporters[i] = newSimpleConsole(writer)
-		}
+		writer := &parallelReportWriter{nRunner: i}
+		parallelReporters[i] = newSimpleConsole(writer)
 	}
 }
 
-// ListenExecutionEvents listens to all execution events for reporting on console
+// ListenExecutionEvents listens to all execution events for reporting on console. The output of a machine readable
+// execution is written by a listener of the execution messages instead.
 func ListenExecutionEvents(wg *sync.WaitGroup) {
+	plugin.UnregisterListener(jsonConsoleListenerName)
+	if MachineReadable {
+		plugin.RegisterListener(jsonConsoleListenerName, 0, newJSONConsole(os.Stdout, IsParallel))
+		return
+	}
 	ch := make(chan event.ExecutionEvent)
 	initParallelReporters()
 	event.Register(ch, event.SuiteStart, event.SpecStart, event.SpecEnd, event.ScenarioStart, event.ScenarioEnd, event.StepStart, event.StepEnd, event.ConceptStart, event.ConceptEnd, event.SuiteEnd)
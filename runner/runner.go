@@ -143,7 +143,7 @@ func getPluginPaths() (paths []string) {
 }
 
 func getLanguageJSONFilePath(manifest *manifest.Manifest, r *RunnerInfo) (string, error) {
-	languageJSONFilePath, err := plugin.GetLanguageJSONFilePath(manifest.Language)
+	languageJSONFilePath, err := plugin.LanguageJSONFilePathToRun(manifest)
 	if err != nil {
 		return "", err
 	}
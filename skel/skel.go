@@ -34,6 +34,9 @@ csv_delimiter = ,
 
 # Allows steps to be written in multiline
 allow_multiline_step = false
+
+# Set to true to reuse the parsed specs and concepts of unchanged files from .gauge/cache
+enable_parse_cache = false
 `
 var ExampleSpec = `# Specification Heading
 
@@ -7,11 +7,14 @@
 package util
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/getgauge/gauge/logger"
 
@@ -81,3 +84,35 @@ func Download(url, targetDir, fileName string, silent bool) (string, error) {
 	}
 	return targetFile, err
 }
+
+// PostJSON fires a HTTP POST request with the JSON body. Each attempt is cut off after the timeout. A failed request, or
+// one the server responds to with 408, 429 or 5xx, is retried up to the given number of times with a backoff starting
+// at the given duration and doubling after each attempt.
+func PostJSON(url string, body []byte, timeout time.Duration, retries int, backoff time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+	for attempt := 0; ; attempt++ {
+		retry, err := postJSON(client, url, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= retries {
+			return err
+		}
+		logger.Debugf(true, "Retrying POST to %s. %s", url, err.Error())
+		time.Sleep(backoff << uint(attempt))
+	}
+}
+
+func postJSON(client *http.Client, url string, body []byte) (bool, error) {
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("Error posting to %s.\n%s", url, resp.Status)
+}
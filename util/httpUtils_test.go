@@ -8,11 +8,14 @@ package util
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/getgauge/common"
 	. "gopkg.in/check.v1"
@@ -97,3 +100,58 @@ func (s *MySuite) TestDownloadSuccess(c *C) {
 	c.Assert(err, Equals, nil)
 	c.Assert(actualFileContents, Equals, expectedFileContents)
 }
+
+func (s *MySuite) TestPostJSONRetriesServerErrors(c *C) {
+	attempts := 0
+	var body string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	err := PostJSON(server.URL, []byte(`{"event":"suiteEnd"}`), time.Second, 3, time.Millisecond)
+
+	c.Assert(err, IsNil)
+	c.Assert(attempts, Equals, 3)
+	c.Assert(body, Equals, `{"event":"suiteEnd"}`)
+}
+
+func (s *MySuite) TestPostJSONDoesNotRetryClientErrors(c *C) {
+	attempts := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	err := PostJSON(server.URL, []byte(`{}`), time.Second, 3, time.Millisecond)
+
+	c.Assert(err, NotNil)
+	c.Assert(attempts, Equals, 1)
+}
+
+func (s *MySuite) TestPostJSONGivesUpAfterTimeouts(c *C) {
+	var attempts int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	err := PostJSON(server.URL, []byte(`{}`), 10*time.Millisecond, 2, time.Millisecond)
+
+	c.Assert(err, NotNil)
+	c.Assert(atomic.LoadInt32(&attempts), Equals, int32(3))
+}
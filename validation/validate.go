@@ -25,6 +25,7 @@ import (
 	gm "github.com/getgauge/gauge-proto/go/gauge_messages"
 	"github.com/getgauge/gauge/api"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/i18n"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/runner"
@@ -189,6 +190,13 @@ func ValidateSpecs(args []string, debug bool) *ValidationResult {
 	return NewValidationResult(gauge.NewSpecCollection(s, false), errMap, r, true)
 }
 
+// ValidateSpecsWithRunner validates parsed specs with an already started runner. It returns the specs to execute,
+// one per data table row, and the errors which skip their scenarios.
+func ValidateSpecsWithRunner(specs []*gauge.Specification, r runner.Runner, conceptDict *gauge.ConceptDictionary) ([]*gauge.Specification, *gauge.BuildErrors) {
+	errMap := getErrMap(gauge.NewBuildErrors(), NewValidator(specs, r, conceptDict).Validate())
+	return parser.GetSpecsForDataTableRows(specs, errMap), errMap
+}
+
 func getErrMap(errMap *gauge.BuildErrors, validationErrors validationErrors) *gauge.BuildErrors {
 	for spec, valErrors := range validationErrors {
 		for _, err := range valErrors {
@@ -349,7 +357,7 @@ func (v *SpecValidator) validateStep(s *gauge.Step) error {
 	if r.GetMessageType() == gm.Message_StepValidateResponse {
 		res := r.GetStepValidateResponse()
 		if !res.GetIsValid() {
-			msg := getMessage(res.GetErrorType().String())
+			msg := i18n.T(getMessage(res.GetErrorType().String()))
 			suggestion := res.GetSuggestion()
 			if s.Parent == nil {
 				vErr := NewStepValidationError(s, msg, v.specification.FileName, &res.ErrorType, suggestion)
@@ -362,7 +370,7 @@ func (v *SpecValidator) validateStep(s *gauge.Step) error {
 		}
 		return nil
 	}
-	return NewStepValidationError(s, "Invalid response from runner for Validation request", v.specification.FileName, &invalidResponse, "")
+	return NewStepValidationError(s, i18n.T("Invalid response from runner for Validation request"), v.specification.FileName, &invalidResponse, "")
 }
 
 func getMessage(message string) string {
@@ -0,0 +1,94 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint restricts the versions of a plugin to a range, lower bound inclusive and upper bound exclusive.
+// It is written as an exact version (4.1.2), a caret range (^4.1, any 4.x from 4.1.0), a tilde range (~4.1.2, any
+// 4.1.x from 4.1.2), a lower bound (>=4.1) or * for any version. Missing minor and patch versions are taken as 0.
+type Constraint struct {
+	text  string
+	lower *Version
+	upper *Version
+}
+
+// ParseConstraint parses the version constraint of a plugin. An empty constraint allows any version.
+func ParseConstraint(text string) (*Constraint, error) {
+	text = strings.TrimSpace(text)
+	c := &Constraint{text: text}
+	if text == "" || text == "*" {
+		return c, nil
+	}
+	var err error
+	switch {
+	case strings.HasPrefix(text, "^"):
+		if c.lower, err = parsePartialVersion(text[1:]); err != nil {
+			return nil, err
+		}
+		c.upper = &Version{c.lower.Major + 1, 0, 0}
+		if c.lower.Major == 0 {
+			c.upper = &Version{0, c.lower.Minor + 1, 0}
+		}
+	case strings.HasPrefix(text, "~"):
+		if c.lower, err = parsePartialVersion(text[1:]); err != nil {
+			return nil, err
+		}
+		c.upper = &Version{c.lower.Major, c.lower.Minor + 1, 0}
+	case strings.HasPrefix(text, ">="):
+		if c.lower, err = parsePartialVersion(text[2:]); err != nil {
+			return nil, err
+		}
+	default:
+		if c.lower, err = ParseVersion(text); err != nil {
+			return nil, fmt.Errorf("invalid version constraint %s. %s", text, err.Error())
+		}
+		c.upper = &Version{c.lower.Major, c.lower.Minor, c.lower.Patch + 1}
+	}
+	return c, nil
+}
+
+func parsePartialVersion(text string) (*Version, error) {
+	text = strings.TrimSpace(text)
+	for strings.Count(text, ".") < 2 {
+		text += ".0"
+	}
+	v, err := ParseVersion(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %s. %s", text, err.Error())
+	}
+	return v, nil
+}
+
+// Allows checks if the version satisfies the constraint.
+func (c *Constraint) Allows(v *Version) bool {
+	if c.lower != nil && v.IsLesserThan(c.lower) {
+		return false
+	}
+	return c.upper == nil || v.IsLesserThan(c.upper)
+}
+
+// Latest returns the latest of the versions which satisfies the constraint, or nil if none does.
+func (c *Constraint) Latest(versions []*Version) *Version {
+	var latest *Version
+	for _, v := range versions {
+		if c.Allows(v) && (latest == nil || v.IsGreaterThan(latest)) {
+			latest = v
+		}
+	}
+	return latest
+}
+
+func (c *Constraint) String() string {
+	if c.text == "" {
+		return "*"
+	}
+	return c.text
+}
@@ -0,0 +1,60 @@
+/*----------------------------------------------------------------
+ *  Copyright (c) ThoughtWorks, Inc.
+ *  Licensed under the Apache License, Version 2.0
+ *  See LICENSE in the project root for license information.
+ *----------------------------------------------------------------*/
+
+package version
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func allows(c *C, constraint, v string) bool {
+	con, err := ParseConstraint(constraint)
+	c.Assert(err, IsNil)
+	version, err := ParseVersion(v)
+	c.Assert(err, IsNil)
+	return con.Allows(version)
+}
+
+func (s *MySuite) TestCaretConstraintAllowsVersionsOfSameMajor(c *C) {
+	c.Assert(allows(c, "^4.1", "4.1.0"), Equals, true)
+	c.Assert(allows(c, "^4.1", "4.9.3"), Equals, true)
+	c.Assert(allows(c, "^4.1", "4.0.9"), Equals, false)
+	c.Assert(allows(c, "^4.1", "5.0.0"), Equals, false)
+	c.Assert(allows(c, "^0.3.1", "0.3.9"), Equals, true)
+	c.Assert(allows(c, "^0.3.1", "0.4.0"), Equals, false)
+}
+
+func (s *MySuite) TestTildeConstraintAllowsVersionsOfSameMinor(c *C) {
+	c.Assert(allows(c, "~4.1.2", "4.1.5"), Equals, true)
+	c.Assert(allows(c, "~4.1.2", "4.1.1"), Equals, false)
+	c.Assert(allows(c, "~4.1.2", "4.2.0"), Equals, false)
+}
+
+func (s *MySuite) TestExactAndLowerBoundConstraints(c *C) {
+	c.Assert(allows(c, "4.1.2", "4.1.2"), Equals, true)
+	c.Assert(allows(c, "4.1.2", "4.1.3"), Equals, false)
+	c.Assert(allows(c, ">=4.1", "12.0.0"), Equals, true)
+	c.Assert(allows(c, ">=4.1", "4.0.12"), Equals, false)
+	c.Assert(allows(c, "*", "0.0.1"), Equals, true)
+	c.Assert(allows(c, "", "99.0.0"), Equals, true)
+}
+
+func (s *MySuite) TestParsingInvalidConstraint(c *C) {
+	_, err := ParseConstraint("^four")
+	c.Assert(err, NotNil)
+
+	_, err = ParseConstraint("4.1")
+	c.Assert(err, ErrorMatches, "invalid version constraint 4.1. .*")
+}
+
+func (s *MySuite) TestLatestVersionAllowedByConstraint(c *C) {
+	con, err := ParseConstraint("^1.2")
+	c.Assert(err, IsNil)
+	versions := []*Version{{1, 1, 0}, {1, 4, 2}, {2, 0, 0}, {1, 3, 9}}
+
+	c.Assert(con.Latest(versions).String(), Equals, "1.4.2")
+	c.Assert(con.Latest(versions[:1]), IsNil)
+}